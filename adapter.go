@@ -0,0 +1,65 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"net/http"
+)
+
+// WrapH adapts an http.Handler to a HandlerFunc. Route parameters are
+// available to h through Request.PathValue.
+func WrapH(h http.Handler) HandlerFunc {
+	return func(c *Context) {
+		for k, v := range c.params {
+			c.Request.SetPathValue(k, v)
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// WrapF adapts an http.HandlerFunc to a HandlerFunc, like WrapH.
+func WrapF(f http.HandlerFunc) HandlerFunc {
+	return WrapH(f)
+}
+
+// ToHTTPHandler adapts h to an http.Handler, to mount sol handlers on
+// other muxes. h runs outside any Sol: engine features such as custom
+// error handlers, templates and events are unavailable to it.
+func ToHTTPHandler(h HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c := &Context{Request: req, handlers: []HandlerFunc{h}, index: -1}
+		c.rw.reset(w)
+		c.Writer = &c.rw
+		c.Next()
+	})
+}
+
+type adapterKey struct{}
+
+// WrapMiddleware adapts net/http middleware, such as chi's, to sol:
+//
+//	sl.Use(sol.WrapMiddleware(middleware.RealIP))
+//
+// The rest of the chain runs as m's next handler, with the writer and
+// request m passes it; both are restored when it returns. If m answers
+// without calling next, the chain is aborted.
+func WrapMiddleware(m func(http.Handler) http.Handler) HandlerFunc {
+	h := m(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c := req.Context().Value(adapterKey{}).(*Context)
+		w0, req0 := c.Writer, c.Request
+		c.Writer, c.Request = w, req
+		c.Next()
+		c.Writer, c.Request = w0, req0
+	}))
+
+	return func(c *Context) {
+		index := c.index
+		h.ServeHTTP(c.Writer, c.Request.WithContext(context.WithValue(c.Context(), adapterKey{}, c)))
+		if c.index == index {
+			c.Abort()
+		}
+	}
+}
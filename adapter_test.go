@@ -0,0 +1,93 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ctxKey string
+
+func TestWrapH(t *testing.T) {
+	sl := New()
+	sl.GET("/users/:id", WrapF(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "user "+r.PathValue("id"))
+	}))
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+	if rec.Body.String() != "user 7" {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
+
+func TestToHTTPHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/hello", ToHTTPHandler(func(c *Context) {
+		c.String(http.StatusCreated, "hello %s", c.QueryParam("name"))
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=sol", nil))
+	if rec.Code != http.StatusCreated || rec.Body.String() != "hello sol" {
+		t.Errorf("got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestWrapMiddleware(t *testing.T) {
+	withValue := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Wrapped", "yes")
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey("user"), "ada")))
+		})
+	}
+	deny := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/private") {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	var after []string
+	sl := New()
+	sl.Use(func(c *Context) {
+		c.Next()
+		after = append(after, c.Request.URL.Path)
+	})
+	sl.Use(WrapMiddleware(withValue), WrapMiddleware(deny))
+	handler := func(c *Context) {
+		user, _ := c.Context().Value(ctxKey("user")).(string)
+		c.String(http.StatusOK, "%s", user)
+	}
+	sl.GET("/public", handler)
+	sl.GET("/private", handler)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/public", http.StatusOK, "ada"},
+		{"/private", http.StatusForbidden, "forbidden\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status || rec.Body.String() != tt.body || rec.Header().Get("X-Wrapped") != "yes" {
+			t.Errorf("%s: got %d %q", tt.path, rec.Code, rec.Body.String())
+		}
+	}
+	if len(after) != 2 {
+		t.Errorf("outer middleware ran %d times, want 2", len(after))
+	}
+}
@@ -0,0 +1,289 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// adminHistory is the number of recent errors and slow requests kept.
+const adminHistory = 50
+
+// AdminConfig configures the admin dashboard.
+type AdminConfig struct {
+	// SlowThreshold marks requests taking longer as slow, 1s by default.
+	SlowThreshold time.Duration
+}
+
+// RequestRecord describes a request seen by the admin dashboard.
+type RequestRecord struct {
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	ClientIP string        `json:"client_ip"`
+	Status   int           `json:"status,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// admin tracks in-flight and recent requests for the dashboard.
+type admin struct {
+	cfg     AdminConfig
+	started time.Time
+	total   atomic.Uint64
+	nextID  atomic.Uint64
+	active  sync.Map // uint64 -> *RequestRecord
+
+	// mu guards errors and slow
+	mu     sync.Mutex
+	errors []RequestRecord
+	slow   []RequestRecord
+}
+
+// EnableAdmin mounts a debug dashboard under prefix showing registered
+// routes, middleware chains, in-flight requests, runtime statistics,
+// recent server errors and slow requests. The auth handlers guard it;
+// the dashboard exposes internals and must not be left open, so
+// EnableAdmin panics when none are given.
+func (sl *Sol) EnableAdmin(prefix string, auth ...HandlerFunc) {
+	sl.EnableAdminWith(prefix, AdminConfig{}, auth...)
+}
+
+// EnableAdminWith is EnableAdmin with explicit configuration.
+func (sl *Sol) EnableAdminWith(prefix string, cfg AdminConfig, auth ...HandlerFunc) {
+	if len(auth) == 0 {
+		panic(fmt.Sprintf("cannot register '%s': admin dashboard needs an auth handler", prefix))
+	}
+	if cfg.SlowThreshold <= 0 {
+		cfg.SlowThreshold = time.Second
+	}
+	sl.admin = &admin{cfg: cfg, started: time.Now()}
+
+	g := sl.Group(prefix, auth...)
+	g.GET("/", func(c *Context) {
+		c.SetHeader("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		adminTmpl.Execute(c.Writer, sl.adminSnapshot())
+	})
+	g.GET("/api", func(c *Context) {
+		c.JSON(http.StatusOK, sl.adminSnapshot())
+	})
+}
+
+// ServeHTTP dispatches the request to the router, recording it for the
+// admin dashboard when enabled.
+func (sl *Sol) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if sl.admin == nil {
+		sl.router.ServeHTTP(w, req)
+		return
+	}
+	sl.admin.serve(sl.router, w, req)
+}
+
+func (a *admin) serve(next http.Handler, w http.ResponseWriter, req *http.Request) {
+	id := a.nextID.Add(1)
+	rec := &RequestRecord{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		ClientIP: ClientIP(req),
+		Start:    time.Now(),
+	}
+	a.total.Add(1)
+	a.active.Store(id, rec)
+
+	sw := &statusWriter{ResponseWriter: w}
+	defer func() {
+		a.active.Delete(id)
+
+		done := *rec
+		done.Status = sw.status
+		if done.Status == 0 {
+			done.Status = http.StatusOK
+		}
+		done.Duration = time.Since(rec.Start)
+
+		a.mu.Lock()
+		if done.Status >= http.StatusInternalServerError {
+			a.errors = appendRecent(a.errors, done)
+		}
+		if done.Duration >= a.cfg.SlowThreshold {
+			a.slow = appendRecent(a.slow, done)
+		}
+		a.mu.Unlock()
+	}()
+
+	next.ServeHTTP(sw, req)
+}
+
+func appendRecent(list []RequestRecord, r RequestRecord) []RequestRecord {
+	list = append(list, r)
+	if len(list) > adminHistory {
+		list = list[len(list)-adminHistory:]
+	}
+	return list
+}
+
+// statusWriter records the response status code.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// AdminRoute is a route as shown on the admin dashboard.
+type AdminRoute struct {
+	Method   string   `json:"method"`
+	Path     string   `json:"path"`
+	Handlers []string `json:"handlers"`
+}
+
+// AdminSnapshot is the data shown on the admin dashboard.
+type AdminSnapshot struct {
+	Uptime        string          `json:"uptime"`
+	TotalRequests uint64          `json:"total_requests"`
+	Goroutines    int             `json:"goroutines"`
+	HeapAlloc     uint64          `json:"heap_alloc"`
+	HeapObjects   uint64          `json:"heap_objects"`
+	NumGC         uint32          `json:"num_gc"`
+	ActiveConns   int64           `json:"active_conns"`
+	IdleConns     int64           `json:"idle_conns"`
+	Routes        []AdminRoute    `json:"routes"`
+	Active        []RequestRecord `json:"active"`
+	Errors        []RequestRecord `json:"errors"`
+	Slow          []RequestRecord `json:"slow"`
+}
+
+func (sl *Sol) adminSnapshot() AdminSnapshot {
+	a := sl.admin
+	st := sl.serverStats()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	snap := AdminSnapshot{
+		Uptime:        time.Since(a.started).Round(time.Second).String(),
+		TotalRequests: a.total.Load(),
+		Goroutines:    runtime.NumGoroutine(),
+		HeapAlloc:     ms.HeapAlloc,
+		HeapObjects:   ms.HeapObjects,
+		NumGC:         ms.NumGC,
+		ActiveConns:   st.connsActive.Load(),
+		IdleConns:     st.connsIdle.Load(),
+	}
+
+	for _, rt := range sl.Routes() {
+		snap.Routes = append(snap.Routes, AdminRoute{
+			Method:   rt.Method(),
+			Path:     rt.Path(),
+			Handlers: rt.Handlers(),
+		})
+	}
+
+	now := time.Now()
+	a.active.Range(func(_, v any) bool {
+		r := *v.(*RequestRecord)
+		r.Duration = now.Sub(r.Start)
+		snap.Active = append(snap.Active, r)
+		return true
+	})
+	sort.Slice(snap.Active, func(i, j int) bool {
+		return snap.Active[i].Start.Before(snap.Active[j].Start)
+	})
+
+	a.mu.Lock()
+	snap.Errors = append([]RequestRecord(nil), a.errors...)
+	snap.Slow = append([]RequestRecord(nil), a.slow...)
+	a.mu.Unlock()
+
+	return snap
+}
+
+// handlerName returns the function name of h for debugging output.
+func handlerName(h HandlerFunc) string {
+	name := funcName(reflect.ValueOf(h).Pointer())
+	// Trim the import path, keeping "package.Func".
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// funcName returns the fully qualified name of the function at pc.
+func funcName(pc uintptr) string {
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	return fn.Name()
+}
+
+var adminTmpl = template.Must(template.New("admin").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Sol admin</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; font-size: 14px; }
+code { font-size: 12px; color: #555; }
+</style>
+</head>
+<body>
+<h1>🌌 Sol</h1>
+<p>Uptime {{.Uptime}} · {{.TotalRequests}} requests · {{.Goroutines}} goroutines · heap {{.HeapAlloc}} bytes ({{.HeapObjects}} objects) · {{.NumGC}} GC cycles · {{.ActiveConns}} active / {{.IdleConns}} idle connections</p>
+
+<h2>Active requests</h2>
+<table>
+<tr><th>Method</th><th>Path</th><th>Client</th><th>Running</th></tr>
+{{range .Active}}<tr><td>{{.Method}}</td><td>{{.Path}}</td><td>{{.ClientIP}}</td><td>{{.Duration}}</td></tr>
+{{end}}</table>
+
+<h2>Recent errors</h2>
+<table>
+<tr><th>Time</th><th>Status</th><th>Method</th><th>Path</th><th>Duration</th></tr>
+{{range .Errors}}<tr><td>{{.Start.Format "15:04:05"}}</td><td>{{.Status}}</td><td>{{.Method}}</td><td>{{.Path}}</td><td>{{.Duration}}</td></tr>
+{{end}}</table>
+
+<h2>Slow requests</h2>
+<table>
+<tr><th>Time</th><th>Status</th><th>Method</th><th>Path</th><th>Duration</th></tr>
+{{range .Slow}}<tr><td>{{.Start.Format "15:04:05"}}</td><td>{{.Status}}</td><td>{{.Method}}</td><td>{{.Path}}</td><td>{{.Duration}}</td></tr>
+{{end}}</table>
+
+<h2>Routes</h2>
+<table>
+<tr><th>Method</th><th>Path</th><th>Handlers</th></tr>
+{{range .Routes}}<tr><td>{{.Method}}</td><td>{{.Path}}</td><td>{{range .Handlers}}<code>{{.}}</code><br>{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
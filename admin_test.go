@@ -0,0 +1,74 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminSnapshot(t *testing.T) {
+	sl := New()
+	sl.EnableAdmin("/_sol", func(c *Context) {
+		if c.Header("X-Admin") != "yes" {
+			c.Status(http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+		c.Next()
+	})
+	sl.GET("/boom", func(c *Context) {
+		c.Status(http.StatusBadGateway)
+	})
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/_sol/api", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated status = %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/_sol/api", nil)
+	req.Header.Set("X-Admin", "yes")
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	var snap AdminSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("decode snapshot: %v", err)
+	}
+	if snap.TotalRequests != 3 {
+		t.Errorf("TotalRequests = %d, want 3", snap.TotalRequests)
+	}
+	if len(snap.Errors) != 1 || snap.Errors[0].Path != "/boom" || snap.Errors[0].Status != http.StatusBadGateway {
+		t.Errorf("Errors = %+v", snap.Errors)
+	}
+	if len(snap.Active) != 1 || snap.Active[0].Path != "/_sol/api" {
+		t.Errorf("Active = %+v", snap.Active)
+	}
+	if len(snap.Routes) != 3 {
+		t.Errorf("Routes = %+v", snap.Routes)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/_sol", nil)
+	req.Header.Set("X-Admin", "yes")
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("dashboard status = %d", rec.Code)
+	}
+}
+
+func TestEnableAdmin_NoAuth(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for admin dashboard without auth")
+		}
+	}()
+	New().EnableAdmin("/_sol")
+}
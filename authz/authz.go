@@ -0,0 +1,111 @@
+// Package authz
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package authz
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+// enforcerKey is the Context key holding the Enforcer.
+const enforcerKey = "sol.authz.enforcer"
+
+// Enforcer decides whether a principal holds a permission.
+// Policy engines such as Casbin can be plugged in by implementing it.
+type Enforcer interface {
+	Enforce(ctx context.Context, p sol.Principal, permission string) (bool, error)
+}
+
+// Use installs the enforcer consulted by Require on the following handlers.
+func Use(e Enforcer) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		c.Set(enforcerKey, e)
+		c.Next()
+	}
+}
+
+// Require allows the request only if the principal holds every permission.
+// It responds 401 without a principal and 403 when a permission is missing.
+func Require(permissions ...string) sol.HandlerFunc {
+	return check(permissions, true)
+}
+
+// RequireAny allows the request if the principal holds at least one permission.
+func RequireAny(permissions ...string) sol.HandlerFunc {
+	return check(permissions, false)
+}
+
+func check(permissions []string, all bool) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		v, _ := c.Get(enforcerKey)
+		e, ok := v.(Enforcer)
+		if !ok {
+			log.Println("[ERROR] authz: no enforcer installed, use authz.Use before authz.Require")
+			c.String(http.StatusInternalServerError, "Internal Server Error")
+			c.Abort()
+			return
+		}
+
+		p := c.Principal()
+		if p == nil {
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+
+		allowed, err := Allowed(c.Context(), e, p, permissions, all)
+		if err != nil {
+			log.Printf("[ERROR] authz: %v", err)
+			c.String(http.StatusInternalServerError, "Internal Server Error")
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.String(http.StatusForbidden, "Forbidden")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Allowed evaluates permissions against e; all selects between
+// requiring every permission and requiring any of them.
+func Allowed(ctx context.Context, e Enforcer, p sol.Principal, permissions []string, all bool) (bool, error) {
+	if len(permissions) == 0 {
+		return true, nil
+	}
+
+	for _, perm := range permissions {
+		ok, err := e.Enforce(ctx, p, perm)
+		if err != nil {
+			return false, err
+		}
+		if ok && !all {
+			return true, nil
+		}
+		if !ok && all {
+			return false, nil
+		}
+	}
+	return all, nil
+}
+
+// match reports whether the granted permission covers the requested one.
+// Grants may end in a "*" segment: "users:*" covers "users:write", "*" covers all.
+func match(granted, requested string) bool {
+	if granted == "*" || granted == requested {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(granted, "*"); ok {
+		return strings.HasPrefix(requested, prefix)
+	}
+	return false
+}
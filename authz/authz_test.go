@@ -0,0 +1,71 @@
+// Package authz
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+type user struct {
+	id    string
+	roles []string
+}
+
+func (u user) Subject() string { return u.id }
+func (u user) Roles() []string { return u.roles }
+
+func TestRequire(t *testing.T) {
+	policy := NewRBAC().
+		Grant("viewer", "users:read").
+		Grant("editor", "users:write").
+		Inherit("editor", "viewer").
+		Grant("admin", "*")
+
+	auth := func(c *sol.Context) {
+		if role := c.Header("X-Role"); role != "" {
+			c.SetPrincipal(user{id: "1", roles: []string{role}})
+		}
+		c.Next()
+	}
+
+	sl := sol.New()
+	admin := sl.Group("/admin", Use(policy), auth, Require("users:write"))
+	admin.GET("/users", func(c *sol.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	sl.GET("/users", Use(policy), auth, RequireAny("users:read", "users:write"), func(c *sol.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		path   string
+		role   string
+		status int
+	}{
+		{"/admin/users", "", http.StatusUnauthorized},
+		{"/admin/users", "viewer", http.StatusForbidden},
+		{"/admin/users", "editor", http.StatusOK},
+		{"/admin/users", "admin", http.StatusOK},
+		{"/users", "viewer", http.StatusOK},
+		{"/users", "guest", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path+"/"+tt.role, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Role", tt.role)
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
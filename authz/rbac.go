@@ -0,0 +1,82 @@
+// Package authz
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package authz
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wantnotshould/sol"
+)
+
+// RBAC is an in-memory role based Enforcer. Principals must implement
+// sol.RoleHolder; roles may inherit the permissions of other roles.
+type RBAC struct {
+	mu       sync.RWMutex
+	grants   map[string][]string
+	inherits map[string][]string
+}
+
+// NewRBAC returns an empty RBAC policy.
+func NewRBAC() *RBAC {
+	return &RBAC{
+		grants:   make(map[string][]string),
+		inherits: make(map[string][]string),
+	}
+}
+
+// Grant gives permissions to role.
+func (r *RBAC) Grant(role string, permissions ...string) *RBAC {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.grants[role] = append(r.grants[role], permissions...)
+	return r
+}
+
+// Inherit makes role inherit every permission of parents.
+func (r *RBAC) Inherit(role string, parents ...string) *RBAC {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inherits[role] = append(r.inherits[role], parents...)
+	return r
+}
+
+// Enforce implements Enforcer.
+func (r *RBAC) Enforce(_ context.Context, p sol.Principal, permission string) (bool, error) {
+	holder, ok := p.(sol.RoleHolder)
+	if !ok {
+		return false, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, role := range holder.Roles() {
+		if r.roleHas(role, permission, seen) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *RBAC) roleHas(role, permission string, seen map[string]bool) bool {
+	if seen[role] {
+		return false
+	}
+	seen[role] = true
+
+	for _, granted := range r.grants[role] {
+		if match(granted, permission) {
+			return true
+		}
+	}
+	for _, parent := range r.inherits[role] {
+		if r.roleHas(parent, permission, seen) {
+			return true
+		}
+	}
+	return false
+}
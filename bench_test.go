@@ -0,0 +1,106 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+// Hot path benchmarks. Run them with
+//
+//	go test -run '^$' -bench . -benchmem
+//
+// and compare against the previous revision with benchstat before merging
+// changes to routing, the context pool or the renderers.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// discardWriter is a ResponseWriter that allocates nothing, so the
+// benchmarks measure sol rather than httptest.ResponseRecorder.
+type discardWriter struct {
+	header http.Header
+}
+
+func newDiscardWriter() *discardWriter {
+	return &discardWriter{header: make(http.Header)}
+}
+
+func (w *discardWriter) Header() http.Header         { return w.header }
+func (w *discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardWriter) WriteString(s string) (int, error) {
+	return len(s), nil
+}
+func (w *discardWriter) WriteHeader(int) {}
+
+func benchServe(b *testing.B, sl *Sol, method, path string) {
+	b.Helper()
+	w := newDiscardWriter()
+	req := httptest.NewRequest(method, path, nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		sl.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkServe_static(b *testing.B) {
+	sl := New()
+	sl.GET("/healthz", func(c *Context) {})
+	benchServe(b, sl, http.MethodGet, "/healthz")
+}
+
+func BenchmarkServe_params(b *testing.B) {
+	sl := New()
+	sl.GET("/users/:id/posts/:post", func(c *Context) {
+		_ = c.Param("id")
+	})
+	benchServe(b, sl, http.MethodGet, "/users/42/posts/7")
+}
+
+func BenchmarkServe_middleware(b *testing.B) {
+	sl := New()
+	for range 5 {
+		sl.Use(func(c *Context) { c.Next() })
+	}
+	sl.GET("/healthz", func(c *Context) {})
+	benchServe(b, sl, http.MethodGet, "/healthz")
+}
+
+func BenchmarkContext_pool(b *testing.B) {
+	r := newRouter(nil, newTreeRouter()).(*routerImpl)
+	w := newDiscardWriter()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		r.releaseCtx(r.acquireCtx(w, req, nil))
+	}
+}
+
+func BenchmarkRender_string(b *testing.B) {
+	sl := New()
+	sl.GET("/", func(c *Context) { c.String(http.StatusOK, "Hello, world!") })
+	benchServe(b, sl, http.MethodGet, "/")
+}
+
+func BenchmarkRender_html(b *testing.B) {
+	sl := New()
+	sl.GET("/", func(c *Context) { c.HTML(http.StatusOK, "<h1>Hello, world!</h1>") })
+	benchServe(b, sl, http.MethodGet, "/")
+}
+
+func BenchmarkRender_json(b *testing.B) {
+	type user struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Email string   `json:"email"`
+		Tags  []string `json:"tags"`
+	}
+	u := user{ID: 42, Name: "Perry", Email: "perry@example.com", Tags: []string{"admin", "ops"}}
+
+	sl := New()
+	sl.GET("/", func(c *Context) { c.JSON(http.StatusOK, u) })
+	benchServe(b, sl, http.MethodGet, "/")
+}
@@ -7,36 +7,82 @@ package binding
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/wantnotshould/sol"
+	"github.com/wantnotshould/sol/validator"
 )
 
 // Constants for max memory and supported content types
 const maxMemory = 32 << 20 // 32 MB
 
+func init() {
+	sol.QueryBinder = Query
+}
+
 // Form binds URL-encoded form data to the given Go struct.
 func Form(c *sol.Context, obj any) error {
+	if err := decompressBody(c); err != nil {
+		return err
+	}
 	if err := c.Request.ParseForm(); err != nil {
 		return fmt.Errorf("parse form error: %w", err)
 	}
-	return bindFromValues(c.Request.Form, obj)
+	return bindFromValues(c.Validator(), c.Request.Form, obj)
 }
 
 // MultipartForm binds multipart form data (including files) to the given Go struct.
 func MultipartForm(c *sol.Context, obj any) error {
+	if err := decompressBody(c); err != nil {
+		return err
+	}
 	if err := c.Request.ParseMultipartForm(maxMemory); err != nil {
 		return fmt.Errorf("parse multipart form error: %w", err)
 	}
 	return bindMultipartFormData(c, obj)
 }
 
+// MultipartStream iterates the parts of a multipart body as they arrive,
+// without ParseMultipartForm buffering them to memory or disk, so large
+// uploads can be piped straight to storage. Each part is closed after fn
+// returns; iteration stops at the first error.
+func MultipartStream(c *sol.Context, fn func(part *multipart.Part) error) error {
+	if err := decompressBody(c); err != nil {
+		return err
+	}
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		return fmt.Errorf("multipart stream error: %w", err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read multipart part error: %w", err)
+		}
+
+		err = fn(part)
+		part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // JSON binds JSON request body data to the given Go struct.
 func JSON(c *sol.Context, obj any) error {
 	contentType := c.Request.Header.Get("Content-Type")
@@ -47,6 +93,9 @@ func JSON(c *sol.Context, obj any) error {
 	if c.Request.Body == nil {
 		return fmt.Errorf("json binding: request body is nil")
 	}
+	if err := decompressBody(c); err != nil {
+		return err
+	}
 
 	bodyBytes, err := io.ReadAll(c.Request.Body)
 	if err != nil {
@@ -60,9 +109,76 @@ func JSON(c *sol.Context, obj any) error {
 		return fmt.Errorf("json unmarshal error: %w", err)
 	}
 
+	// A JSON array bound into *[]T is validated element by element.
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Slice {
+		if errs := validateSlice(c.Validator(), v.Elem()); len(errs) > 0 {
+			return errs
+		}
+	}
+
 	return nil
 }
 
+// NDJSON streams a newline-delimited JSON body, decoding one item at a
+// time and passing it to fn, so bulk imports never buffer the whole
+// payload. Struct items are validated before fn sees them. It stops at
+// the first decoding, validation or fn error, reporting the item number.
+func NDJSON[T any](c *sol.Context, fn func(item T) error) error {
+	mediaType, _, _ := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+	default:
+		return fmt.Errorf("ndjson binding: Content-Type is not ndjson, got %s", c.Request.Header.Get("Content-Type"))
+	}
+
+	if c.Request.Body == nil {
+		return fmt.Errorf("ndjson binding: request body is nil")
+	}
+	if err := decompressBody(c); err != nil {
+		return err
+	}
+
+	vd := c.Validator()
+	dec := json.NewDecoder(c.Request.Body)
+	for n := 1; ; n++ {
+		var item T
+		if err := dec.Decode(&item); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("ndjson item %d: %w", n, err)
+		}
+
+		if v := reflect.Indirect(reflect.ValueOf(item)); v.Kind() == reflect.Struct {
+			if errs := vd.Validate(v.Interface()); len(errs) > 0 {
+				return fmt.Errorf("ndjson item %d: %w", n, errs)
+			}
+		}
+
+		if err := fn(item); err != nil {
+			return fmt.Errorf("ndjson item %d: %w", n, err)
+		}
+	}
+}
+
+// validateSlice validates the struct elements of a slice, keying errors
+// by index, e.g. "[2].email".
+func validateSlice(vd *validator.Validator, v reflect.Value) validator.FieldErrors {
+	var errs validator.FieldErrors
+
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+
+		prefix := "[" + strconv.Itoa(i) + "]"
+		for _, fe := range vd.Validate(elem.Interface()) {
+			errs.Add(prefix+"."+fe.Field, fe)
+		}
+	}
+	return errs
+}
+
 // XML binds XML request body data to the given Go struct.
 func XML(c *sol.Context, obj any) error {
 	contentType := c.Request.Header.Get("Content-Type")
@@ -74,6 +190,9 @@ func XML(c *sol.Context, obj any) error {
 	if c.Request.Body == nil {
 		return fmt.Errorf("xml binding: request body is nil")
 	}
+	if err := decompressBody(c); err != nil {
+		return err
+	}
 
 	bodyBytes, err := io.ReadAll(c.Request.Body)
 	if err != nil {
@@ -90,8 +209,40 @@ func XML(c *sol.Context, obj any) error {
 	return nil
 }
 
+// Query binds URL query parameters to the given Go struct using form tags.
+func Query(c *sol.Context, obj any) error {
+	return bindFromValues(c.Validator(), c.QueryAll(), obj)
+}
+
+// Bind picks a binder from the request. GET, HEAD and DELETE requests are
+// bound from the query string, so list endpoints with filters share the
+// call path of body-carrying methods. Other requests are bound by
+// Content-Type, falling back to Form.
+func Bind(c *sol.Context, obj any) error {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return Query(c, obj)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json":
+		return JSON(c, obj)
+	case mediaType == "application/xml" || mediaType == "text/xml":
+		return XML(c, obj)
+	case mediaType == "multipart/form-data":
+		return MultipartForm(c, obj)
+	default:
+		return Form(c, obj)
+	}
+}
+
 // bindFromValues binds form values to the struct based on the form tags.
-func bindFromValues(values url.Values, obj any) error {
+// Conversion errors of all fields are collected in field order into one
+// validator.FieldErrors, so a form with several bad fields is reported in
+// one go. They are keyed by vd's field names, like validation errors, so
+// clients see one name per field whichever check failed.
+func bindFromValues(vd *validator.Validator, values url.Values, obj any) error {
 	v := reflect.ValueOf(obj)
 	if v.Kind() != reflect.Pointer || v.IsNil() {
 		return fmt.Errorf("binding: obj must be a non-nil pointer")
@@ -101,25 +252,166 @@ func bindFromValues(values url.Values, obj any) error {
 	}
 
 	elem := v.Elem()
+	var errs validator.FieldErrors
 
 	for i := 0; i < elem.NumField(); i++ {
 		field := elem.Type().Field(i)
-		tag := field.Tag.Get("form")
+		tag, rawOpts, _ := strings.Cut(field.Tag.Get("form"), ",")
 		if tag == "" || tag == "-" {
 			continue
 		}
 
-		if strs, ok := values[tag]; ok && len(strs) > 0 {
-			value := strs[0]
-			fieldValue := elem.Field(i)
-			if !fieldValue.CanSet() {
-				continue
+		strs, ok := values[tag]
+		if !ok || len(strs) == 0 {
+			continue
+		}
+		fieldValue := elem.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+		opts, err := parseFieldOptions(field, rawOpts)
+		if err != nil {
+			return fmt.Errorf("bind %s: %w", tag, err)
+		}
+
+		if fieldValue.Kind() == reflect.Slice && fieldValue.Type() != reflect.TypeFor[[]byte]() {
+			if opts.sep != "" {
+				strs = splitValues(strs, opts.sep)
 			}
-			if err := setField(fieldValue, value); err != nil {
-				return fmt.Errorf("bind %s=%s: %w", tag, value, err)
+			if err := setSlice(fieldValue, strs, opts); err != nil {
+				errs.Add(vd.FieldName(field), fieldError(err))
 			}
+			continue
+		}
+
+		if err := setField(fieldValue, strs[0], opts); err != nil {
+			errs.Add(vd.FieldName(field), fieldError(err))
 		}
 	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// fieldOptions controls how form values are converted into a field.
+//
+// Options of the form tag:
+//   - comma, semicolon, pipe, space: split slice values on the delimiter,
+//     for partners sending lists as ids=1,2,3 rather than repeated keys
+//   - decimal_comma: numbers use European notation, "1.234,56"
+//
+// time.Time fields read their layout from the time_format tag (RFC 3339,
+// datetime-local and date inputs by default) and their zone from the
+// time_location tag (UTC by default).
+type fieldOptions struct {
+	sep          string
+	decimalComma bool
+	timeFormat   string
+	location     *time.Location
+}
+
+// fieldError turns a conversion error into its validator.FieldError,
+// keeping the code of errors that carry one, such as enum checks.
+func fieldError(err error) validator.FieldError {
+	fe := validator.FieldError{Code: "type", Message: err.Error()}
+	var inner validator.FieldError
+	if errors.As(err, &inner) {
+		fe.Code, fe.Param = inner.Code, inner.Param
+	}
+	return fe
+}
+
+func parseFieldOptions(field reflect.StructField, raw string) (fieldOptions, error) {
+	opts := fieldOptions{location: time.UTC}
+	for opt := range strings.SplitSeq(raw, ",") {
+		switch opt {
+		case "comma":
+			opts.sep = ","
+		case "semicolon":
+			opts.sep = ";"
+		case "pipe":
+			opts.sep = "|"
+		case "space":
+			opts.sep = " "
+		case "decimal_comma":
+			opts.decimalComma = true
+		}
+	}
+
+	opts.timeFormat = field.Tag.Get("time_format")
+	if name := field.Tag.Get("time_location"); name != "" {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return opts, fmt.Errorf("invalid time_location: %w", err)
+		}
+		opts.location = loc
+	}
+	return opts, nil
+}
+
+// defaultTimeFormats are tried in order when no time_format is set.
+var defaultTimeFormats = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+func parseTime(value string, opts fieldOptions) (time.Time, error) {
+	if opts.timeFormat != "" {
+		return time.ParseInLocation(opts.timeFormat, value, opts.location)
+	}
+
+	var err error
+	for _, layout := range defaultTimeFormats {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, value, opts.location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// normalizeNumber turns "1.234,56" into "1234.56" for decimal_comma fields.
+func normalizeNumber(value string, opts fieldOptions) string {
+	if !opts.decimalComma {
+		return value
+	}
+	value = strings.ReplaceAll(value, ".", "")
+	return strings.Replace(value, ",", ".", 1)
+}
+
+// parseBool accepts the values HTML forms send besides strconv's,
+// such as "on" for checked checkboxes.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "yes", "y":
+		return true, nil
+	case "off", "no", "n", "":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// splitValues splits every value by sep, dropping empty items.
+func splitValues(strs []string, sep string) []string {
+	var out []string
+	for _, s := range strs {
+		for item := range strings.SplitSeq(s, sep) {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
+
+// setSlice sets a slice field to strs converted to its element type.
+func setSlice(field reflect.Value, strs []string, opts fieldOptions) error {
+	slice := reflect.MakeSlice(field.Type(), len(strs), len(strs))
+	for i, s := range strs {
+		if err := setField(slice.Index(i), s, opts); err != nil {
+			return fmt.Errorf("item %d=%s: %w", i, s, err)
+		}
+	}
+	field.Set(slice)
 	return nil
 }
 
@@ -133,7 +425,7 @@ func bindMultipartFormData(c *sol.Context, obj any) error {
 	t := v.Type()
 
 	if c.Request.MultipartForm != nil && c.Request.MultipartForm.Value != nil {
-		if err := bindFromValues(c.Request.MultipartForm.Value, obj); err != nil {
+		if err := bindFromValues(c.Validator(), c.Request.MultipartForm.Value, obj); err != nil {
 			return err
 		}
 	}
@@ -146,7 +438,7 @@ func bindMultipartFormData(c *sol.Context, obj any) error {
 			continue
 		}
 
-		tag := field.Tag.Get("form")
+		tag, _, _ := strings.Cut(field.Tag.Get("form"), ",")
 		if tag == "" || tag == "-" {
 			continue
 		}
@@ -179,33 +471,61 @@ func bindMultipartFormData(c *sol.Context, obj any) error {
 	return nil
 }
 
+// numberError reports a failed number conversion. Values that do not fit
+// the field's size get the "range" code instead of wrapping around.
+func numberError(field reflect.Value, value, kind string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return validator.FieldError{
+			Code:    "range",
+			Message: fmt.Sprintf("value %s out of range for %s", value, field.Type()),
+		}
+	}
+	return fmt.Errorf("invalid %s value: %w", kind, err)
+}
+
 // setField sets the value of a struct field based on its type.
-func setField(field reflect.Value, value string) error {
+func setField(field reflect.Value, value string, opts fieldOptions) error {
+	if field.Type() == reflect.TypeFor[time.Time]() {
+		t, err := parseTime(value, opts)
+		if err != nil {
+			return fmt.Errorf("invalid time value: %w", err)
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
 	switch field.Kind() {
 	case reflect.String:
+		if err := checkEnum(field.Type(), value); err != nil {
+			return err
+		}
 		field.SetString(value)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		i, err := strconv.ParseInt(value, 10, 64)
+		i, err := strconv.ParseInt(normalizeNumber(value, opts), 10, field.Type().Bits())
 		if err != nil {
-			return fmt.Errorf("invalid int value: %w", err)
+			return numberError(field, value, "int", err)
 		}
 		field.SetInt(i)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		u, err := strconv.ParseUint(value, 10, 64)
+		u, err := strconv.ParseUint(normalizeNumber(value, opts), 10, field.Type().Bits())
 		if err != nil {
-			return fmt.Errorf("invalid uint value: %w", err)
+			return numberError(field, value, "uint", err)
 		}
 		field.SetUint(u)
 	case reflect.Bool:
-		b, err := strconv.ParseBool(value)
+		b, err := parseBool(value)
 		if err != nil {
 			return fmt.Errorf("invalid bool value: %w", err)
 		}
 		field.SetBool(b)
 	case reflect.Float32, reflect.Float64:
-		f, err := strconv.ParseFloat(value, 64)
+		f, err := strconv.ParseFloat(normalizeNumber(value, opts), field.Type().Bits())
 		if err != nil {
-			return fmt.Errorf("invalid float value: %w", err)
+			return numberError(field, value, "float", err)
+		}
+		// NaN and infinities parse fine but are never meant as form input.
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("invalid float value %q", value)
 		}
 		field.SetFloat(f)
 	default:
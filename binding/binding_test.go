@@ -6,12 +6,19 @@ package binding
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/wantnotshould/sol"
+	"github.com/wantnotshould/sol/validator"
 )
 
 type User struct {
@@ -285,3 +292,351 @@ func TestMissingRequiredField(t *testing.T) {
 		t.Errorf("Expected Address empty, got %q", user.Address)
 	}
 }
+
+func TestBind(t *testing.T) {
+	type filter struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		want        filter
+	}{
+		{"get query", http.MethodGet, "/users?name=Perry&age=25", "", "", filter{"Perry", 25}},
+		{"delete query", http.MethodDelete, "/users?name=Perry", "", "", filter{Name: "Perry"}},
+		{"post json", http.MethodPost, "/users?name=ignored", "application/json; charset=utf-8", `{"Name":"Perry","Age":30}`, filter{"Perry", 30}},
+		{"post form", http.MethodPost, "/users", "application/x-www-form-urlencoded", "name=Perry&age=40", filter{"Perry", 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			c := &sol.Context{Request: req}
+
+			var got filter
+			if err := Bind(c, &got); err != nil {
+				t.Fatalf("Bind: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONBindingSlice(t *testing.T) {
+	body := `[
+		{"name": "Perry", "age": 25, "email": "perry@example.com", "address": "Wonderland"},
+		{"name": "Ferb", "age": 12, "email": "ferb@example.com", "address": "Danville"}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	var users []User
+	err := JSON(&sol.Context{Request: req}, &users)
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("bound %d users, want 2", len(users))
+	}
+	for _, key := range []string{"[1].name", "[1].age"} {
+		if len(verrs[key]) == 0 {
+			t.Errorf("missing error for %s in %v", key, verrs)
+		}
+	}
+	if _, ok := verrs["[0].name"]; ok {
+		t.Errorf("unexpected error for first element: %v", verrs)
+	}
+}
+
+func TestBindingUsesRequestValidator(t *testing.T) {
+	type signup struct {
+		Age  int    `form:"user_age" json:"age"`
+		Name string `form:"user_name" json:"name" validate:"required"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?user_age=old", nil)
+	var got signup
+	err := Query(&sol.Context{Request: req}, &got)
+	var ferrs validator.FieldErrors
+	if !errors.As(err, &ferrs) || ferrs[0].Field != "age" {
+		t.Errorf("conversion error = %v, want it keyed like validation errors", err)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"name": ""}]`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "zh-CN")
+	var list []signup
+	err = JSON(&sol.Context{Request: req}, &list)
+	want := validator.GetMessageIn(validator.ZH, "required", nil)
+	if !errors.As(err, &ferrs) || ferrs[0].Field != "[0].name" || ferrs[0].Message != want {
+		t.Errorf("slice errors = %v, want %q on [0].name", err, want)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	body := `{"name": "Perry", "age": 25, "email": "perry@example.com", "address": "Wonderland"}
+{"name": "Candy", "age": 30, "email": "candy@example.com", "address": "Danville"}
+{"name": "Ferb", "age": 12, "email": "ferb@example.com", "address": "Danville"}
+`
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	var names []string
+	err := NDJSON(&sol.Context{Request: req}, func(u User) error {
+		names = append(names, u.Name)
+		return nil
+	})
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) || !strings.Contains(err.Error(), "item 3") {
+		t.Fatalf("expected validation error on item 3, got %v", err)
+	}
+	if want := []string{"Perry", "Candy"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestMultipartStream(t *testing.T) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	writer.WriteField("name", "Perry")
+	file, _ := writer.CreateFormFile("video", "video.mp4")
+	file.Write(bytes.Repeat([]byte("x"), 1<<16))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	sizes := map[string]int64{}
+	err := MultipartStream(&sol.Context{Request: req}, func(part *multipart.Part) error {
+		n, err := io.Copy(io.Discard, part)
+		sizes[part.FormName()] = n
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("MultipartStream: %v", err)
+	}
+	if sizes["name"] != 5 || sizes["video"] != 1<<16 {
+		t.Errorf("sizes = %v", sizes)
+	}
+}
+
+func TestQuerySliceSeparator(t *testing.T) {
+	type filter struct {
+		IDs    []int    `form:"ids,comma"`
+		Tags   []string `form:"tag"`
+		Status []string `form:"status,pipe"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?ids=1,2,3&ids=4&tag=a&tag=b&status=active|banned", nil)
+	var got filter
+	if err := Query(&sol.Context{Request: req}, &got); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if !slices.Equal(got.IDs, []int{1, 2, 3, 4}) {
+		t.Errorf("IDs = %v", got.IDs)
+	}
+	if !slices.Equal(got.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags = %v", got.Tags)
+	}
+	if !slices.Equal(got.Status, []string{"active", "banned"}) {
+		t.Errorf("Status = %v", got.Status)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?ids=1,x", nil)
+	if err := Query(&sol.Context{Request: req}, &got); err == nil {
+		t.Error("expected error for invalid item")
+	}
+}
+
+type postStatus string
+
+func TestEnumBinding(t *testing.T) {
+	RegisterEnum[postStatus]("draft", "published")
+
+	type post struct {
+		Status postStatus `form:"status"`
+	}
+
+	var p post
+	req := httptest.NewRequest(http.MethodGet, "/posts?status=published", nil)
+	if err := Query(&sol.Context{Request: req}, &p); err != nil || p.Status != "published" {
+		t.Fatalf("Query: %v, status %q", err, p.Status)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/posts?status=deleted", nil)
+	var ferrs validator.FieldErrors
+	if err := Query(&sol.Context{Request: req}, &p); !errors.As(err, &ferrs) || ferrs[0].Code != "oneof" || !strings.Contains(ferrs[0].Message, "draft, published") {
+		t.Errorf("expected enum error, got %v", err)
+	}
+}
+
+func TestFormBindingLocaleOptions(t *testing.T) {
+	type order struct {
+		Price      float64   `form:"price,decimal_comma"`
+		Quantity   int       `form:"qty,decimal_comma"`
+		Gift       bool      `form:"gift"`
+		Newsletter bool      `form:"newsletter"`
+		Deliver    time.Time `form:"deliver" time_location:"Europe/Berlin"`
+		Birthday   time.Time `form:"birthday" time_format:"02.01.2006"`
+	}
+
+	body := "price=1.234,56&qty=1.000&gift=on&newsletter=no&deliver=2026-03-01T09:30&birthday=24.12.1990"
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var got order
+	if err := Form(&sol.Context{Request: req}, &got); err != nil {
+		t.Fatalf("Form: %v", err)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	if got.Price != 1234.56 || got.Quantity != 1000 {
+		t.Errorf("Price = %v, Quantity = %v", got.Price, got.Quantity)
+	}
+	if !got.Gift || got.Newsletter {
+		t.Errorf("Gift = %v, Newsletter = %v", got.Gift, got.Newsletter)
+	}
+	if want := time.Date(2026, 3, 1, 9, 30, 0, 0, berlin); !got.Deliver.Equal(want) {
+		t.Errorf("Deliver = %v, want %v", got.Deliver, want)
+	}
+	if want := time.Date(1990, 12, 24, 0, 0, 0, 0, time.UTC); !got.Birthday.Equal(want) {
+		t.Errorf("Birthday = %v, want %v", got.Birthday, want)
+	}
+}
+
+func TestFormBindingAccumulatesErrors(t *testing.T) {
+	type form struct {
+		Name string  `form:"name"`
+		Age  int8    `form:"age"`
+		IDs  []int   `form:"ids,comma"`
+		Rate float64 `form:"rate"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?name=Perry&age=old&ids=1,x&rate=fast", nil)
+	var got form
+	err := Query(&sol.Context{Request: req}, &got)
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	byField := err.(validator.FieldErrors).ByField()
+	for _, field := range []string{"age", "ids", "rate"} {
+		if len(verrs[field]) != 1 || byField[field][0].Code != "type" {
+			t.Errorf("errors for %s = %v", field, verrs[field])
+		}
+	}
+	if got.Name != "Perry" {
+		t.Errorf("valid fields should still bind, Name = %q", got.Name)
+	}
+
+	var ferrs validator.FieldErrors
+	if !errors.As(err, &ferrs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+	var order []string
+	for _, fe := range ferrs {
+		order = append(order, fe.Field)
+	}
+	if want := []string{"age", "ids", "rate"}; !slices.Equal(order, want) {
+		t.Errorf("field order = %v, want %v", order, want)
+	}
+}
+
+func TestRouteQuery(t *testing.T) {
+	type listUsers struct {
+		Page int    `form:"page" json:"page" validate:"min=1"`
+		Sort string `form:"sort" json:"sort"`
+	}
+
+	sl := sol.New()
+	route := sl.GET("/users", func(c *sol.Context) {
+		q := sol.BoundQuery[listUsers](c)
+		c.String(http.StatusOK, "page %d sort %s", q.Page, q.Sort)
+	}).Query(&listUsers{})
+
+	if route.QueryType() != reflect.TypeFor[listUsers]() {
+		t.Errorf("QueryType = %v", route.QueryType())
+	}
+
+	tests := []struct {
+		query  string
+		status int
+		body   string
+	}{
+		{"?page=2&sort=name", http.StatusOK, "page 2 sort name"},
+		{"?page=0", http.StatusBadRequest, `{"error":"validation failed","fields":{"page":[{"code":"min","message":"This field must be at least 1","param":"1"}]}}`},
+		{"?page=two", http.StatusBadRequest, `"code":"type"`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.body) {
+			t.Errorf("%s: got %d %q, want %d %q", tt.query, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+}
+
+func TestFormBindingNumberRange(t *testing.T) {
+	type limits struct {
+		Small int8    `form:"small"`
+		Port  uint16  `form:"port"`
+		Ratio float32 `form:"ratio"`
+		Score float64 `form:"score"`
+	}
+
+	tests := []struct {
+		query string
+		field string
+		code  string
+	}{
+		{"small=127&port=65535&ratio=1.5&score=2", "", ""},
+		{"small=300", "small", "range"},
+		{"small=-129", "small", "range"},
+		{"port=70000", "port", "range"},
+		{"port=-1", "port", "type"},
+		{"ratio=1e40", "ratio", "range"},
+		{"score=NaN", "score", "type"},
+		{"score=-Inf", "score", "type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			var got limits
+			err := Query(&sol.Context{Request: req}, &got)
+
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got.Small != 127 || got.Port != 65535 {
+					t.Errorf("bound %+v", got)
+				}
+				return
+			}
+
+			var ferrs validator.FieldErrors
+			if !errors.As(err, &ferrs) || len(ferrs.ByField()[tt.field]) != 1 || ferrs.ByField()[tt.field][0].Code != tt.code {
+				t.Errorf("errors = %v, want %s on %s", err, tt.code, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package binding
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package binding
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+// MaxDecompressedSize caps the size of a decompressed request body,
+// guarding against compression bombs.
+var MaxDecompressedSize int64 = 32 << 20 // 32 MB
+
+// ErrBodyTooLarge is returned when a decompressed body exceeds MaxDecompressedSize.
+var ErrBodyTooLarge = errors.New("binding: decompressed body too large")
+
+// decompressBody transparently replaces a gzip or deflate encoded request
+// body with its decompressed content. Binders call it before reading, so
+// compressed payloads bind like plain ones.
+func decompressBody(c *sol.Context) error {
+	req := c.Request
+	enc := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+	if enc == "" || enc == "identity" || req.Body == nil {
+		return nil
+	}
+
+	var r io.ReadCloser
+	switch enc {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return fmt.Errorf("gzip body error: %w", err)
+		}
+		r = gz
+	case "deflate":
+		// HTTP deflate is the zlib format, not raw DEFLATE (RFC 9110 8.4.1.2).
+		zr, err := zlib.NewReader(req.Body)
+		if err != nil {
+			return fmt.Errorf("deflate body error: %w", err)
+		}
+		r = zr
+	default:
+		return fmt.Errorf("binding: unsupported Content-Encoding %q", enc)
+	}
+
+	req.Body = &limitedBody{r: r, closer: req.Body, left: MaxDecompressedSize}
+	req.Header.Del("Content-Encoding")
+	req.ContentLength = -1
+	return nil
+}
+
+// limitedBody fails with ErrBodyTooLarge instead of silently truncating.
+type limitedBody struct {
+	r      io.ReadCloser
+	closer io.Closer
+	left   int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.left <= 0 {
+		// Probe for more data to tell EOF from an oversized body.
+		var one [1]byte
+		if n, _ := b.r.Read(one[:]); n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.left {
+		p = p[:b.left]
+	}
+	n, err := b.r.Read(p)
+	b.left -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	b.r.Close()
+	return b.closer.Close()
+}
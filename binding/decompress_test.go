@@ -0,0 +1,82 @@
+// Package binding
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package binding
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+func gzipped(s string) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return &buf
+}
+
+func TestJSONBindingGzip(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users", gzipped(`{"name": "Perry", "age": 25}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	var user User
+	if err := JSON(&sol.Context{Request: req}, &user); err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if user.Name != "Perry" || user.Age != 25 {
+		t.Errorf("user = %+v", user)
+	}
+}
+
+func TestJSONBindingDeflate(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write([]byte(`{"name": "Perry", "age": 25}`))
+	zw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/users", &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "deflate")
+
+	var user User
+	if err := JSON(&sol.Context{Request: req}, &user); err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	if user.Name != "Perry" || user.Age != 25 {
+		t.Errorf("user = %+v", user)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("not zlib"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "deflate")
+	if err := JSON(&sol.Context{Request: req}, &user); err == nil {
+		t.Error("expected an error for a malformed deflate body")
+	}
+}
+
+func TestDecompressBomb(t *testing.T) {
+	old := MaxDecompressedSize
+	MaxDecompressedSize = 1 << 10
+	t.Cleanup(func() { MaxDecompressedSize = old })
+
+	payload := `{"name": "` + strings.Repeat("a", 1<<20) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/users", gzipped(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	var user User
+	if err := JSON(&sol.Context{Request: req}, &user); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("expected ErrBodyTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package binding
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/wantnotshould/sol/validator"
+)
+
+// RegisterEnum declares the values of a string-backed enum once, for both
+// layers: the binder rejects other values on conversion and the validator
+// checks fields of type T as if tagged with oneof.
+//
+//	type Status string
+//
+//	binding.RegisterEnum[Status]("draft", "published")
+func RegisterEnum[T ~string](values ...T) {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	validator.RegisterEnum(reflect.TypeFor[T](), strs...)
+}
+
+// checkEnum rejects values outside a registered enum type.
+func checkEnum(t reflect.Type, value string) error {
+	allowed, ok := validator.EnumValues(t)
+	if !ok || slices.Contains(allowed, value) {
+		return nil
+	}
+	return validator.FieldError{
+		Code:    "oneof",
+		Message: fmt.Sprintf("invalid %s value %q, must be one of %s", t.Name(), value, strings.Join(allowed, ", ")),
+		Param:   strings.Join(allowed, " "),
+	}
+}
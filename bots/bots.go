@@ -0,0 +1,289 @@
+// Package bots
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package bots
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wantnotshould/sol"
+	"github.com/wantnotshould/sol/ratelimit"
+)
+
+// verdictKey is the Context key holding the Verdict.
+const verdictKey = "sol.bots.verdict"
+
+// Action is what happens to unverified automated traffic.
+type Action int
+
+const (
+	// Tag lets bots through, only recording the Verdict.
+	Tag Action = iota
+	// Throttle rate limits bots per IP, see Config.Throttle.
+	Throttle
+	// Block answers bots with 403.
+	Block
+)
+
+// Crawler is a search engine crawler whose requests can be verified by
+// reverse DNS: the IP must resolve to a host under one of Domains, which
+// must resolve back to the IP.
+type Crawler struct {
+	Name string
+	// Token identifies the crawler in the User-Agent, lower case.
+	Token   string
+	Domains []string
+}
+
+// KnownCrawlers are the crawlers verified by default.
+var KnownCrawlers = []Crawler{
+	{"Googlebot", "googlebot", []string{".googlebot.com", ".google.com", ".googleusercontent.com"}},
+	{"Bingbot", "bingbot", []string{".search.msn.com"}},
+	{"Applebot", "applebot", []string{".applebot.apple.com"}},
+	{"YandexBot", "yandex", []string{".yandex.ru", ".yandex.net", ".yandex.com"}},
+	{"Baiduspider", "baiduspider", []string{".crawl.baidu.com", ".crawl.baidu.jp"}},
+}
+
+// Resolver performs the DNS lookups of crawler verification.
+// *net.Resolver implements it.
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Config configures the middleware.
+type Config struct {
+	// Action applies to bots that are not verified crawlers, Tag by default.
+	Action Action
+	// Throttle is the per IP limit of Action Throttle, 30 per minute by default.
+	Throttle ratelimit.Limit
+	// Crawlers are verified by reverse DNS, KnownCrawlers if nil. Requests
+	// claiming to be one of them that fail verification are blocked.
+	Crawlers []Crawler
+	// Resolver is net.DefaultResolver by default.
+	Resolver Resolver
+	// Honeypots are paths no human follows, e.g. a link hidden by CSS or
+	// disallowed in robots.txt. Clients requesting one are banned.
+	Honeypots []string
+	// BanDuration is how long honeypot visitors stay banned, 24h by default.
+	BanDuration time.Duration
+	// Detect classifies a request as automated. It defaults to the
+	// device class from sol.Context.Device.
+	Detect func(c *sol.Context) bool
+}
+
+// Verdict describes how the middleware classified a request.
+type Verdict struct {
+	Bot bool
+	// Crawler is the name of the crawler the request claims to be, if any.
+	Crawler string
+	// Verified is set when the crawler passed the reverse DNS check.
+	Verified bool
+}
+
+// Middleware detects automated traffic and tags, throttles or blocks it
+// before the handlers after it run. Verified crawlers are always let
+// through; impostors and banned clients get 403.
+func Middleware(cfg Config) sol.HandlerFunc {
+	if cfg.Crawlers == nil {
+		cfg.Crawlers = KnownCrawlers
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = net.DefaultResolver
+	}
+	if cfg.BanDuration <= 0 {
+		cfg.BanDuration = 24 * time.Hour
+	}
+	if cfg.Throttle.Requests <= 0 || cfg.Throttle.Window <= 0 {
+		cfg.Throttle = ratelimit.PerMinute(30)
+	}
+	if cfg.Detect == nil {
+		cfg.Detect = func(c *sol.Context) bool { return c.Device().IsBot() }
+	}
+
+	g := &guard{cfg: cfg, banned: make(map[string]time.Time), verified: make(map[string]verification)}
+	throttle := ratelimit.Middleware(ratelimit.Config{
+		Limit: cfg.Throttle,
+		Key:   func(c *sol.Context) string { return "bot:" + sol.TrustedClientIP(c.Request) },
+	})
+
+	return func(c *sol.Context) {
+		// Bans, verification and throttling must not trust a client
+		// supplied X-Forwarded-For, or anyone could get another IP banned
+		// or pass as a crawler.
+		ip := sol.TrustedClientIP(c.Request)
+		if g.isBanned(ip) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		if slices.Contains(cfg.Honeypots, c.Path()) {
+			log.Printf("[WARN] bots: %s requested honeypot %s, banned for %v", ip, c.Path(), cfg.BanDuration)
+			g.ban(ip)
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		v := Verdict{Bot: cfg.Detect(c)}
+		ua := strings.ToLower(c.Request.UserAgent())
+		for _, cr := range cfg.Crawlers {
+			if strings.Contains(ua, cr.Token) {
+				v.Bot, v.Crawler = true, cr.Name
+				v.Verified = g.verify(c.Context(), ip, cr)
+				break
+			}
+		}
+		c.Set(verdictKey, v)
+
+		switch {
+		case !v.Bot || v.Verified:
+			c.Next()
+		case v.Crawler != "":
+			log.Printf("[WARN] bots: %s claims to be %s but failed verification", ip, v.Crawler)
+			c.AbortWithStatus(http.StatusForbidden)
+		case cfg.Action == Block:
+			c.AbortWithStatus(http.StatusForbidden)
+		case cfg.Action == Throttle:
+			// The limiter continues the chain itself when under the limit.
+			throttle(c)
+		default:
+			c.Next()
+		}
+	}
+}
+
+// From returns the Verdict of the request, the zero Verdict without the
+// middleware.
+func From(c *sol.Context) Verdict {
+	v, _ := c.Get(verdictKey)
+	verdict, _ := v.(Verdict)
+	return verdict
+}
+
+// verifyTTL is how long a crawler verification is cached per IP.
+const verifyTTL = time.Hour
+
+// maxTracked bounds the banned IPs and cached verifications each.
+var maxTracked = 100000
+
+type verification struct {
+	ok      bool
+	expires time.Time
+}
+
+type guard struct {
+	cfg Config
+
+	mu       sync.Mutex
+	banned   map[string]time.Time
+	verified map[string]verification
+}
+
+func (g *guard) ban(ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := g.banned[ip]; !ok && len(g.banned) >= maxTracked {
+		// Drop lifted bans, then the ban closest to lifting.
+		maps.DeleteFunc(g.banned, func(_ string, until time.Time) bool { return now.After(until) })
+		if len(g.banned) >= maxTracked {
+			var first string
+			for k, until := range g.banned {
+				if first == "" || until.Before(g.banned[first]) {
+					first = k
+				}
+			}
+			delete(g.banned, first)
+		}
+	}
+	g.banned[ip] = now.Add(g.cfg.BanDuration)
+}
+
+func (g *guard) isBanned(ip string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	until, ok := g.banned[ip]
+	if ok && time.Now().After(until) {
+		delete(g.banned, ip)
+		return false
+	}
+	return ok
+}
+
+// verify runs the reverse and forward DNS check, caching the outcome.
+// Lookups that failed for other reasons than a missing record are not
+// cached, so a DNS outage does not lock a crawler out for an hour.
+func (g *guard) verify(ctx context.Context, ip string, cr Crawler) bool {
+	key := cr.Name + "|" + ip
+	now := time.Now()
+
+	g.mu.Lock()
+	if v, ok := g.verified[key]; ok && now.Before(v.expires) {
+		g.mu.Unlock()
+		return v.ok
+	}
+	g.mu.Unlock()
+
+	ok, err := verifyCrawler(ctx, g.cfg.Resolver, ip, cr)
+	if err != nil {
+		log.Printf("[WARN] bots: verify %s as %s: %v", ip, cr.Name, err)
+		return false
+	}
+
+	g.mu.Lock()
+	if len(g.verified) >= maxTracked {
+		maps.DeleteFunc(g.verified, func(_ string, v verification) bool { return now.After(v.expires) })
+		if len(g.verified) >= maxTracked {
+			clear(g.verified)
+		}
+	}
+	g.verified[key] = verification{ok: ok, expires: now.Add(verifyTTL)}
+	g.mu.Unlock()
+	return ok
+}
+
+// verifyCrawler checks ip against the domains of cr. It returns an error
+// when a lookup failed transiently and the outcome is unknown.
+func verifyCrawler(ctx context.Context, r Resolver, ip string, cr Crawler) (bool, error) {
+	names, err := r.LookupAddr(ctx, ip)
+	if err != nil {
+		return false, transient(err)
+	}
+	for _, name := range names {
+		host := strings.TrimSuffix(strings.ToLower(name), ".")
+		if !slices.ContainsFunc(cr.Domains, func(d string) bool { return strings.HasSuffix(host, d) }) {
+			continue
+		}
+		addrs, err := r.LookupHost(ctx, host)
+		if err != nil {
+			if err = transient(err); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if slices.Contains(addrs, ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// transient returns err unless it reports a missing DNS record, which
+// is a definite answer.
+func transient(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil
+	}
+	return err
+}
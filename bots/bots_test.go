@@ -0,0 +1,210 @@
+// Package bots
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package bots
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+	"github.com/wantnotshould/sol/ratelimit"
+)
+
+// fakeDNS resolves 66.249.66.1 as a real Googlebot.
+type fakeDNS struct{}
+
+func (fakeDNS) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	if addr == "66.249.66.1" {
+		return []string{"crawl-66-249-66-1.googlebot.com."}, nil
+	}
+	return nil, &net.DNSError{Err: "no PTR record", Name: addr, IsNotFound: true}
+}
+
+func (fakeDNS) LookupHost(_ context.Context, host string) ([]string, error) {
+	if host == "crawl-66-249-66-1.googlebot.com" {
+		return []string{"66.249.66.1"}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// flakyDNS fails its first lookup with a timeout, then answers like fakeDNS.
+type flakyDNS struct {
+	fakeDNS
+	failed bool
+}
+
+func (f *flakyDNS) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	if !f.failed {
+		f.failed = true
+		return nil, &net.DNSError{Err: "i/o timeout", Name: addr, IsTimeout: true}
+	}
+	return f.fakeDNS.LookupAddr(ctx, addr)
+}
+
+const (
+	googlebotUA = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+	browserUA   = "Mozilla/5.0 (X11; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0"
+)
+
+func TestMiddleware(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	sl := sol.New()
+	sl.Use(Middleware(Config{
+		Action:    Throttle,
+		Throttle:  ratelimit.PerMinute(2),
+		Resolver:  fakeDNS{},
+		Honeypots: []string{"/wp-admin"},
+	}))
+	sl.GET("/*path", func(c *sol.Context) {
+		v := From(c)
+		c.String(http.StatusOK, "bot=%v crawler=%s verified=%v", v.Bot, v.Crawler, v.Verified)
+	})
+
+	do := func(ip, ua, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("User-Agent", ua)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		ip, ua string
+		status int
+		body   string
+	}{
+		{"browser", "203.0.113.1", browserUA, http.StatusOK, "bot=false crawler= verified=false"},
+		{"verified crawler", "66.249.66.1", googlebotUA, http.StatusOK, "bot=true crawler=Googlebot verified=true"},
+		{"fake crawler", "203.0.113.2", googlebotUA, http.StatusForbidden, ""},
+		{"script", "203.0.113.3", "curl/8.7.1", http.StatusOK, "bot=true crawler= verified=false"},
+	}
+	for _, tt := range tests {
+		rec := do(tt.ip, tt.ua, "/page")
+		if rec.Code != tt.status || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s: %d %q, want %d %q", tt.name, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+
+	// The script is throttled after its second request, browsers are not.
+	if rec := do("203.0.113.3", "curl/8.7.1", "/page"); rec.Code != http.StatusOK {
+		t.Errorf("second script request: %d", rec.Code)
+	}
+	if rec := do("203.0.113.3", "curl/8.7.1", "/page"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("third script request: %d, want 429", rec.Code)
+	}
+	for range 3 {
+		if rec := do("203.0.113.1", browserUA, "/page"); rec.Code != http.StatusOK {
+			t.Errorf("browser throttled: %d", rec.Code)
+		}
+	}
+
+	// A honeypot visit bans the client.
+	if rec := do("203.0.113.9", browserUA, "/wp-admin"); rec.Code != http.StatusForbidden {
+		t.Errorf("honeypot: %d", rec.Code)
+	}
+	if rec := do("203.0.113.9", browserUA, "/page"); rec.Code != http.StatusForbidden {
+		t.Errorf("banned client: %d, want 403", rec.Code)
+	}
+}
+
+func TestMiddleware_IgnoresForwardedFor(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	sl := sol.New()
+	sl.Use(Middleware(Config{Resolver: fakeDNS{}, Honeypots: []string{"/trap"}}))
+	sl.GET("/*path", func(c *sol.Context) { c.Status(http.StatusOK) })
+
+	do := func(remote, forwarded, ua, path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remote + ":1234"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		req.Header.Set("User-Agent", ua)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A fake crawler cannot borrow a Googlebot IP.
+	if code := do("203.0.113.2", "66.249.66.1", googlebotUA, "/page"); code != http.StatusForbidden {
+		t.Errorf("fake crawler with forged XFF: %d, want 403", code)
+	}
+	// Nor get a victim banned.
+	do("203.0.113.5", "198.51.100.7", browserUA, "/trap")
+	if code := do("198.51.100.7", "", browserUA, "/page"); code != http.StatusOK {
+		t.Errorf("victim of forged XFF: %d, want 200", code)
+	}
+}
+
+func TestMiddleware_SpoofedForwardedForThroughProxy(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	if err := sol.SetTrustedProxies("10.0.0.0/8"); err != nil {
+		t.Fatal(err)
+	}
+	defer sol.SetTrustedProxies()
+
+	sl := sol.New()
+	sl.Use(Middleware(Config{Resolver: fakeDNS{}, Honeypots: []string{"/trap"}}))
+	sl.GET("/*path", func(c *sol.Context) { c.Status(http.StatusOK) })
+
+	do := func(forwarded, path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		req.Header.Set("User-Agent", browserUA)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	do("9.9.9.9, 203.0.113.9", "/trap")
+	// A banned client cannot escape by rotating a forged prefix.
+	if code := do("8.8.8.8, 203.0.113.9", "/page"); code != http.StatusForbidden {
+		t.Errorf("banned client with forged XFF: %d, want 403", code)
+	}
+	// Nor get the forged address banned.
+	if code := do("9.9.9.9", "/page"); code != http.StatusOK {
+		t.Errorf("forged address: %d, want 200", code)
+	}
+}
+
+func TestVerifyTransientError(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	g := &guard{cfg: Config{Resolver: &flakyDNS{}}, banned: make(map[string]time.Time), verified: make(map[string]verification)}
+	if g.verify(context.Background(), "66.249.66.1", KnownCrawlers[0]) {
+		t.Fatal("verified despite the DNS timeout")
+	}
+	if !g.verify(context.Background(), "66.249.66.1", KnownCrawlers[0]) {
+		t.Error("DNS timeout was cached as a failed verification")
+	}
+}
+
+func TestBanBounded(t *testing.T) {
+	defer func(n int) { maxTracked = n }(maxTracked)
+	maxTracked = 2
+
+	g := &guard{cfg: Config{BanDuration: time.Hour}, banned: make(map[string]time.Time)}
+	for _, ip := range []string{"a", "b", "c"} {
+		g.ban(ip)
+	}
+	if len(g.banned) != 2 || !g.isBanned("c") {
+		t.Errorf("banned = %v", g.banned)
+	}
+}
@@ -0,0 +1,35 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer is the largest buffer capacity returned to the pool,
+// so one huge response does not stay pinned in memory.
+const maxPooledBuffer = 64 << 10 // 64 KB
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns buf to the pool unless it grew beyond maxPooledBuffer.
+// buf must not be used afterwards.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,91 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache describes a Cache-Control header. Durations are rounded down to
+// whole seconds; zero durations are left out.
+type Cache struct {
+	MaxAge  time.Duration
+	SMaxAge time.Duration
+
+	Public  bool
+	Private bool
+
+	NoCache        bool
+	NoStore        bool
+	NoTransform    bool
+	MustRevalidate bool
+	Immutable      bool
+
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+}
+
+// DefaultStaticCache is applied to files served by StaticFS, StaticEmbed
+// and SPA, except index.html, which is always revalidated. Files are
+// revalidated hourly through Last-Modified.
+var DefaultStaticCache = Cache{Public: true, MaxAge: time.Hour}
+
+// String formats the directives, e.g. "public, max-age=3600".
+func (cc Cache) String() string {
+	var b strings.Builder
+	add := func(directive string) {
+		if b.Len() > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(directive)
+	}
+	addSecs := func(directive string, d time.Duration) {
+		if d > 0 {
+			add(directive + "=" + strconv.FormatInt(int64(d/time.Second), 10))
+		}
+	}
+
+	if cc.Public {
+		add("public")
+	}
+	if cc.Private {
+		add("private")
+	}
+	if cc.NoCache {
+		add("no-cache")
+	}
+	if cc.NoStore {
+		add("no-store")
+	}
+	if cc.NoTransform {
+		add("no-transform")
+	}
+	if cc.MustRevalidate {
+		add("must-revalidate")
+	}
+	addSecs("max-age", cc.MaxAge)
+	addSecs("s-maxage", cc.SMaxAge)
+	addSecs("stale-while-revalidate", cc.StaleWhileRevalidate)
+	addSecs("stale-if-error", cc.StaleIfError)
+	if cc.Immutable {
+		add("immutable")
+	}
+	return b.String()
+}
+
+// CacheControl sets the Cache-Control response header.
+func (c *Context) CacheControl(cc Cache) {
+	c.Writer.Header().Set("Cache-Control", cc.String())
+}
+
+// NoCache marks the response as not cacheable by browsers or proxies.
+func (c *Context) NoCache() {
+	h := c.Writer.Header()
+	h.Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	h.Set("Pragma", "no-cache")
+	h.Set("Expires", "0")
+}
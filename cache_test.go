@@ -0,0 +1,55 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestCache_String(t *testing.T) {
+	tests := []struct {
+		cc   Cache
+		want string
+	}{
+		{Cache{}, ""},
+		{Cache{Public: true, MaxAge: time.Hour}, "public, max-age=3600"},
+		{Cache{Private: true, NoCache: true}, "private, no-cache"},
+		{Cache{Public: true, MaxAge: 365 * 24 * time.Hour, Immutable: true}, "public, max-age=31536000, immutable"},
+		{Cache{MaxAge: time.Minute, SMaxAge: 10 * time.Minute, StaleWhileRevalidate: 30 * time.Second, StaleIfError: time.Hour}, "max-age=60, s-maxage=600, stale-while-revalidate=30, stale-if-error=3600"},
+	}
+	for _, tt := range tests {
+		if got := tt.cc.String(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.cc, got, tt.want)
+		}
+	}
+}
+
+func TestStaticCacheDefault(t *testing.T) {
+	sl := New()
+	sl.StaticFS("/assets", fstest.MapFS{"app.js": {Data: []byte("app")}})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/assets/app.js", nil))
+	if got := rec.Header().Get("Cache-Control"); got != DefaultStaticCache.String() {
+		t.Errorf("Cache-Control = %q, want %q", got, DefaultStaticCache.String())
+	}
+}
+
+func TestContext_NoCache(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := &Context{Writer: rec}
+	c.NoCache()
+
+	if got := rec.Header().Get("Cache-Control"); got != "no-store, no-cache, must-revalidate, max-age=0" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if rec.Header().Get("Pragma") != "no-cache" || rec.Header().Get("Expires") != "0" {
+		t.Errorf("headers = %v", rec.Header())
+	}
+}
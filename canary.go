@@ -0,0 +1,107 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// variantKey is the Context key holding the variant chosen by Canary.
+const variantKey = "sol.variant"
+
+// Variants recorded by Canary.
+const (
+	VariantStable = "stable"
+	VariantCanary = "canary"
+)
+
+// StickyFunc returns the key keeping a client on the same Canary variant,
+// or "" to pick at random.
+type StickyFunc func(c *Context) string
+
+// StickyByCookie keeps clients on a variant by the value of a cookie,
+// e.g. a session or device ID.
+func StickyByCookie(name string) StickyFunc {
+	return func(c *Context) string {
+		v, _ := c.Cookie(name)
+		return v
+	}
+}
+
+// StickyByPrincipal keeps authenticated users on a variant. Auth
+// middleware must run first.
+func StickyByPrincipal(c *Context) string {
+	if p := c.Principal(); p != nil {
+		return p.Subject()
+	}
+	return ""
+}
+
+// Canary sends percent of the route's traffic to h instead of its final
+// handler, for gradual rollouts:
+//
+//	sl.GET("/search", search).Canary(searchV2, 5)
+//
+// Requests are split at random unless a StickyFunc is given, in which
+// case a key always lands on the same variant. The chosen variant is
+// recorded on the Context, see Context.Variant.
+func (rt *Route) Canary(h HandlerFunc, percent int, sticky ...StickyFunc) *Route {
+	if percent < 0 || percent > 100 {
+		panic(fmt.Sprintf("cannot register '%s %s': canary percent %d out of range 0-100", rt.method, rt.path, percent))
+	}
+	if len(rt.handlers) == 0 {
+		panic(fmt.Sprintf("cannot register '%s %s': Canary needs a stable handler", rt.method, rt.path))
+	}
+
+	var key StickyFunc
+	if len(sticky) > 0 {
+		key = sticky[0]
+	}
+
+	// Build a new slice rather than editing rt.handlers, which may be the
+	// caller's, and keep the stable handler itself in the chain.
+	last := len(rt.handlers) - 1
+	handlers := make([]HandlerFunc, 0, len(rt.handlers)+1)
+	handlers = append(handlers, rt.handlers[:last]...)
+	rt.handlers = append(handlers, canarySplit(h, percent, key), rt.handlers[last])
+	rt.router.recompose(rt)
+	return rt
+}
+
+// canarySplit runs right before the stable handler. Canary requests are
+// answered by canary, skipping the stable handler; the others go on to
+// it, so Handlers and HandlerName still name it.
+func canarySplit(canary HandlerFunc, percent int, key StickyFunc) HandlerFunc {
+	return func(c *Context) {
+		if canaryBucket(c, key) < percent {
+			c.Set(variantKey, VariantCanary)
+			canary(c)
+			c.index++
+			return
+		}
+		c.Set(variantKey, VariantStable)
+	}
+}
+
+// canaryBucket places the request in one of 100 buckets.
+func canaryBucket(c *Context, key StickyFunc) int {
+	if key != nil {
+		if k := key(c); k != "" {
+			h := fnv.New32a()
+			h.Write([]byte(c.RoutePattern() + "\x00" + k))
+			return int(h.Sum32() % 100)
+		}
+	}
+	return rand.IntN(100)
+}
+
+// Variant returns the variant Canary chose for the request, VariantStable
+// or VariantCanary, or "" on routes without a canary.
+func (c *Context) Variant() string {
+	v, _ := c.GetString(variantKey)
+	return v
+}
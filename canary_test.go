@@ -0,0 +1,92 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_Canary(t *testing.T) {
+	sl := New()
+	respond := func(c *Context) { c.String(http.StatusOK, "%s", c.Variant()) }
+
+	sl.GET("/all", respond).Canary(respond, 100)
+	sl.GET("/none", respond).Canary(respond, 0)
+	sl.GET("/split", respond).Canary(respond, 30)
+	sl.GET("/sticky", respond).Canary(respond, 50, StickyByCookie("uid"))
+
+	get := func(path, uid string) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if uid != "" {
+			req.AddCookie(&http.Cookie{Name: "uid", Value: uid})
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	if got := get("/all", ""); got != VariantCanary {
+		t.Errorf("100%%: variant = %q", got)
+	}
+	if got := get("/none", ""); got != VariantStable {
+		t.Errorf("0%%: variant = %q", got)
+	}
+
+	canary := 0
+	for range 2000 {
+		if get("/split", "") == VariantCanary {
+			canary++
+		}
+	}
+	if canary < 450 || canary > 750 {
+		t.Errorf("30%% split sent %d of 2000 requests to the canary", canary)
+	}
+
+	seen := map[string]bool{}
+	for i := range 50 {
+		uid := fmt.Sprint("user-", i)
+		first := get("/sticky", uid)
+		for range 5 {
+			if got := get("/sticky", uid); got != first {
+				t.Fatalf("%s moved from %s to %s", uid, first, got)
+			}
+		}
+		seen[first] = true
+	}
+	if !seen[VariantStable] || !seen[VariantCanary] {
+		t.Errorf("sticky split used only %v", seen)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for percent over 100")
+		}
+	}()
+	sl.GET("/bad", respond).Canary(respond, 101)
+}
+
+func stableHandler(c *Context) { c.String(http.StatusOK, "%s", c.HandlerName()) }
+
+func TestRoute_CanaryHandlerNames(t *testing.T) {
+	sl := New()
+	handlers := []HandlerFunc{func(c *Context) { c.Next() }, stableHandler}
+	rt := sl.GET("/search", handlers...).Canary(func(c *Context) {}, 0)
+
+	if names := rt.Handlers(); names[len(names)-1] != "sol.stableHandler" {
+		t.Errorf("Handlers = %v", names)
+	}
+	if got := handlerName(handlers[1]); got != "sol.stableHandler" {
+		t.Errorf("caller's slice was modified: %s", got)
+	}
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search", nil))
+	if rec.Body.String() != "sol.stableHandler" {
+		t.Errorf("HandlerName = %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,56 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import "log"
+
+// bodyCapture configures the request bodies attached to error reports.
+type bodyCapture struct {
+	limit  int
+	redact *Redaction
+}
+
+// WithBodyCapture attaches up to limit bytes of the request body to the
+// logged reports of panics and 5xx responses, redacted with r, or
+// DefaultRedaction if nil, to help reproduce failures. Only bodies cached
+// by Context.Body are captured; streamed bodies cannot be read again and
+// Raw routes are left out.
+func (sl *Sol) WithBodyCapture(limit int, r *Redaction) *Sol {
+	if limit <= 0 {
+		sl.capture = nil
+		return sl
+	}
+	if r == nil {
+		r = &DefaultRedaction
+	}
+	sl.capture = &bodyCapture{limit: limit, redact: r}
+	return sl
+}
+
+// capturedBody returns the redacted and truncated request body for an
+// error report, or "" if capture is off or the body was not read.
+func (c *Context) capturedBody() string {
+	if c.engine == nil || c.engine.capture == nil || c.raw || !c.bodyRead || len(c.body) == 0 {
+		return ""
+	}
+	cfg := c.engine.capture
+	b := cfg.redact.Body(c.Header("Content-Type"), c.body)
+	if len(b) > cfg.limit {
+		return string(b[:cfg.limit]) + "...(truncated)"
+	}
+	return string(b)
+}
+
+// reportServerError logs the captured body of a request answered with a
+// 5xx status, unless a panic report already carried it.
+func (c *Context) reportServerError() {
+	if c.bodyReported || c.rw.status < 500 {
+		return
+	}
+	if body := c.capturedBody(); body != "" {
+		log.Printf("[ERROR] %s %s answered %d (request %s)\nbody: %s",
+			c.Method(), c.Path(), c.rw.status, c.RequestID(), body)
+	}
+}
@@ -0,0 +1,249 @@
+// Package client
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+// Config configures a Client.
+type Config struct {
+	// Timeout bounds each call, retries included, 10s by default.
+	Timeout time.Duration
+	// Retries is the number of retries of failed idempotent requests.
+	Retries int
+	// Backoff is the wait before the first retry, doubled for each
+	// following one up to MaxBackoff, with jitter. 100ms by default.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Transport is http.DefaultTransport by default.
+	Transport http.RoundTripper
+}
+
+// Client is an http.Client for calls between services. Requests made
+// while handling a sol request carry its request ID and trace context.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	requests atomic.Uint64
+	inFlight atomic.Int64
+	retries  atomic.Uint64
+	failures atomic.Uint64
+	status   [6]atomic.Uint64 // by status class, 1xx to 5xx
+	duration atomic.Int64     // total nanoseconds
+}
+
+// New returns a Client.
+func New(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = 100 * time.Millisecond
+	}
+	if cfg.MaxBackoff < cfg.Backoff {
+		cfg.MaxBackoff = max(2*time.Second, cfg.Backoff)
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+	return &Client{cfg: cfg, http: &http.Client{Transport: cfg.Transport}}
+}
+
+// Get issues a GET request to url.
+func (cl *Client) Get(c *sol.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Do(c, req)
+}
+
+// Post issues a POST request to url.
+func (cl *Client) Post(c *sol.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return cl.Do(c, req)
+}
+
+// Do sends req within the Timeout. With a sol Context c, which may be nil,
+// the call is cancelled with the incoming request and carries its request
+// ID and trace headers. Idempotent requests are retried on network
+// errors and 429, 502, 503 and 504 responses, if their body can be
+// replayed.
+func (cl *Client) Do(c *sol.Context, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if c != nil {
+		if ctx == context.Background() {
+			ctx = c.Context()
+		}
+		req.Header.Set(sol.RequestIDHeader, c.RequestID())
+		c.InjectTraceHeaders(req)
+	}
+	ctx, cancel := context.WithTimeout(ctx, cl.cfg.Timeout)
+	req = req.WithContext(ctx)
+
+	cl.requests.Add(1)
+	cl.inFlight.Add(1)
+	defer cl.inFlight.Add(-1)
+	start := time.Now()
+	defer func() { cl.duration.Add(int64(time.Since(start))) }()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := cl.http.Do(req)
+		if err == nil {
+			cl.status[min(resp.StatusCode/100, 5)].Add(1)
+		}
+		if attempt >= cl.cfg.Retries || !retryable(req, resp, err) {
+			if err != nil {
+				cl.failures.Add(1)
+				cancel()
+				return nil, err
+			}
+			// The timeout covers reading the body, so cancel on Close.
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		cl.retries.Add(1)
+		select {
+		case <-time.After(cl.backoff(attempt)):
+		case <-ctx.Done():
+			cl.failures.Add(1)
+			cancel()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+var idempotent = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// retryable reports whether a failed attempt may be sent again.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if !slices.Contains(idempotent, req.Method) {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry attempt+1, with full jitter in
+// its upper half.
+func (cl *Client) backoff(attempt int) time.Duration {
+	d := cl.cfg.Backoff << attempt
+	if d <= 0 || d > cl.cfg.MaxBackoff {
+		d = cl.cfg.MaxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// Stats is a snapshot of the client's calls.
+type Stats struct {
+	Requests uint64 `json:"requests"`
+	InFlight int64  `json:"in_flight"`
+	Retries  uint64 `json:"retries"`
+	// Failures counts calls that got no response.
+	Failures uint64 `json:"failures"`
+	// Responses counts responses by status class, "2xx" to "5xx".
+	Responses map[string]uint64 `json:"responses"`
+	Duration  time.Duration     `json:"duration"`
+}
+
+// Stats returns the current statistics.
+func (cl *Client) Stats() Stats {
+	s := Stats{
+		Requests:  cl.requests.Load(),
+		InFlight:  cl.inFlight.Load(),
+		Retries:   cl.retries.Load(),
+		Failures:  cl.failures.Load(),
+		Responses: make(map[string]uint64),
+		Duration:  time.Duration(cl.duration.Load()),
+	}
+	for class := 1; class <= 5; class++ {
+		if n := cl.status[class].Load(); n > 0 {
+			s.Responses[fmt.Sprintf("%dxx", class)] = n
+		}
+	}
+	return s
+}
+
+// Metrics serves Stats in the Prometheus text format, alongside
+// Sol.EnableMetrics:
+//
+//	sl.GET("/metrics/client", api.Metrics())
+func (cl *Client) Metrics() sol.HandlerFunc {
+	return func(c *sol.Context) {
+		c.SetHeader("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Status(http.StatusOK)
+		io.WriteString(c.Writer, cl.Stats().prometheus())
+	}
+}
+
+func (s Stats) prometheus() string {
+	var b strings.Builder
+	metric := func(name, kind, help string, v any) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, v)
+	}
+
+	metric("sol_client_requests_total", "counter", "Outbound calls made.", s.Requests)
+	metric("sol_client_requests_in_flight", "gauge", "Outbound calls in progress.", s.InFlight)
+	metric("sol_client_retries_total", "counter", "Outbound attempts retried.", s.Retries)
+	metric("sol_client_failures_total", "counter", "Outbound calls without a response.", s.Failures)
+	fmt.Fprintf(&b, "# HELP sol_client_responses_total Responses by status class.\n# TYPE sol_client_responses_total counter\n")
+	for class := 1; class <= 5; class++ {
+		code := fmt.Sprintf("%dxx", class)
+		fmt.Fprintf(&b, "sol_client_responses_total{code=%q} %d\n", code, s.Responses[code])
+	}
+	metric("sol_client_request_duration_seconds_total", "counter", "Total time spent in outbound calls.", s.Duration.Seconds())
+	return b.String()
+}
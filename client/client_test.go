@@ -0,0 +1,87 @@
+// Package client
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestClient(t *testing.T) {
+	var hits atomic.Int32
+	var gotID, gotTrace string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, gotTrace = r.Header.Get(sol.RequestIDHeader), r.Header.Get("traceparent")
+		if r.URL.Path == "/flaky" && hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(append([]byte("ok "), body...))
+	}))
+	defer upstream.Close()
+
+	cl := New(Config{Retries: 2, Backoff: time.Millisecond, Timeout: 100 * time.Millisecond})
+
+	sl := sol.New()
+	sl.GET("/call", func(c *sol.Context) {
+		resp, err := cl.Get(c, upstream.URL+"/flaky")
+		if err != nil {
+			c.String(http.StatusBadGateway, "%v", err)
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		c.String(resp.StatusCode, "%s", body)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/call", nil)
+	req.Header.Set(sol.RequestIDHeader, "req-1")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok " {
+		t.Fatalf("call: %d %q", rec.Code, rec.Body.String())
+	}
+	if gotID != "req-1" || !strings.HasPrefix(gotTrace, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+		t.Errorf("propagated request ID %q, traceparent %q", gotID, gotTrace)
+	}
+
+	// POST is not idempotent and is not retried.
+	hits.Store(0)
+	resp, err := cl.Post(nil, upstream.URL+"/flaky", "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || hits.Load() != 1 {
+		t.Errorf("POST: %d after %d attempts", resp.StatusCode, hits.Load())
+	}
+
+	if _, err := cl.Get(nil, upstream.URL+"/slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow call: %v, want deadline exceeded", err)
+	}
+
+	s := cl.Stats()
+	if s.Requests != 3 || s.Retries != 2 || s.Failures != 1 || s.Responses["5xx"] != 3 || s.Responses["2xx"] != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+	if m := s.prometheus(); !strings.Contains(m, `sol_client_responses_total{code="5xx"} 3`) {
+		t.Errorf("metrics:\n%s", m)
+	}
+}
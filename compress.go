@@ -0,0 +1,118 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"sync"
+)
+
+var gzipPool = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+// Compress gzips responses for clients that prefer gzip over identity,
+// as decided by NegotiateEncoding; identity counts as q=1 unless listed,
+// so "gzip;q=0.5" alone leaves responses uncompressed. Responses a
+// handler encoded itself, e.g. a cached gzip body sent after checking
+// AcceptsEncoding, pass through untouched, as do responses without a
+// body and Raw routes. Flushes reach the client, so streams keep working.
+func Compress() HandlerFunc {
+	return func(c *Context) {
+		if c.IsRaw() {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if c.Method() == http.MethodHead || c.NegotiateEncoding("gzip", "identity") != "gzip" {
+			c.Next()
+			return
+		}
+
+		w := c.Writer
+		cw := &compressWriter{ResponseWriter: w}
+		c.Writer = cw
+		// Restore the writer even on panic, so Recover can still answer.
+		defer func() { c.Writer = w }()
+
+		c.Next()
+		cw.close()
+	}
+}
+
+// compressWriter gzips the body once the status shows there is one and
+// the handler did not set Content-Encoding itself.
+type compressWriter struct {
+	http.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided && code >= http.StatusOK {
+		w.decided = true
+		h := w.Header()
+		if bodyAllowed(code) && h.Get("Content-Encoding") == "" {
+			h.Set("Content-Encoding", "gzip")
+			h.Del("Content-Length")
+			w.gz = gzipPool.Get().(*gzip.Writer)
+			w.gz.Reset(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError pushes the data compressed so far to the client.
+func (w *compressWriter) FlushError() error {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher for handlers asserting it directly.
+func (w *compressWriter) Flush() {
+	w.FlushError()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close()
+	w.gz.Reset(io.Discard)
+	gzipPool.Put(w.gz)
+	w.gz = nil
+}
+
+// bodyAllowed reports whether a response with status code has a body.
+func bodyAllowed(code int) bool {
+	return code != http.StatusNoContent && code != http.StatusNotModified
+}
@@ -0,0 +1,122 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat("hello sol ", 100)
+	precompressed := gzipBytes(t, "cached")
+
+	sl := New()
+	sl.Use(Compress())
+	sl.GET("/text", func(c *Context) { c.String(http.StatusOK, "%s", body) })
+	sl.GET("/cached", func(c *Context) {
+		if !c.AcceptsEncoding("gzip") {
+			c.String(http.StatusOK, "cached")
+			return
+		}
+		c.SetHeader("Content-Encoding", "gzip")
+		c.Status(http.StatusOK)
+		c.Writer.Write(precompressed)
+	})
+	sl.GET("/empty", func(c *Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		encoding string
+	}{
+		{"none", "/text", "", ""},
+		{"gzip", "/text", "gzip", "gzip"},
+		{"gzip weighted", "/text", "br;q=0.5, gzip", "gzip"},
+		{"gzip below implicit identity", "/text", "gzip;q=0.5", ""},
+		{"gzip refused", "/text", "gzip;q=0", ""},
+		{"identity preferred", "/text", "identity, gzip;q=0.5", ""},
+		{"identity refused", "/text", "identity;q=0, gzip;q=0.1", "gzip"},
+		{"wildcard", "/text", "*", "gzip"},
+		{"wildcard refused", "/text", "*;q=0, identity", ""},
+		{"unknown coding", "/text", "zstd", ""},
+		{"precompressed", "/cached", "gzip", "gzip"},
+		{"precompressed fallback", "/cached", "br", ""},
+		{"no body", "/empty", "gzip", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept-Encoding", tt.accept)
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.encoding)
+			}
+			if rec.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("Vary = %q", rec.Header().Get("Vary"))
+			}
+
+			got := rec.Body.Bytes()
+			if tt.encoding == "gzip" {
+				zr, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, err = io.ReadAll(zr); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want := map[string]string{"/text": body, "/cached": "cached", "/empty": ""}[tt.path]
+			if string(got) != want {
+				t.Errorf("body = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompress_flush(t *testing.T) {
+	sl := New()
+	sl.Use(Compress())
+	sl.GET("/events", func(c *Context) {
+		c.Status(http.StatusOK)
+		io.WriteString(c.Writer, "data: 1\n\n")
+		c.Writer.(http.Flusher).Flush()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if !rec.Flushed {
+		t.Error("flush did not reach the client")
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(zr); string(got) != "data: 1\n\n" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	io.WriteString(zw, s)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
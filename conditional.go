@@ -0,0 +1,99 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// Matcher selects requests for When and Unless.
+type Matcher func(c *Context) bool
+
+// When runs m only for requests matching pred; others continue straight
+// to the next handler:
+//
+//	sl.Use(sol.When(sol.MatchPath("/admin/*"), requireAdmin))
+func When(pred Matcher, m HandlerFunc) HandlerFunc {
+	return func(c *Context) {
+		if pred(c) {
+			m(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Unless runs m for every request not matching pred:
+//
+//	sl.Use(sol.Unless(sol.MatchPath("/public/*", "/healthz"), auth))
+func Unless(pred Matcher, m HandlerFunc) HandlerFunc {
+	return When(Not(pred), m)
+}
+
+// MatchPath matches request paths against patterns. A pattern ending in
+// "/*" matches the whole subtree below it; others are path.Match globs,
+// whose "*" stops at slashes.
+func MatchPath(patterns ...string) Matcher {
+	return func(c *Context) bool {
+		p := c.Request.URL.Path
+		for _, pattern := range patterns {
+			if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+				if p == prefix || strings.HasPrefix(p, prefix+"/") {
+					return true
+				}
+				continue
+			}
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchMethod matches requests with one of methods.
+func MatchMethod(methods ...string) Matcher {
+	return func(c *Context) bool {
+		return slices.Contains(methods, c.Request.Method)
+	}
+}
+
+// MatchHeader matches requests whose header name has value, or that carry
+// the header at all if value is "".
+func MatchHeader(name, value string) Matcher {
+	return func(c *Context) bool {
+		v := c.Request.Header.Get(name)
+		if value == "" {
+			return v != ""
+		}
+		return v == value
+	}
+}
+
+// Not inverts pred.
+func Not(pred Matcher) Matcher {
+	return func(c *Context) bool { return !pred(c) }
+}
+
+// Any matches requests matching at least one of preds.
+func Any(preds ...Matcher) Matcher {
+	return func(c *Context) bool {
+		return slices.ContainsFunc(preds, func(p Matcher) bool { return p(c) })
+	}
+}
+
+// All matches requests matching every one of preds.
+func All(preds ...Matcher) Matcher {
+	return func(c *Context) bool {
+		for _, p := range preds {
+			if !p(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
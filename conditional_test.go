@@ -0,0 +1,62 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWhenUnless(t *testing.T) {
+	deny := func(c *Context) { c.AbortWithStatus(http.StatusUnauthorized) }
+
+	sl := New()
+	sl.Use(Unless(MatchPath("/public/*", "/healthz"), deny))
+	sl.Use(When(All(MatchMethod(http.MethodPost), MatchPath("/public/*.json")), func(c *Context) {
+		c.SetHeader("X-Matched", "yes")
+		c.Next()
+	}))
+	ok := func(c *Context) { c.Status(http.StatusOK) }
+	for _, p := range []string{"/public/*path", "/healthz", "/private"} {
+		sl.GET(p, ok)
+		sl.POST(p, ok)
+	}
+
+	tests := []struct {
+		method, path string
+		status       int
+		matched      bool
+	}{
+		{http.MethodGet, "/public/css/site.css", http.StatusOK, false},
+		{http.MethodPost, "/public/data.json", http.StatusOK, true},
+		{http.MethodPost, "/public/nested/data.json", http.StatusOK, false},
+		{http.MethodGet, "/healthz", http.StatusOK, false},
+		{http.MethodGet, "/private", http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status || (rec.Header().Get("X-Matched") == "yes") != tt.matched {
+			t.Errorf("%s %s: %d matched=%q", tt.method, tt.path, rec.Code, rec.Header().Get("X-Matched"))
+		}
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Beta", "1")
+	c := &Context{Request: req}
+
+	if !MatchHeader("X-Beta", "")(c) || !MatchHeader("X-Beta", "1")(c) || MatchHeader("X-Beta", "2")(c) {
+		t.Error("MatchHeader")
+	}
+	if !Any(MatchMethod(http.MethodPost), MatchHeader("X-Beta", ""))(c) || Any()(c) {
+		t.Error("Any")
+	}
+	if !All()(c) || Not(MatchPath("/*"))(c) {
+		t.Error("All or Not")
+	}
+}
@@ -0,0 +1,207 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Modes for Options.Mode.
+const (
+	// ModeRelease is the default.
+	ModeRelease = "release"
+	// ModeDebug logs every request with Logger.
+	ModeDebug = "debug"
+)
+
+// Options configures the engine declaratively, see ConfigFromEnv,
+// ConfigFromFile and Sol.WithOptions.
+type Options struct {
+	// Addr is the address Run listens on.
+	Addr     string
+	Timeouts Timeouts
+	// TLSCert and TLSKey make Run serve HTTPS from these files.
+	TLSCert string
+	TLSKey  string
+	// TrustedProxies are passed to SetTrustedProxies.
+	TrustedProxies []string
+	Mode           string
+	// LogLevel is passed to SetLogLevel, LevelInfo by default.
+	LogLevel string
+}
+
+// DefaultOptions returns the options matching New.
+func DefaultOptions() Options {
+	return Options{Timeouts: DefaultTimeouts, Mode: ModeRelease, LogLevel: LevelInfo}
+}
+
+// ConfigFromEnv reads Options from the environment, starting from
+// DefaultOptions:
+//
+//	SOL_ADDR                  address, e.g. ":8080"
+//	SOL_READ_HEADER_TIMEOUT   duration, e.g. "10s"
+//	SOL_READ_TIMEOUT          duration
+//	SOL_WRITE_TIMEOUT         duration
+//	SOL_IDLE_TIMEOUT          duration
+//	SOL_TLS_CERT              certificate file
+//	SOL_TLS_KEY               key file
+//	SOL_TRUSTED_PROXIES       comma separated IPs or CIDRs
+//	SOL_MODE                  "release" or "debug"
+//	SOL_LOG_LEVEL             "debug", "info", "warn" or "error"
+func ConfigFromEnv() (Options, error) {
+	o := DefaultOptions()
+	o.Addr = os.Getenv("SOL_ADDR")
+	o.TLSCert = os.Getenv("SOL_TLS_CERT")
+	o.TLSKey = os.Getenv("SOL_TLS_KEY")
+	if v := os.Getenv("SOL_TRUSTED_PROXIES"); v != "" {
+		o.TrustedProxies = strings.Split(v, ",")
+	}
+	if v := os.Getenv("SOL_MODE"); v != "" {
+		o.Mode = v
+	}
+	if v := os.Getenv("SOL_LOG_LEVEL"); v != "" {
+		o.LogLevel = v
+	}
+
+	for _, d := range []struct {
+		env string
+		dst *time.Duration
+	}{
+		{"SOL_READ_HEADER_TIMEOUT", &o.Timeouts.ReadHeader},
+		{"SOL_READ_TIMEOUT", &o.Timeouts.Read},
+		{"SOL_WRITE_TIMEOUT", &o.Timeouts.Write},
+		{"SOL_IDLE_TIMEOUT", &o.Timeouts.Idle},
+	} {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return Options{}, fmt.Errorf("sol: %s: %w", d.env, err)
+		}
+		*d.dst = dur
+	}
+	return o, o.validate()
+}
+
+// optionsFile is the JSON form of Options, with durations as strings.
+type optionsFile struct {
+	Addr     string `json:"addr"`
+	Timeouts struct {
+		ReadHeader *string `json:"read_header"`
+		Read       *string `json:"read"`
+		Write      *string `json:"write"`
+		Idle       *string `json:"idle"`
+	} `json:"timeouts"`
+	TLSCert        string   `json:"tls_cert"`
+	TLSKey         string   `json:"tls_key"`
+	TrustedProxies []string `json:"trusted_proxies"`
+	Mode           string   `json:"mode"`
+	LogLevel       string   `json:"log_level"`
+}
+
+// ConfigFromFile reads Options from a JSON file, starting from
+// DefaultOptions. Keys mirror ConfigFromEnv:
+//
+//	{
+//	  "addr": ":8080",
+//	  "timeouts": {"read_header": "5s", "write": "1m"},
+//	  "trusted_proxies": ["10.0.0.0/8"],
+//	  "mode": "release",
+//	  "log_level": "warn"
+//	}
+func ConfigFromFile(path string) (Options, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Options{}, err
+	}
+	var f optionsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Options{}, fmt.Errorf("sol: %s: %w", path, err)
+	}
+
+	o := DefaultOptions()
+	o.Addr, o.TLSCert, o.TLSKey = f.Addr, f.TLSCert, f.TLSKey
+	o.TrustedProxies = f.TrustedProxies
+	if f.Mode != "" {
+		o.Mode = f.Mode
+	}
+	if f.LogLevel != "" {
+		o.LogLevel = f.LogLevel
+	}
+
+	for _, d := range []struct {
+		key string
+		src *string
+		dst *time.Duration
+	}{
+		{"read_header", f.Timeouts.ReadHeader, &o.Timeouts.ReadHeader},
+		{"read", f.Timeouts.Read, &o.Timeouts.Read},
+		{"write", f.Timeouts.Write, &o.Timeouts.Write},
+		{"idle", f.Timeouts.Idle, &o.Timeouts.Idle},
+	} {
+		if d.src == nil {
+			continue
+		}
+		dur, err := time.ParseDuration(*d.src)
+		if err != nil {
+			return Options{}, fmt.Errorf("sol: %s: timeouts.%s: %w", path, d.key, err)
+		}
+		*d.dst = dur
+	}
+	return o, o.validate()
+}
+
+func (o Options) validate() error {
+	if o.Mode != ModeRelease && o.Mode != ModeDebug {
+		return fmt.Errorf("sol: unknown mode %q", o.Mode)
+	}
+	if _, ok := logLevels[o.LogLevel]; !ok {
+		return fmt.Errorf("sol: unknown log level %q", o.LogLevel)
+	}
+	if (o.TLSCert == "") != (o.TLSKey == "") {
+		return fmt.Errorf("sol: TLS needs both a certificate and a key")
+	}
+	return nil
+}
+
+// WithOptions applies o to the engine. Zero Timeouts mean DefaultTimeouts,
+// not none. The trusted proxies and log level are process wide; without
+// TrustedProxies the current setting is kept. It panics on options that
+// ConfigFromEnv or ConfigFromFile would reject.
+func (sl *Sol) WithOptions(o Options) *Sol {
+	if o.Mode == "" {
+		o.Mode = ModeRelease
+	}
+	if o.LogLevel == "" {
+		o.LogLevel = LevelInfo
+	}
+	if o.Timeouts == (Timeouts{}) {
+		o.Timeouts = DefaultTimeouts
+	}
+	if err := o.validate(); err != nil {
+		panic(err.Error())
+	}
+	if len(o.TrustedProxies) > 0 {
+		if err := SetTrustedProxies(o.TrustedProxies...); err != nil {
+			panic(err.Error())
+		}
+	}
+	SetLogLevel(o.LogLevel)
+
+	// The access log is added once, when debug mode is first entered.
+	debugLog := o.Mode == ModeDebug && sl.opts.Mode != ModeDebug
+	sl.opts = o
+	sl.WithTimeouts(o.Timeouts)
+	if debugLog {
+		sl.WithLogger()
+	}
+	return sl
+}
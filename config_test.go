@@ -0,0 +1,92 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SOL_ADDR", ":8080")
+	t.Setenv("SOL_WRITE_TIMEOUT", "2m")
+	t.Setenv("SOL_TRUSTED_PROXIES", "10.0.0.0/8,192.0.2.1")
+	t.Setenv("SOL_LOG_LEVEL", "warn")
+
+	o, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Addr != ":8080" || o.Timeouts.Write != 2*time.Minute || o.Timeouts.Read != DefaultTimeouts.Read {
+		t.Errorf("options = %+v", o)
+	}
+	if !slices.Equal(o.TrustedProxies, []string{"10.0.0.0/8", "192.0.2.1"}) || o.LogLevel != LevelWarn || o.Mode != ModeRelease {
+		t.Errorf("options = %+v", o)
+	}
+
+	t.Setenv("SOL_READ_TIMEOUT", "soon")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("invalid duration accepted")
+	}
+}
+
+func TestConfigFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sol.json")
+	write := func(s string) {
+		if err := os.WriteFile(path, []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"addr": ":9000", "timeouts": {"idle": "0s", "read_header": "5s"}, "mode": "debug"}`)
+	o, err := ConfigFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultOptions()
+	want.Addr, want.Mode = ":9000", ModeDebug
+	want.Timeouts.Idle, want.Timeouts.ReadHeader = 0, 5*time.Second
+	if o.Addr != want.Addr || o.Mode != want.Mode || o.Timeouts != want.Timeouts {
+		t.Errorf("options = %+v, want %+v", o, want)
+	}
+
+	for _, bad := range []string{
+		`{"mode": "turbo"}`,
+		`{"log_level": "loud"}`,
+		`{"tls_cert": "cert.pem"}`,
+		`{"timeouts": {"write": "forever"}}`,
+		`{"addr": 8080}`,
+	} {
+		write(bad)
+		if _, err := ConfigFromFile(path); err == nil {
+			t.Errorf("%s accepted", bad)
+		}
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	if err := SetTrustedProxies("10.0.0.0/8"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrustedProxies()
+
+	sl := New().WithOptions(Options{Addr: ":8080"})
+	if sl.server.ReadHeaderTimeout != DefaultTimeouts.ReadHeader || sl.server.IdleTimeout != DefaultTimeouts.Idle {
+		t.Errorf("zero Timeouts disabled the server timeouts: %v, %v", sl.server.ReadHeaderTimeout, sl.server.IdleTimeout)
+	}
+	if trustedProxies.Load() == nil {
+		t.Error("options without TrustedProxies reset the trusted proxies")
+	}
+
+	sl.WithOptions(Options{Mode: ModeDebug}).WithOptions(Options{Mode: ModeDebug})
+	defer SetLogLevel(LevelInfo)
+	route := sl.GET("/", func(c *Context) {})
+	if n := slices.Index(route.Handlers(), "sol.Logger.func1"); n < 0 || slices.Contains(route.Handlers()[n+1:], "sol.Logger.func1") {
+		t.Errorf("handlers = %v, want Logger once", route.Handlers())
+	}
+}
@@ -0,0 +1,114 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Connections holds the connection handling options of the server.
+// The zero value keeps the http.Server defaults.
+type Connections struct {
+	// MaxHeaderBytes caps the size of request headers, see
+	// http.Server.MaxHeaderBytes.
+	MaxHeaderBytes int
+	// MaxPerIP caps the open connections of a single remote IP. Excess
+	// connections are closed as soon as they are accepted.
+	MaxPerIP int
+	// DisableKeepAlives closes every connection after one request.
+	DisableKeepAlives bool
+	// DrainDelay is how long Run keeps serving with keep-alives disabled
+	// before shutting down, so clients and load balancers move their
+	// traffic elsewhere instead of seeing connections reset.
+	DrainDelay time.Duration
+}
+
+// WithConnections applies the connection options to the server.
+func (sl *Sol) WithConnections(c Connections) *Sol {
+	sl.conns = c
+	sl.server.MaxHeaderBytes = c.MaxHeaderBytes
+	sl.server.SetKeepAlivesEnabled(!c.DisableKeepAlives)
+	return sl
+}
+
+// listen opens the listener Run serves on, limited per IP when configured.
+func (sl *Sol) listen(addr string) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return sl.limitListener(ln), nil
+}
+
+// limitListener applies Connections.MaxPerIP to ln.
+func (sl *Sol) limitListener(ln net.Listener) net.Listener {
+	if sl.conns.MaxPerIP > 0 {
+		return newPerIPListener(ln, sl.conns.MaxPerIP)
+	}
+	return ln
+}
+
+// perIPListener closes accepted connections of IPs that already have limit
+// connections open.
+type perIPListener struct {
+	net.Listener
+	limit int
+
+	mu   sync.Mutex
+	open map[string]int
+}
+
+func newPerIPListener(ln net.Listener, limit int) *perIPListener {
+	return &perIPListener{Listener: ln, limit: limit, open: make(map[string]int)}
+}
+
+func (l *perIPListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		ip, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+		if err != nil {
+			return conn, nil
+		}
+		if !l.acquire(ip) {
+			conn.Close()
+			continue
+		}
+		return &perIPConn{Conn: conn, release: func() { l.release(ip) }}, nil
+	}
+}
+
+func (l *perIPListener) acquire(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.open[ip] >= l.limit {
+		return false
+	}
+	l.open[ip]++
+	return true
+}
+
+func (l *perIPListener) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.open[ip]--; l.open[ip] <= 0 {
+		delete(l.open, ip)
+	}
+}
+
+type perIPConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *perIPConn) Close() error {
+	c.once.Do(c.release)
+	return c.Conn.Close()
+}
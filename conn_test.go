@@ -0,0 +1,102 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPerIPListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limited := newPerIPListener(ln, 2)
+	defer limited.Close()
+
+	accepted := make(chan net.Conn, 4)
+	go func() {
+		for {
+			conn, err := limited.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	dial := func() net.Conn {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	waitAccepted := func() net.Conn {
+		select {
+		case conn := <-accepted:
+			return conn
+		case <-time.After(time.Second):
+			t.Fatal("connection not accepted")
+			return nil
+		}
+	}
+
+	c1, c2 := dial(), dial()
+	defer c1.Close()
+	defer c2.Close()
+	s1, s2 := waitAccepted(), waitAccepted()
+
+	// A third connection from the same IP is closed by the server.
+	c3 := dial()
+	defer c3.Close()
+	c3.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := c3.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("excess connection: read error %v, want EOF", err)
+	}
+
+	// Closing one frees a slot.
+	s1.Close()
+	s1.Close()
+	c4 := dial()
+	defer c4.Close()
+	waitAccepted().Close()
+	s2.Close()
+
+	limited.mu.Lock()
+	defer limited.mu.Unlock()
+	if len(limited.open) != 0 {
+		t.Errorf("open = %v after all connections closed", limited.open)
+	}
+}
+
+func TestWithConnections(t *testing.T) {
+	sl := New().WithConnections(Connections{MaxHeaderBytes: 4 << 10, DisableKeepAlives: true})
+	sl.GET("/", func(c *Context) { c.String(http.StatusOK, "ok") })
+
+	if sl.server.MaxHeaderBytes != 4<<10 {
+		t.Errorf("MaxHeaderBytes = %d", sl.server.MaxHeaderBytes)
+	}
+
+	ln, err := sl.listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go sl.server.Serve(ln)
+	defer sl.server.Close()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if !resp.Close {
+		t.Error("keep-alive not disabled: response lacks Connection: close")
+	}
+}
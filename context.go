@@ -5,37 +5,114 @@
 package sol
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"maps"
+	"math"
 	"net/http"
 	"net/url"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 type HandlerFunc func(*Context)
 
+// maxHandlers caps the length of a route's handler chain, middleware included.
+const maxHandlers = math.MaxInt16
+
 type Context struct {
 	Request *http.Request
 	Writer  http.ResponseWriter
 
+	// rw is the writer behind Writer, tracking whether it was committed
+	rw responseWriter
+	// abortConn makes the server drop the connection after the chain,
+	// set when a panic left a committed response incomplete
+	abortConn bool
+
+	// engine is the Sol instance serving the request
+	engine *Sol
+
 	params map[string]string
+	// route is the matched route, nil when none matched
+	route *Route
+	// pattern is the registered path of the matched route
+	pattern string
+	// templates is the template set of the matched route's group
+	templates *templateSet
+	// errorHandler is the error handler of the matched route's group
+	errorHandler ErrorHandler
+	// raw is set for routes marked with Route.Raw
+	raw bool
+	// queryCache caches the parsed query string
+	queryCache url.Values
 	// data stores custom data for the request
 	data map[string]any
 
-	index    int8
+	index    int
 	handlers []HandlerFunc
 	aborted  bool
+	// clientGone is set when the chain stopped because the client went away
+	clientGone bool
+
+	// locale and translate are set by i18n middleware
+	locale    string
+	translate TranslateFunc
+	// principal is set by auth middleware
+	principal Principal
+
+	// body caches the raw request body read by Body
+	body     []byte
+	bodyRead bool
+	// bodyReported is set once an error report carried the captured body
+	bodyReported bool
+
+	// deferred are the hooks registered with Defer
+	deferred []func()
 
 	// mu protects data map
 	mu sync.RWMutex
 }
 
+// IsRaw reports whether the matched route was marked with Route.Raw, in
+// which case middleware must leave the request and response bodies alone.
+func (c *Context) IsRaw() bool {
+	return c.raw
+}
+
 // Context returns the request's context
 func (c *Context) Context() context.Context {
 	return c.Request.Context()
 }
 
+// WithTimeout bounds the request context with a deadline and swaps it into
+// c.Request, so downstream calls given c.Context() are cut off after d.
+// The returned cancel must be called to release resources.
+func (c *Context) WithTimeout(d time.Duration) (cancel func()) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+	c.Request = c.Request.WithContext(ctx)
+	return cancel
+}
+
+// Deadline returns the deadline of the request context, if any.
+func (c *Context) Deadline() (time.Time, bool) {
+	return c.Request.Context().Deadline()
+}
+
+// Done returns a channel closed when the request context is cancelled
+// or its deadline passes.
+func (c *Context) Done() <-chan struct{} {
+	return c.Request.Context().Done()
+}
+
 // Header returns the value of a request header.
 func (c *Context) Header(key string) string {
 	return c.Request.Header.Get(key)
@@ -106,6 +183,29 @@ func (c *Context) Method() string {
 	return c.Request.Method
 }
 
+// Body reads and caches the raw request body. The request body is replaced
+// with a reader over the cached bytes, so binders and later calls still see it.
+func (c *Context) Body() ([]byte, error) {
+	if c.bodyRead {
+		return c.body, nil
+	}
+	if c.Request.Body == nil {
+		c.bodyRead = true
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(c.Request.Body)
+	c.Request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.body = b
+	c.bodyRead = true
+	c.Request.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
 // Param returns the value of a named route parameter.
 func (c *Context) Param(key string) string {
 	if c.params == nil {
@@ -114,19 +214,118 @@ func (c *Context) Param(key string) string {
 	return c.params[key]
 }
 
-// Params returns the Context params.
+// Params returns the Context params. The map is internal and must not be
+// modified; use ParamsMap for a copy, or ParamCount and ParamByIndex to
+// enumerate the params.
 func (c *Context) Params() map[string]string {
 	return c.params
 }
 
+// ParamCount returns the number of route parameters.
+func (c *Context) ParamCount() int {
+	return len(c.params)
+}
+
+// ParamByIndex returns the i-th route parameter, in the order they appear
+// in the route pattern, or empty strings if i is out of range.
+func (c *Context) ParamByIndex(i int) (key, value string) {
+	keys := c.paramKeys()
+	if i < 0 || i >= len(keys) {
+		return "", ""
+	}
+	return keys[i], c.params[keys[i]]
+}
+
+// ParamsMap returns a copy of the route parameters, which callers may
+// keep or modify.
+func (c *Context) ParamsMap() map[string]string {
+	return maps.Clone(c.params)
+}
+
+// paramKeys returns the param names in pattern order; names missing from
+// the pattern follow, sorted.
+func (c *Context) paramKeys() []string {
+	keys := make([]string, 0, len(c.params))
+	for seg := range strings.SplitSeq(c.pattern, "/") {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			if _, ok := c.params[seg[1:]]; ok {
+				keys = append(keys, seg[1:])
+			}
+		}
+	}
+	if len(keys) < len(c.params) {
+		var rest []string
+		for k := range c.params {
+			if !slices.Contains(keys, k) {
+				rest = append(rest, k)
+			}
+		}
+		slices.Sort(rest)
+		keys = append(keys, rest...)
+	}
+	return keys
+}
+
+// RoutePattern returns the registered pattern of the matched route, e.g.
+// "/users/:id", or "" if no route matched. Unlike Path it has bounded
+// cardinality, so metrics, tracing and logs can be labeled with it.
+func (c *Context) RoutePattern() string {
+	return c.pattern
+}
+
+// initQueryCache parses the raw query once per request.
+func (c *Context) initQueryCache() {
+	if c.queryCache == nil {
+		if c.Request != nil && c.Request.URL != nil {
+			c.queryCache = c.Request.URL.Query()
+		} else {
+			c.queryCache = url.Values{}
+		}
+	}
+}
+
 // QueryParam returns the first value for the named query parameter.
 func (c *Context) QueryParam(key string) string {
-	return c.Request.URL.Query().Get(key)
+	c.initQueryCache()
+	return c.queryCache.Get(key)
+}
+
+// QueryValues returns all values for the named query parameter.
+func (c *Context) QueryValues(key string) []string {
+	c.initQueryCache()
+	return c.queryCache[key]
 }
 
 // QueryAll returns the full parsed query values.
+// The values are cached for the request and must not be modified.
 func (c *Context) QueryAll() url.Values {
-	return c.Request.URL.Query()
+	c.initQueryCache()
+	return c.queryCache
+}
+
+// ContextKey is the context.Context key under which values stored with
+// Set are visible, for libraries that only accept a context.Context:
+//
+//	c.Set("user", u)
+//	c.Request.Context().Value(sol.ContextKey("user")) // u
+type ContextKey string
+
+// dataContext exposes the Context data through context.Context.Value.
+// It keeps the data map of its own request, so a context that outlives
+// the request never sees data of a later one.
+type dataContext struct {
+	context.Context
+	mu   *sync.RWMutex
+	data map[string]any
+}
+
+func (d *dataContext) Value(key any) any {
+	if k, ok := key.(ContextKey); ok {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		return d.data[string(k)]
+	}
+	return d.Context.Value(key)
 }
 
 // Set stores a value in the request context.
@@ -136,6 +335,13 @@ func (c *Context) Set(key string, value any) {
 
 	if c.data == nil {
 		c.data = make(map[string]any)
+		if c.Request != nil {
+			c.Request = c.Request.WithContext(&dataContext{
+				Context: c.Request.Context(),
+				mu:      &c.mu,
+				data:    c.data,
+			})
+		}
 	}
 	c.data[key] = value
 }
@@ -171,6 +377,54 @@ func (c *Context) Delete(key string) {
 	}
 }
 
+// SetWriteDeadline overrides the server write timeout for the current request.
+// A zero time removes the deadline, e.g. for long-lived streaming responses.
+func (c *Context) SetWriteDeadline(t time.Time) error {
+	return http.NewResponseController(c.Writer).SetWriteDeadline(t)
+}
+
+// SetReadDeadline overrides the server read timeout for the current request.
+// A zero time removes the deadline.
+func (c *Context) SetReadDeadline(t time.Time) error {
+	return http.NewResponseController(c.Writer).SetReadDeadline(t)
+}
+
+// Defer registers fn to run once the handler chain has returned and the
+// response has been flushed, before the Context is reused. Hooks run in
+// reverse order of registration, like deferred calls. They suit audit
+// logging, metrics finalization and temp-file cleanup; the response can
+// no longer be changed from them.
+func (c *Context) Defer(fn func()) {
+	c.deferred = append(c.deferred, fn)
+}
+
+// runDeferred flushes the response and runs the Defer hooks.
+func (c *Context) runDeferred() {
+	if len(c.deferred) == 0 {
+		return
+	}
+
+	if err := http.NewResponseController(c.Writer).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("[WARN] flush response: %v", err)
+	}
+
+	for i := len(c.deferred) - 1; i >= 0; i-- {
+		runHook(c.deferred[i])
+	}
+	clear(c.deferred)
+	c.deferred = c.deferred[:0]
+}
+
+// runHook calls fn, logging a panic instead of letting it escape.
+func runHook(fn func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("[PANIC] deferred hook: %v\n%s", err, debug.Stack())
+		}
+	}()
+	fn()
+}
+
 // Next invokes the next handler in the chain.
 func (c *Context) Next() {
 	// If already aborted or request context is done, stop processing
@@ -180,12 +434,15 @@ func (c *Context) Next() {
 
 	c.index++
 
-	for c.index < int8(len(c.handlers)) {
+	for c.index < len(c.handlers) {
 		if c.aborted {
 			return
 		}
 
-		if c.Request.Context().Err() != nil {
+		if err := c.Request.Context().Err(); err != nil {
+			if errors.Is(err, context.Canceled) {
+				c.clientGone = true
+			}
 			return
 		}
 
@@ -194,6 +451,14 @@ func (c *Context) Next() {
 	}
 }
 
+// ClientDisconnected reports whether the handler chain was cut short
+// because the client cancelled the request. Code after c.Next() and
+// trailing middleware still run, so loggers and metrics can record the
+// outcome, e.g. as a 499.
+func (c *Context) ClientDisconnected() bool {
+	return c.clientGone
+}
+
 // Abort stops execution of remaining handlers.
 func (c *Context) Abort() {
 	c.aborted = true
@@ -204,40 +469,92 @@ func (c *Context) IsAborted() bool {
 	return c.aborted
 }
 
+// HandlerNames returns the names of the request's handler chain,
+// middleware first, e.g. "sol.Recover.func1" or "main.getUser".
+func (c *Context) HandlerNames() []string {
+	names := make([]string, len(c.handlers))
+	for i, h := range c.handlers {
+		names[i] = handlerName(h)
+	}
+	return names
+}
+
+// HandlerName returns the name of the handler currently executing, which
+// in a deferred recover is the one that panicked. Before and after the
+// chain runs it is the final handler. It is "" without handlers.
+func (c *Context) HandlerName() string {
+	if len(c.handlers) == 0 {
+		return ""
+	}
+	i := c.index
+	if i < 0 || i >= len(c.handlers) {
+		i = len(c.handlers) - 1
+	}
+	return handlerName(c.handlers[i])
+}
+
 func (c *Context) String(status int, format string, values ...any) {
 	c.Writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	c.Writer.WriteHeader(status)
 	if format == "" {
 		return
 	}
-	msg := fmt.Sprintf(format, values...)
-	c.Writer.Write([]byte(msg))
+	// Plain messages skip formatting and go straight to the writer.
+	if len(values) == 0 && strings.IndexByte(format, '%') < 0 {
+		io.WriteString(c.Writer, format)
+		return
+	}
+	fmt.Fprintf(c.Writer, format, values...)
 }
 
+// JSON encodes obj and writes it with the given status. Encoding happens
+// before anything is written, so a failure still yields a clean 500.
 func (c *Context) JSON(status int, obj any) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := json.NewEncoder(buf).Encode(obj); err != nil {
+		log.Printf("[ERROR] json encode: %v", err)
+		c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteHeader(http.StatusInternalServerError)
+		c.Writer.Write([]byte(`{"error":"json marshal failed"}` + "\n"))
+		return
+	}
+
 	c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 	c.Writer.WriteHeader(status)
+	c.Writer.Write(buf.Bytes())
+}
 
-	encoder := json.NewEncoder(c.Writer)
-	if err := encoder.Encode(obj); err != nil {
-		http.Error(c.Writer, `{"error":"json marshal failed"}`, http.StatusInternalServerError)
-		return
+// JSONStream encodes obj directly to the response without buffering,
+// for large payloads. Headers are committed first, so an encoding error
+// can only be logged and the response is left truncated.
+func (c *Context) JSONStream(status int, obj any) {
+	c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	c.Writer.WriteHeader(status)
+
+	if err := json.NewEncoder(c.Writer).Encode(obj); err != nil {
+		log.Printf("[ERROR] json stream encode: %v", err)
 	}
 }
 
 func (c *Context) HTML(status int, html string) {
 	c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
 	c.Writer.WriteHeader(status)
-	c.Writer.Write([]byte(html))
+	io.WriteString(c.Writer, html)
 }
 
 func (c *Context) XML(status int, data map[string]string) {
-	c.Writer.Header().Set("Content-Type", "text/xml; charset=utf-8")
-	c.Writer.WriteHeader(status)
+	buf := getBuffer()
+	defer putBuffer(buf)
 
-	c.Writer.Write([]byte("<xml>"))
+	buf.WriteString("<xml>")
 	for k, v := range data {
-		fmt.Fprintf(c.Writer, "<%s><![CDATA[%s]]></%s>", k, v, k)
+		fmt.Fprintf(buf, "<%s><![CDATA[%s]]></%s>", k, v, k)
 	}
-	c.Writer.Write([]byte("</xml>"))
+	buf.WriteString("</xml>")
+
+	c.Writer.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	c.Writer.WriteHeader(status)
+	c.Writer.Write(buf.Bytes())
 }
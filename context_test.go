@@ -0,0 +1,271 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestContext_JSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    any
+		status int
+		body   string
+	}{
+		{"ok", map[string]string{"name": "Perry"}, http.StatusCreated, `{"name":"Perry"}` + "\n"},
+		{"encode error", map[string]any{"ch": make(chan int)}, http.StatusInternalServerError, `{"error":"json marshal failed"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := &Context{Writer: rec, Request: httptest.NewRequest(http.MethodGet, "/", nil)}
+			c.JSON(http.StatusCreated, tt.obj)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+		})
+	}
+}
+
+func TestPutBufferDropsLargeBuffers(t *testing.T) {
+	buf := getBuffer()
+	buf.Grow(maxPooledBuffer + 1)
+	putBuffer(buf)
+
+	// A dropped buffer must not come back; a fresh one starts small.
+	for range 10 {
+		if b := getBuffer(); b.Cap() > maxPooledBuffer {
+			t.Fatalf("pool returned buffer with cap %d", b.Cap())
+		}
+	}
+}
+
+func TestContext_SetVisibleThroughContext(t *testing.T) {
+	type otherKey struct{}
+
+	sl := New()
+	sl.Use(func(c *Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), otherKey{}, "other"))
+	})
+
+	var kept context.Context
+	sl.GET("/", func(c *Context) {
+		c.Set("user", c.QueryParam("user"))
+		kept = c.Request.Context()
+
+		if got := kept.Value(ContextKey("user")); got != c.QueryParam("user") {
+			t.Errorf("Value(user) = %v", got)
+		}
+		if got := kept.Value(otherKey{}); got != "other" {
+			t.Errorf("Value(otherKey) = %v, want other", got)
+		}
+		if got := kept.Value(ContextKey("missing")); got != nil {
+			t.Errorf("Value(missing) = %v, want nil", got)
+		}
+	})
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?user=perry", nil))
+	first := kept
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?user=ferb", nil))
+
+	if got := first.Value(ContextKey("user")); got != "perry" {
+		t.Errorf("retained context sees %v, want perry", got)
+	}
+}
+
+func TestContext_WithTimeout(t *testing.T) {
+	c := &Context{Request: httptest.NewRequest(http.MethodGet, "/", nil)}
+
+	if _, ok := c.Deadline(); ok {
+		t.Fatal("unexpected deadline before WithTimeout")
+	}
+
+	cancel := c.WithTimeout(time.Minute)
+	if dl, ok := c.Deadline(); !ok || time.Until(dl) > time.Minute {
+		t.Errorf("Deadline() = %v, %v", dl, ok)
+	}
+
+	select {
+	case <-c.Done():
+		t.Fatal("done before cancel")
+	default:
+	}
+
+	cancel()
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after cancel")
+	}
+	if c.Context().Err() != context.Canceled {
+		t.Errorf("Err() = %v, want context.Canceled", c.Context().Err())
+	}
+}
+
+func TestContext_Defer(t *testing.T) {
+	sl := New()
+
+	var order []string
+	sl.GET("/", func(c *Context) {
+		c.Defer(func() { order = append(order, "first") })
+		c.Defer(func() { panic("boom") })
+		c.Defer(func() { order = append(order, "last") })
+		c.String(http.StatusOK, "ok")
+		order = append(order, "handler")
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if want := []string{"handler", "last", "first"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if !rec.Flushed {
+		t.Error("response not flushed before hooks ran")
+	}
+	if rec.Body.String() != "ok" {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
+
+func TestContext_RoutePattern(t *testing.T) {
+	sl := New()
+	var got string
+	record := func(c *Context) { got = c.RoutePattern() }
+
+	sl.GET("/users/:id", record)
+	sl.GET("/about", record)
+	sl.Group("/api").GET("/files/*path", record)
+	sl.NotFound(record)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/users/42", "/users/:id"},
+		{"/about/", "/about"},
+		{"/api/files/a/b.txt", "/api/files/*path"},
+		{"/missing", ""},
+	}
+	for _, tt := range tests {
+		got = "unset"
+		sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if got != tt.want {
+			t.Errorf("%s: RoutePattern = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestContext_ParamByIndex(t *testing.T) {
+	sl := New()
+	var keys, values []string
+	var copied map[string]string
+	sl.GET("/orgs/:org/repos/:repo/files/*path", func(c *Context) {
+		for i := range c.ParamCount() {
+			k, v := c.ParamByIndex(i)
+			keys, values = append(keys, k), append(values, v)
+		}
+		copied = c.ParamsMap()
+		copied["org"] = "changed"
+		if c.Param("org") != "sol" {
+			t.Error("modifying ParamsMap changed the params")
+		}
+		if k, v := c.ParamByIndex(3); k != "" || v != "" {
+			t.Errorf("ParamByIndex(3) = %q, %q", k, v)
+		}
+	})
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orgs/sol/repos/core/files/a/b.go", nil))
+	if !slices.Equal(keys, []string{"org", "repo", "path"}) || !slices.Equal(values, []string{"sol", "core", "a/b.go"}) {
+		t.Errorf("params = %v %v", keys, values)
+	}
+}
+
+func panickingHandler(c *Context) { panic("boom") }
+
+func TestContext_HandlerName(t *testing.T) {
+	var names []string
+	var current string
+	var pe *PanicError
+	sl := New().WithErrorHandler(func(c *Context, err error) {
+		errors.As(err, &pe)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+	sl.GET("/names", func(c *Context) {
+		names = c.HandlerNames()
+		current = c.HandlerName()
+	})
+	sl.GET("/panic", panickingHandler)
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/names", nil))
+	if len(names) != 2 || names[0] != "sol.Recover.func1" || names[1] != "sol.TestContext_HandlerName.func2" {
+		t.Errorf("HandlerNames = %v", names)
+	}
+	if current != names[1] {
+		t.Errorf("HandlerName = %q, want %q", current, names[1])
+	}
+
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if pe == nil || pe.Handler != "sol.panickingHandler" {
+		t.Errorf("PanicError = %+v", pe)
+	}
+}
+
+func TestContext_ClientDisconnected(t *testing.T) {
+	sl := New()
+
+	var ran []string
+	var gone bool
+	var disconnect context.CancelFunc
+	sl.Use(func(c *Context) {
+		if disconnect != nil {
+			disconnect()
+		}
+		c.Next()
+		ran = append(ran, "after-next")
+	})
+	sl.UseAfter(func(c *Context) { gone = c.ClientDisconnected() })
+	sl.GET("/slow", func(c *Context) {
+		ran = append(ran, "handler")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	disconnect = cancel
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil).WithContext(ctx)
+	sl.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !slices.Equal(ran, []string{"after-next"}) {
+		t.Errorf("ran = %v, want [after-next]", ran)
+	}
+	if !gone {
+		t.Error("ClientDisconnected() = false for a cancelled request")
+	}
+
+	ran, disconnect = nil, nil
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if gone || !slices.Equal(ran, []string{"handler", "after-next"}) {
+		t.Errorf("live request: gone = %v, ran = %v", gone, ran)
+	}
+}
@@ -0,0 +1,123 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import "strings"
+
+// deviceKey is the Context key holding the classified device.
+const deviceKey = "sol.device"
+
+// Device classes.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+)
+
+// Device describes the client as told by its User-Agent. Browser and OS
+// are "" when not recognized.
+type Device struct {
+	Class   string
+	Browser string
+	OS      string
+}
+
+// IsBot reports whether the client is a crawler or script.
+func (d Device) IsBot() bool { return d.Class == DeviceBot }
+
+// IsMobile reports whether the client is a phone or tablet.
+func (d Device) IsMobile() bool { return d.Class == DeviceMobile || d.Class == DeviceTablet }
+
+// DeviceParser classifies a User-Agent header.
+type DeviceParser func(userAgent string) Device
+
+// DeviceDetection classifies every request's User-Agent with parser, or
+// ParseUserAgent if nil, for Context.Device. Plug in a full parser such
+// as a uap-core port when the built-in heuristics are too coarse.
+func DeviceDetection(parser DeviceParser) HandlerFunc {
+	if parser == nil {
+		parser = ParseUserAgent
+	}
+	return func(c *Context) {
+		c.Set(deviceKey, parser(c.Request.UserAgent()))
+		c.Next()
+	}
+}
+
+// Device returns the client device found by DeviceDetection. Without the
+// middleware, the User-Agent is classified with ParseUserAgent.
+func (c *Context) Device() Device {
+	if v, ok := c.Get(deviceKey); ok {
+		return v.(Device)
+	}
+	d := ParseUserAgent(c.Request.UserAgent())
+	c.Set(deviceKey, d)
+	return d
+}
+
+var botMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "curl/", "wget/", "python-", "go-http-client",
+	"java/", "okhttp", "headlesschrome", "lighthouse", "facebookexternalhit", "preview",
+}
+
+// ParseUserAgent classifies a User-Agent with simple substring rules.
+// Empty User-Agents are taken for bots.
+func ParseUserAgent(ua string) Device {
+	l := strings.ToLower(ua)
+
+	var d Device
+	switch {
+	case l == "" || containsAny(l, botMarkers...):
+		d.Class = DeviceBot
+	case containsAny(l, "ipad", "tablet", "kindle", "silk/", "playbook") ||
+		strings.Contains(l, "android") && !strings.Contains(l, "mobile"):
+		d.Class = DeviceTablet
+	case containsAny(l, "mobi", "iphone", "ipod", "android", "windows phone"):
+		d.Class = DeviceMobile
+	default:
+		d.Class = DeviceDesktop
+	}
+
+	switch {
+	case strings.Contains(l, "edg/") || strings.Contains(l, "edga/") || strings.Contains(l, "edgios/"):
+		d.Browser = "Edge"
+	case strings.Contains(l, "opr/") || strings.Contains(l, "opera"):
+		d.Browser = "Opera"
+	case strings.Contains(l, "samsungbrowser/"):
+		d.Browser = "Samsung Internet"
+	case strings.Contains(l, "firefox/") || strings.Contains(l, "fxios/"):
+		d.Browser = "Firefox"
+	case strings.Contains(l, "chrome/") || strings.Contains(l, "crios/"):
+		d.Browser = "Chrome"
+	case strings.Contains(l, "safari/"):
+		d.Browser = "Safari"
+	}
+
+	switch {
+	case strings.Contains(l, "windows"):
+		d.OS = "Windows"
+	case containsAny(l, "iphone", "ipad", "ipod"):
+		d.OS = "iOS"
+	case strings.Contains(l, "android"):
+		d.OS = "Android"
+	case strings.Contains(l, "cros"):
+		d.OS = "ChromeOS"
+	case strings.Contains(l, "mac os x") || strings.Contains(l, "macintosh"):
+		d.OS = "macOS"
+	case strings.Contains(l, "linux"):
+		d.OS = "Linux"
+	}
+	return d
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
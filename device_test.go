@@ -0,0 +1,53 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want Device
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", Device{DeviceDesktop, "Chrome", "Windows"}},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0", Device{DeviceDesktop, "Edge", "Windows"}},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15", Device{DeviceDesktop, "Safari", "macOS"}},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0", Device{DeviceDesktop, "Firefox", "Linux"}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1", Device{DeviceMobile, "Safari", "iOS"}},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36", Device{DeviceMobile, "Chrome", "Android"}},
+		{"Mozilla/5.0 (Linux; Android 13; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36", Device{DeviceTablet, "Chrome", "Android"}},
+		{"Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/126.0 Mobile/15E148 Safari/604.1", Device{DeviceTablet, "Chrome", "iOS"}},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", Device{Class: DeviceBot}},
+		{"curl/8.7.1", Device{Class: DeviceBot}},
+		{"", Device{Class: DeviceBot}},
+	}
+	for _, tt := range tests {
+		if got := ParseUserAgent(tt.ua); got != tt.want {
+			t.Errorf("ParseUserAgent(%q) = %+v, want %+v", tt.ua, got, tt.want)
+		}
+	}
+}
+
+func TestDeviceDetection(t *testing.T) {
+	sl := New()
+	sl.Use(DeviceDetection(func(ua string) Device {
+		return Device{Class: DeviceBot, Browser: ua}
+	}))
+	sl.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "%s %s %v", c.Device().Class, c.Device().Browser, c.Device().IsBot())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "probe")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Body.String() != "bot probe true" {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
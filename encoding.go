@@ -0,0 +1,79 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AcceptsEncoding reports whether the client accepts a response in the
+// content coding enc, e.g. "gzip" or "br". Handlers holding pre-compressed
+// bodies use it to send them as is, setting Content-Encoding themselves;
+// Compress then leaves them alone.
+func (c *Context) AcceptsEncoding(enc string) bool {
+	return acceptsEncoding(c.Header("Accept-Encoding"), enc)
+}
+
+// NegotiateEncoding returns the offered content coding the client prefers
+// by Accept-Encoding weight, ties going to the earlier offer. It returns
+// "" when none is acceptable, in which case the response should be sent
+// uncompressed.
+func (c *Context) NegotiateEncoding(offers ...string) string {
+	return negotiateEncoding(c.Header("Accept-Encoding"), offers)
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows coding.
+func acceptsEncoding(header, coding string) bool {
+	return encodingWeight(header, coding) > 0
+}
+
+func negotiateEncoding(header string, offers []string) string {
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := encodingWeight(header, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// encodingWeight returns the weight an Accept-Encoding header gives to
+// coding. An explicit entry wins over "*". Identity is acceptable unless
+// refused, every other coding must be listed.
+func encodingWeight(header, coding string) float64 {
+	wildcard := -1.0
+	for part := range strings.SplitSeq(header, ",") {
+		token, params, _ := strings.Cut(part, ";")
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+
+		if strings.EqualFold(token, coding) {
+			return q
+		}
+		if token == "*" {
+			wildcard = q
+		}
+	}
+
+	if wildcard >= 0 {
+		return wildcard
+	}
+	if strings.EqualFold(coding, "identity") {
+		return 1
+	}
+	return 0
+}
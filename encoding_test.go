@@ -0,0 +1,43 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContext_NegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		offers []string
+		want   string
+	}{
+		{"", []string{"gzip", "br"}, ""},
+		{"", []string{"gzip", "identity"}, "identity"},
+		{"gzip", []string{"br", "gzip"}, "gzip"},
+		{"gzip, br", []string{"br", "gzip"}, "br"},
+		{"gzip, br", []string{"gzip", "br"}, "gzip"},
+		{"gzip;q=0.8, br;q=0.9", []string{"gzip", "br"}, "br"},
+		{"GZIP", []string{"gzip"}, "gzip"},
+		{"br;q=0", []string{"br"}, ""},
+		{"*", []string{"zstd"}, "zstd"},
+		{"*;q=0, gzip", []string{"br", "gzip"}, "gzip"},
+		{"identity;q=0, gzip", []string{"identity"}, ""},
+		{"gzip;q=bogus", []string{"gzip"}, ""},
+	}
+
+	for _, tt := range tests {
+		c := &Context{Request: httptest.NewRequest(http.MethodGet, "/", nil)}
+		c.Request.Header.Set("Accept-Encoding", tt.header)
+		if got := c.NegotiateEncoding(tt.offers...); got != tt.want {
+			t.Errorf("%q %v: NegotiateEncoding = %q, want %q", tt.header, tt.offers, got, tt.want)
+		}
+		if tt.want != "" && !c.AcceptsEncoding(tt.want) {
+			t.Errorf("%q: AcceptsEncoding(%q) = false", tt.header, tt.want)
+		}
+	}
+}
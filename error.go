@@ -0,0 +1,126 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/wantnotshould/sol/validator"
+)
+
+// ErrorHandler writes the response for an error reported with Context.Error.
+type ErrorHandler func(c *Context, err error)
+
+// StatusCoder is implemented by errors that carry an HTTP status.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// PanicError is the error Recover reports for a recovered panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+	// Handler is the name of the handler that panicked.
+	Handler string
+	// RequestID is the ID of the request that panicked.
+	RequestID string
+	// Body is the redacted start of the request body, see
+	// Sol.WithBodyCapture.
+	Body string
+
+	// pcs are the program counters of the panicking goroutine
+	pcs []uintptr
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// StatusCode implements StatusCoder.
+func (e *PanicError) StatusCode() int {
+	return http.StatusInternalServerError
+}
+
+// WithErrorHandler replaces the handler for errors reported with
+// Context.Error, recovered panics included, so every error shares one
+// response format.
+func (sl *Sol) WithErrorHandler(h ErrorHandler) *Sol {
+	sl.errorHandler = h
+	return sl
+}
+
+// Error reports err to the engine error handler and aborts the chain.
+// Server errors also go to the ErrorReporter. The default handler answers
+// with the status of a StatusCoder, or 500, using the negotiated body of
+// AbortWithStatus. JSON clients get validation errors in their JSON form
+// instead. Once the response is written no handler runs, as a second
+// body would only corrupt the first; the error is logged instead.
+func (c *Context) Error(err error) {
+	c.Abort()
+	c.reportError(err, errorStatus(err))
+	if c.Written() {
+		log.Printf("[WARN] %s %s: error after the response was written: %v", c.Method(), c.Path(), err)
+		return
+	}
+	if c.errorHandler != nil {
+		c.errorHandler(c, err)
+		return
+	}
+	if c.engine != nil && c.engine.errorHandler != nil {
+		c.engine.errorHandler(c, err)
+		return
+	}
+	defaultErrorHandler(c, err)
+}
+
+func defaultErrorHandler(c *Context, err error) {
+	if c.Written() {
+		return
+	}
+
+	var perr *PanicError
+	if c.engine != nil && c.engine.opts.Mode == ModeDebug && errors.As(err, &perr) &&
+		negotiateError(c.Header("Accept")) == "html" {
+		renderPanicPage(c, perr)
+		return
+	}
+
+	// Clients asking for problem details get them, as do errors that are
+	// problems already.
+	var p *ProblemDetails
+	if strings.Contains(c.Header("Accept"), ProblemContentType) || errors.As(err, &p) {
+		ProblemErrorHandler(c, err)
+		return
+	}
+
+	status := errorStatus(err)
+
+	// JSON clients get the failing fields of validation errors.
+	if negotiateError(c.Header("Accept")) == "json" {
+		var ferrs validator.FieldErrors
+		var verrs validator.ValidationErrors
+		switch {
+		case errors.As(err, &ferrs):
+			c.JSON(status, ferrs)
+			return
+		case errors.As(err, &verrs):
+			c.JSON(status, verrs)
+			return
+		}
+	}
+	c.AbortWithStatus(status)
+}
@@ -0,0 +1,251 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+type statusErr int
+
+func (e statusErr) Error() string   { return http.StatusText(int(e)) }
+func (e statusErr) StatusCode() int { return int(e) }
+
+func TestContext_Error(t *testing.T) {
+	var got error
+	sl := New().WithErrorHandler(func(c *Context, err error) {
+		got = err
+		defaultErrorHandler(c, err)
+	})
+	sl.GET("/teapot", func(c *Context) { c.Error(statusErr(http.StatusTeapot)) })
+	sl.GET("/panic", func(c *Context) { panic(io.ErrUnexpectedEOF) })
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/teapot", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+
+	var pe *PanicError
+	if !errors.As(got, &pe) || len(pe.Stack) == 0 {
+		t.Fatalf("error handler got %v, want *PanicError with stack", got)
+	}
+	if !errors.Is(got, io.ErrUnexpectedEOF) {
+		t.Errorf("PanicError does not unwrap to the panic value")
+	}
+}
+
+func TestContext_ErrorAfterWrite(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	var handled bool
+	sl := New().WithErrorHandler(func(c *Context, err error) {
+		handled = true
+		defaultErrorHandler(c, err)
+	})
+	sl.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "partial")
+		c.Error(statusErr(http.StatusTeapot))
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "partial" || handled {
+		t.Errorf("status = %d, body = %q, handled = %v", rec.Code, rec.Body.String(), handled)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	sl := New()
+	sl.GET("/download", func(c *Context) {
+		c.SetHeader("Content-Disposition", `attachment; filename="report.csv"`)
+		c.SetHeader("Content-Type", "text/csv")
+		panic("report failed")
+	})
+	sl.GET("/stream", func(c *Context) {
+		c.SetHeader("Content-Type", "text/plain")
+		c.Writer.WriteHeader(http.StatusOK)
+		io.WriteString(c.Writer, "partial")
+		c.Writer.(http.Flusher).Flush()
+		panic("stream failed")
+	})
+
+	// Before anything was sent, the panic renders as a negotiated error.
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("Content-Disposition") != "" {
+		t.Errorf("uncommitted panic: %d %v", rec.Code, rec.Header())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("uncommitted panic: Content-Type %q", ct)
+	}
+
+	// Mid stream, the connection is dropped instead.
+	srv := httptest.NewServer(sl)
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		t.Errorf("committed panic: body %q read without error, want truncated response", body)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != "partial" {
+		t.Errorf("committed panic: %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestRecover_debugPage(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, mode := range []string{ModeDebug, ModeRelease} {
+		sl := New().WithOptions(Options{Mode: mode})
+		sl.GET("/boom", func(c *Context) {
+			var m map[string]int
+			m["boom"]++ // the panic line
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+		req.Header.Set("Accept", "text/html")
+		req.Header.Set(RequestIDHeader, "req-42")
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+
+		body := rec.Body.String()
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("%s: status %d", mode, rec.Code)
+		}
+		detailed := strings.Contains(body, "the panic line") && strings.Contains(body, "req-42") &&
+			strings.Contains(body, "framework frames")
+		if detailed != (mode == ModeDebug) {
+			t.Errorf("%s: detailed page = %v:\n%s", mode, detailed, body)
+		}
+	}
+}
+
+func TestPanicError_Frames(t *testing.T) {
+	var perr *PanicError
+	sl := New().WithErrorHandler(func(c *Context, err error) {
+		errors.As(err, &perr)
+		c.Status(http.StatusInternalServerError)
+	})
+	sl.GET("/boom", func(c *Context) { panic("boom") })
+
+	log.SetOutput(io.Discard)
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+	log.SetOutput(os.Stderr)
+
+	if perr == nil {
+		t.Fatal("error handler did not get a *PanicError")
+	}
+	frames := perr.Frames()
+	if len(frames) == 0 || frames[0].Framework || !strings.Contains(frames[0].Function, "TestPanicError_Frames") {
+		t.Fatalf("first frame = %+v, want the panicking handler", frames)
+	}
+	if perr.RequestID == "" {
+		t.Error("RequestID not set")
+	}
+}
+
+func TestWithBodyCapture(t *testing.T) {
+	var buf strings.Builder
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	sl := New().WithBodyCapture(60, nil)
+	sl.POST("/fail", func(c *Context) {
+		c.Body()
+		c.Status(http.StatusBadGateway)
+	})
+	sl.POST("/panic", func(c *Context) {
+		c.Body()
+		panic("boom")
+	})
+	sl.POST("/unread", func(c *Context) { c.Status(http.StatusInternalServerError) })
+
+	post := func(path, body string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		sl.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	post("/fail", `{"user":"ann","password":"hunter2","remark":"`+strings.Repeat("x", 100)+`"}`)
+	out := buf.String()
+	if !strings.Contains(out, `answered 502`) || !strings.Contains(out, `"password":"[REDACTED]"`) || !strings.Contains(out, "...(truncated)") {
+		t.Errorf("5xx report:\n%s", out)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("5xx report leaks the password:\n%s", out)
+	}
+
+	buf.Reset()
+	post("/panic", `{"token":"t0k"}`)
+	out = buf.String()
+	if strings.Count(out, "body: ") != 1 || !strings.Contains(out, `{"token":"[REDACTED]"}`) {
+		t.Errorf("panic report, want the body once:\n%s", out)
+	}
+
+	buf.Reset()
+	post("/unread", `{"a":1}`)
+	if strings.Contains(buf.String(), "body: ") {
+		t.Errorf("unread body captured:\n%s", buf.String())
+	}
+}
+
+func TestWithErrorReporter(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	var reports []*ErrorReport
+	sl := New().WithErrorReporter(ErrorReporterFunc(func(_ context.Context, r *ErrorReport) {
+		reports = append(reports, r)
+	}))
+	sl.GET("/users/:id", func(c *Context) { c.Error(errors.New("db down")) })
+	sl.GET("/missing", func(c *Context) { c.Error(statusErr(http.StatusNotFound)) })
+	sl.GET("/panic", func(c *Context) { panic("boom") })
+
+	for _, path := range []string{"/users/7", "/missing", "/panic"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set(RequestIDHeader, "req"+strings.ReplaceAll(path, "/", "-"))
+		sl.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2 (client errors are not reported)", len(reports))
+	}
+	r := reports[0]
+	if r.Status != 500 || r.Err.Error() != "db down" || r.Route != "/users/:id" || r.Path != "/users/7" ||
+		r.RequestID != "req-users-7" || r.Header.Get("Authorization") != Redacted || r.Stack != nil {
+		t.Errorf("error report = %+v", r)
+	}
+	r = reports[1]
+	if r.RequestID != "req-panic" || len(r.Stack) == 0 || len(r.Frames) == 0 {
+		t.Errorf("panic report = %+v", r)
+	}
+}
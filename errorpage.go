@@ -0,0 +1,112 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"html/template"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrorPageData is passed to the error page template.
+type ErrorPageData struct {
+	Status  int
+	Message string
+}
+
+var defaultErrorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Message}}</title></head>
+<body style="font-family:sans-serif;text-align:center;padding-top:10%">
+<h1>{{.Status}}</h1>
+<p>{{.Message}}</p>
+</body>
+</html>
+`))
+
+// WithErrorPage replaces the HTML template of default error responses
+// sent to browsers. It is executed with ErrorPageData.
+func (sl *Sol) WithErrorPage(tmpl *template.Template) *Sol {
+	sl.errorPage = tmpl
+	return sl
+}
+
+// AbortWithStatus writes the default body for status and aborts the chain.
+// The body is negotiated from the Accept header: JSON for API clients,
+// an HTML page for browsers and plain text otherwise.
+func (c *Context) AbortWithStatus(status int) {
+	c.Abort()
+
+	msg := http.StatusText(status)
+	switch negotiateError(c.Header("Accept")) {
+	case "json":
+		c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteHeader(status)
+		io.WriteString(c.Writer, `{"status":`+strconv.Itoa(status)+`,"error":`+strconv.Quote(msg)+"}\n")
+	case "html":
+		tmpl := defaultErrorPage
+		if c.engine != nil && c.engine.errorPage != nil {
+			tmpl = c.engine.errorPage
+		}
+
+		buf := getBuffer()
+		defer putBuffer(buf)
+		if err := tmpl.Execute(buf, ErrorPageData{Status: status, Message: msg}); err != nil {
+			log.Printf("[ERROR] error page: %v", err)
+			buf.Reset()
+			buf.WriteString(msg)
+		}
+
+		c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		c.Writer.WriteHeader(status)
+		c.Writer.Write(buf.Bytes())
+	default:
+		// Same bodies as net/http's NotFound and Error.
+		if status == http.StatusNotFound {
+			msg = "404 page not found"
+		}
+		c.Writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		c.Writer.Header().Set("X-Content-Type-Options", "nosniff")
+		c.Writer.WriteHeader(status)
+		io.WriteString(c.Writer, msg+"\n")
+	}
+}
+
+// negotiateError picks "json", "html" or "text" from an Accept header,
+// preferring the highest quality and, on ties, the first listed type.
+func negotiateError(accept string) string {
+	best, bestQ := "text", 0.0
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		var kind string
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			kind = "json"
+		case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+			kind = "html"
+		default:
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = kind, q
+		}
+	}
+	return best
+}
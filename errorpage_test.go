@@ -0,0 +1,83 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorPage_negotiation(t *testing.T) {
+	sl := New()
+	sl.GET("/users", func(c *Context) {})
+	sl.POST("/users", func(c *Context) {})
+	sl.GET("/panic", func(c *Context) { panic("boom") })
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"text 404", http.MethodGet, "/missing", "", http.StatusNotFound, "text/plain; charset=utf-8", "404 page not found\n"},
+		{"json 404", http.MethodGet, "/missing", "application/json", http.StatusNotFound, "application/json; charset=utf-8", `{"status":404,"error":"Not Found"}` + "\n"},
+		{"html 404", http.MethodGet, "/missing", "text/html,application/xhtml+xml,*/*;q=0.8", http.StatusNotFound, "text/html; charset=utf-8", "<h1>404</h1>"},
+		{"q prefers json", http.MethodGet, "/missing", "text/html;q=0.5, application/problem+json", http.StatusNotFound, "application/json; charset=utf-8", `"error":"Not Found"`},
+		{"405", http.MethodDelete, "/users", "application/json", http.StatusMethodNotAllowed, "application/json; charset=utf-8", `{"status":405,"error":"Method Not Allowed"}` + "\n"},
+		{"500", http.MethodGet, "/panic", "", http.StatusInternalServerError, "text/plain; charset=utf-8", "Internal Server Error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.contentType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("body = %q, want it to contain %q", rec.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestErrorPage_allowHeader(t *testing.T) {
+	sl := New()
+	sl.GET("/users/:id", func(c *Context) {})
+	sl.PUT("/users/:id", func(c *Context) {})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/1", nil))
+	if got := rec.Header().Get("Allow"); got != "GET, PUT" {
+		t.Errorf("Allow = %q, want %q", got, "GET, PUT")
+	}
+}
+
+func TestErrorPage_custom(t *testing.T) {
+	sl := New().WithErrorPage(template.Must(template.New("e").Parse(`oops {{.Status}}`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Body.String() != "oops 404" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "oops 404")
+	}
+}
@@ -0,0 +1,140 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// EventHandler handles a published event payload.
+type EventHandler func(ctx context.Context, payload any) error
+
+type subscriber struct {
+	handler EventHandler
+	async   bool
+}
+
+// EventBus is an in-process publish/subscribe bus. Subscribers are usually
+// registered at startup; async subscribers run as background tasks and are
+// waited for at shutdown.
+type EventBus struct {
+	engine *Sol
+
+	mu     sync.RWMutex
+	topics map[string][]subscriber
+}
+
+// Events returns the engine event bus.
+func (sl *Sol) Events() *EventBus {
+	sl.eventsOnce.Do(func() {
+		sl.events = &EventBus{
+			engine: sl,
+			topics: make(map[string][]subscriber),
+		}
+	})
+	return sl.events
+}
+
+// Events returns the event bus of the engine serving the request. A
+// Context built outside an engine, e.g. in tests, gets an empty bus of
+// its own whose async subscribers run inside Publish.
+func (c *Context) Events() *EventBus {
+	if c.engine == nil {
+		return &EventBus{topics: make(map[string][]subscriber)}
+	}
+	return c.engine.Events()
+}
+
+// Subscribe registers h to run synchronously inside Publish.
+func (b *EventBus) Subscribe(topic string, h EventHandler) {
+	b.subscribe(topic, subscriber{handler: h})
+}
+
+// SubscribeAsync registers h to run in the background. It uses the worker
+// pool when one is configured (see WithWorkers) and a new goroutine
+// otherwise. Events arriving while the pool queue is full are dropped with
+// a warning rather than piling up goroutines.
+func (b *EventBus) SubscribeAsync(topic string, h EventHandler) {
+	b.subscribe(topic, subscriber{handler: h, async: true})
+}
+
+func (b *EventBus) subscribe(topic string, s subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topics[topic] = append(b.topics[topic], s)
+}
+
+// Publish delivers payload to the subscribers of topic.
+func (b *EventBus) Publish(topic string, payload any) error {
+	return b.PublishContext(context.Background(), topic, payload)
+}
+
+// PublishContext delivers payload to the subscribers of topic. Synchronous
+// subscribers receive ctx and their errors are joined into the result;
+// asynchronous ones receive the background task context and their errors
+// are logged.
+func (b *EventBus) PublishContext(ctx context.Context, topic string, payload any) error {
+	b.mu.RLock()
+	subs := b.topics[topic]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subs {
+		if !s.async {
+			if err := s.handler(ctx, payload); err != nil {
+				errs = append(errs, fmt.Errorf("event %s: %w", topic, err))
+			}
+			continue
+		}
+
+		h := s.handler
+		task := func(ctx context.Context) {
+			if err := h(ctx, payload); err != nil {
+				log.Printf("[ERROR] event %s: %v", topic, err)
+			}
+		}
+		b.dispatch(topic, task)
+	}
+	return errors.Join(errs...)
+}
+
+// dispatch runs an async subscriber on the worker pool, or in its own
+// goroutine without one.
+func (b *EventBus) dispatch(topic string, task func(ctx context.Context)) {
+	switch {
+	case b.engine == nil:
+		task(context.Background())
+	case !b.engine.tasks.pooled():
+		b.engine.Go(task)
+	case !b.engine.Submit(task):
+		log.Printf("[WARN] event %s dropped: worker queue full or shutting down", topic)
+	}
+}
+
+// On subscribes a typed handler to topic. Payloads of another type are
+// reported as errors instead of reaching fn.
+func On[T any](b *EventBus, topic string, fn func(ctx context.Context, payload T) error) {
+	b.Subscribe(topic, typedHandler(topic, fn))
+}
+
+// OnAsync is the asynchronous variant of On.
+func OnAsync[T any](b *EventBus, topic string, fn func(ctx context.Context, payload T) error) {
+	b.SubscribeAsync(topic, typedHandler(topic, fn))
+}
+
+func typedHandler[T any](topic string, fn func(ctx context.Context, payload T) error) EventHandler {
+	return func(ctx context.Context, payload any) error {
+		v, ok := payload.(T)
+		if !ok {
+			var zero T
+			return fmt.Errorf("payload type %T does not match subscriber type %T on %s", payload, zero, topic)
+		}
+		return fn(ctx, v)
+	}
+}
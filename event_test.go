@@ -0,0 +1,118 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type userCreated struct {
+	Name string
+}
+
+func TestEventBus(t *testing.T) {
+	sl := New()
+
+	var (
+		mu   sync.Mutex
+		sent []string
+		wg   sync.WaitGroup
+	)
+	On(sl.Events(), "user.created", func(ctx context.Context, u userCreated) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, "sync:"+u.Name)
+		return nil
+	})
+	wg.Add(1)
+	OnAsync(sl.Events(), "user.created", func(ctx context.Context, u userCreated) error {
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, "async:"+u.Name)
+		return nil
+	})
+
+	sl.POST("/users", func(c *Context) {
+		if err := c.Events().Publish("user.created", userCreated{Name: "Perry"}); err != nil {
+			t.Errorf("Publish: %v", err)
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", nil))
+	wg.Wait()
+
+	if len(sent) != 2 || sent[0] != "sync:Perry" || sent[1] != "async:Perry" {
+		t.Errorf("sent = %v", sent)
+	}
+
+	if err := sl.Events().Publish("user.created", "wrong type"); err == nil {
+		t.Error("expected type mismatch error from sync subscriber")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	sl.tasks.shutdown(ctx)
+}
+
+func TestEventBus_fullQueueDrops(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	sl := New().WithWorkers(1, 1)
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{}, 1)
+		release = make(chan struct{})
+	)
+	sl.Events().SubscribeAsync("tick", func(ctx context.Context, payload any) error {
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	sl.Events().Publish("tick", 1)
+	<-started
+	sl.Events().Publish("tick", 2) // queued
+	sl.Events().Publish("tick", 3) // dropped
+	close(release)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	sl.tasks.shutdown(ctx)
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("calls = %d, want 2 with the third event dropped", n)
+	}
+}
+
+func TestContext_EventsWithoutEngine(t *testing.T) {
+	c := &Context{}
+	bus := c.Events()
+
+	called := false
+	bus.SubscribeAsync("tick", func(ctx context.Context, payload any) error {
+		called = true
+		return nil
+	})
+	if err := bus.Publish("tick", nil); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("expected the async subscriber to run inside Publish")
+	}
+}
@@ -0,0 +1,138 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// experimentsKey is the Context key holding the request's assignments.
+const experimentsKey = "sol.experiments"
+
+// ExperimentCookie persists experiment assignments across requests.
+var ExperimentCookie = "sol_exp"
+
+// Experiment is an A/B test with its variants, e.g. "control" and
+// "treatment". Weights, if set, give the relative share of each variant;
+// variants are equally likely otherwise.
+type Experiment struct {
+	Name     string
+	Variants []string
+	Weights  []int
+}
+
+// Experiments assigns every request a variant of each experiment, exposed
+// with Context.Experiment. Authenticated users are bucketed by a hash of
+// their subject, so they see the same variant on every device, even one
+// whose cookie holds an assignment from before they signed in. Others are
+// bucketed at random and keep their variant in ExperimentCookie, so it
+// stays put when weights change later.
+func Experiments(exps ...Experiment) HandlerFunc {
+	for _, e := range exps {
+		if e.Name == "" || len(e.Variants) == 0 {
+			panic(fmt.Sprintf("invalid experiment '%s': a name and variants are required", e.Name))
+		}
+		if e.Weights != nil && len(e.Weights) != len(e.Variants) {
+			panic(fmt.Sprintf("invalid experiment '%s': %d weights for %d variants", e.Name, len(e.Weights), len(e.Variants)))
+		}
+	}
+
+	return func(c *Context) {
+		stored := url.Values{}
+		if raw, err := c.Cookie(ExperimentCookie); err == nil {
+			stored, _ = url.ParseQuery(raw)
+		}
+
+		assigned := make(map[string]string, len(exps))
+		changed := false
+		subject := principalSubject(c)
+		for _, e := range exps {
+			v := stored.Get(e.Name)
+			if subject != "" || !slices.Contains(e.Variants, v) {
+				v = e.assign(subject)
+			}
+			assigned[e.Name] = v
+			if stored.Get(e.Name) != v {
+				stored.Set(e.Name, v)
+				changed = true
+			}
+		}
+		c.Set(experimentsKey, assigned)
+
+		if changed {
+			c.SetCookie(&http.Cookie{
+				Name:     ExperimentCookie,
+				Value:    stored.Encode(),
+				Path:     "/",
+				MaxAge:   int(365 * 24 * time.Hour / time.Second),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+		c.Next()
+	}
+}
+
+// principalSubject returns the subject of the request's principal, or "".
+func principalSubject(c *Context) string {
+	if p := c.Principal(); p != nil {
+		return p.Subject()
+	}
+	return ""
+}
+
+// assign picks a variant by the hash of subject, or at random without one.
+func (e Experiment) assign(subject string) string {
+	total := 0
+	for i := range e.Variants {
+		total += e.weight(i)
+	}
+	if total <= 0 {
+		return e.Variants[0]
+	}
+
+	var n int
+	if subject != "" {
+		h := fnv.New32a()
+		h.Write([]byte(e.Name + "\x00" + subject))
+		n = int(h.Sum32() % uint32(total))
+	} else {
+		n = rand.IntN(total)
+	}
+
+	for i, v := range e.Variants {
+		if n -= e.weight(i); n < 0 {
+			return v
+		}
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+func (e Experiment) weight(i int) int {
+	if e.Weights == nil {
+		return 1
+	}
+	return max(e.Weights[i], 0)
+}
+
+// Experiment returns the variant of the named experiment assigned to the
+// request, or "" if the experiment is not running on this route.
+func (c *Context) Experiment(name string) string {
+	return c.Experiments()[name]
+}
+
+// Experiments returns every assignment of the request, keyed by
+// experiment name, e.g. to label metrics or analytics events.
+func (c *Context) Experiments() map[string]string {
+	v, _ := c.Get(experimentsKey)
+	m, _ := v.(map[string]string)
+	return m
+}
@@ -0,0 +1,82 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testPrincipal string
+
+func (p testPrincipal) Subject() string { return string(p) }
+
+func TestExperiments(t *testing.T) {
+	sl := New()
+	sl.Use(func(c *Context) {
+		if u := c.Header("X-User"); u != "" {
+			c.SetPrincipal(testPrincipal(u))
+		}
+		c.Next()
+	})
+	sl.Use(Experiments(
+		Experiment{Name: "checkout", Variants: []string{"control", "one-page"}},
+		Experiment{Name: "banner", Variants: []string{"off", "on"}, Weights: []int{0, 1}},
+	))
+	sl.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "%s %s", c.Experiment("checkout"), c.Experiment("banner"))
+	})
+
+	do := func(user string, cookie *http.Cookie) (string, *http.Cookie) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if user != "" {
+			req.Header.Set("X-User", user)
+		}
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		var set *http.Cookie
+		if cs := rec.Result().Cookies(); len(cs) > 0 {
+			set = cs[0]
+		}
+		return rec.Body.String(), set
+	}
+
+	body, cookie := do("", nil)
+	if cookie == nil || cookie.Name != ExperimentCookie {
+		t.Fatalf("no assignment cookie, body = %q", body)
+	}
+	if body != "control on" && body != "one-page on" {
+		t.Errorf("body = %q", body)
+	}
+	for range 10 {
+		again, set := do("", cookie)
+		if again != body || set != nil {
+			t.Fatalf("assignment changed to %q (cookie %v), want %q", again, set, body)
+		}
+	}
+
+	// Users are bucketed by subject, the same on every device.
+	first, _ := do("user-7", nil)
+	for range 10 {
+		if got, _ := do("user-7", nil); got != first {
+			t.Fatalf("user-7 got %q then %q", first, got)
+		}
+	}
+
+	// The subject wins over an assignment made before signing in.
+	other := "control"
+	if strings.HasPrefix(first, "control") {
+		other = "one-page"
+	}
+	stale := &http.Cookie{Name: ExperimentCookie, Value: "banner=on&checkout=" + other}
+	if got, set := do("user-7", stale); got != first || set == nil {
+		t.Errorf("user-7 with a stale cookie got %q (cookie %v), want %q", got, set, first)
+	}
+}
@@ -0,0 +1,90 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"archive/zip"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// File serves the named file from disk. Range, If-Range and conditional
+// requests are handled, so interrupted downloads can resume.
+func (c *Context) File(name string) {
+	http.ServeFile(c.Writer, c.Request, name)
+}
+
+// Attachment serves the named file from disk as a download saved as filename.
+func (c *Context) Attachment(name, filename string) {
+	c.Writer.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.File(name)
+}
+
+// DataFromReader writes the content of r with the given status.
+//
+// When r is an io.ReadSeeker and status is 200, the response is served
+// with http.ServeContent: it advertises Accept-Ranges and answers single
+// and multi range requests with 206, letting clients resume downloads.
+// modTime may be zero. Otherwise r is streamed as is, with Content-Length
+// set when size is not negative.
+func (c *Context) DataFromReader(status int, contentType string, r io.Reader, size int64, modTime time.Time) {
+	if contentType != "" {
+		c.Writer.Header().Set("Content-Type", contentType)
+	}
+
+	if rs, ok := r.(io.ReadSeeker); ok && status == http.StatusOK {
+		http.ServeContent(c.Writer, c.Request, "", modTime, rs)
+		return
+	}
+
+	if size >= 0 {
+		c.Writer.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	c.Writer.WriteHeader(status)
+	if _, err := io.Copy(c.Writer, r); err != nil {
+		log.Printf("[WARN] write response: %v", err)
+	}
+}
+
+// Zip streams a zip archive built by entries straight to the client, so
+// "download all" endpoints never hold the archive in memory. Headers are
+// committed before entries runs; an error can only be logged and leaves
+// the archive truncated, which clients detect as corrupt. Set
+// Content-Disposition beforehand to name the download.
+func (c *Context) Zip(status int, entries func(zw *zip.Writer) error) {
+	c.Writer.Header().Set("Content-Type", "application/zip")
+	c.Writer.WriteHeader(status)
+
+	zw := zip.NewWriter(c.Writer)
+	if err := entries(zw); err != nil {
+		log.Printf("[ERROR] zip stream: %v", err)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("[ERROR] zip stream: %v", err)
+	}
+}
+
+// MultipartMixed streams a multipart/mixed response whose parts are
+// written by parts as they are produced. Like Zip, headers are committed
+// first and errors are only logged.
+func (c *Context) MultipartMixed(status int, parts func(mw *multipart.Writer) error) {
+	mw := multipart.NewWriter(c.Writer)
+	c.Writer.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	c.Writer.WriteHeader(status)
+
+	if err := parts(mw); err != nil {
+		log.Printf("[ERROR] multipart stream: %v", err)
+		return
+	}
+	if err := mw.Close(); err != nil {
+		log.Printf("[ERROR] multipart stream: %v", err)
+	}
+}
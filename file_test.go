@@ -0,0 +1,154 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContext_DataFromReaderRange(t *testing.T) {
+	content := "0123456789abcdefghij"
+
+	tests := []struct {
+		name        string
+		reader      func() io.Reader
+		rangeHeader string
+		status      int
+		body        string
+	}{
+		{"full", func() io.Reader { return strings.NewReader(content) }, "", http.StatusOK, content},
+		{"range", func() io.Reader { return strings.NewReader(content) }, "bytes=10-14", http.StatusPartialContent, "abcde"},
+		{"suffix", func() io.Reader { return strings.NewReader(content) }, "bytes=-3", http.StatusPartialContent, "hij"},
+		{"unseekable", func() io.Reader { return io.LimitReader(strings.NewReader(content), 100) }, "bytes=10-14", http.StatusOK, content},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := New()
+			sl.GET("/report", func(c *Context) {
+				c.DataFromReader(http.StatusOK, "text/plain", tt.reader(), int64(len(content)), time.Time{})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/report", nil)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("status = %d, body = %q; want %d, %q", rec.Code, rec.Body.String(), tt.status, tt.body)
+			}
+		})
+	}
+}
+
+func TestContext_FileRange(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "video.bin")
+	if err := os.WriteFile(name, []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sl := New()
+	sl.GET("/video", func(c *Context) { c.Attachment(name, "video clip.bin") })
+
+	req := httptest.NewRequest(http.MethodGet, "/video", nil)
+	req.Header.Set("Range", "bytes=4-")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "456789" {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Accept-Ranges") != "bytes" {
+		t.Errorf("Accept-Ranges = %q", rec.Header().Get("Accept-Ranges"))
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="video clip.bin"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+}
+
+func TestContext_Zip(t *testing.T) {
+	sl := New()
+	sl.GET("/attachments", func(c *Context) {
+		c.SetHeader("Content-Disposition", "attachment; filename=attachments.zip")
+		c.Zip(http.StatusOK, func(zw *zip.Writer) error {
+			for _, name := range []string{"a.txt", "b.txt"} {
+				w, err := zw.Create(name)
+				if err != nil {
+					return err
+				}
+				io.WriteString(w, "content of "+name)
+			}
+			return nil
+		})
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/attachments", nil))
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	if len(zr.File) != 2 || zr.File[1].Name != "b.txt" {
+		t.Errorf("files = %v", zr.File)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename=attachments.zip` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+}
+
+func TestContext_MultipartMixed(t *testing.T) {
+	sl := New()
+	sl.GET("/batch", func(c *Context) {
+		c.MultipartMixed(http.StatusOK, func(mw *multipart.Writer) error {
+			for _, body := range []string{`{"id":1}`, `{"id":2}`} {
+				w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+				if err != nil {
+					return err
+				}
+				io.WriteString(w, body)
+			}
+			return nil
+		})
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/batch", nil))
+
+	_, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := multipart.NewReader(rec.Body, params["boundary"])
+	var bodies []string
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(p)
+		bodies = append(bodies, string(b))
+	}
+	if len(bodies) != 2 || bodies[1] != `{"id":2}` {
+		t.Errorf("parts = %q", bodies)
+	}
+}
@@ -0,0 +1,97 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FlashCookie is the cookie carrying flash messages to the next request.
+var FlashCookie = "sol_flash"
+
+const (
+	flashOutKey = "sol.flash"
+	flashInKey  = "sol.flashes"
+	csrfKey     = "sol.csrf"
+)
+
+// FlashMessage is a one-time message shown on the next rendered page,
+// e.g. after a redirect.
+type FlashMessage struct {
+	Kind    string `json:"k"`
+	Message string `json:"m"`
+}
+
+// Flash queues a message of the given kind ("success", "error", ...) for
+// the next request, typically before redirecting. It must be called before
+// the response is written.
+func (c *Context) Flash(kind, message string) {
+	var out []FlashMessage
+	if v, ok := c.Get(flashOutKey); ok {
+		out = v.([]FlashMessage)
+	}
+	out = append(out, FlashMessage{Kind: kind, Message: message})
+	c.Set(flashOutKey, out)
+
+	b, _ := json.Marshal(out)
+	c.setFlashCookie(base64.RawURLEncoding.EncodeToString(b), 0)
+}
+
+// Flashes returns the messages queued by the previous request and clears
+// them, so they are shown once. Templates reach them as "flashes".
+func (c *Context) Flashes() []FlashMessage {
+	if v, ok := c.Get(flashInKey); ok {
+		return v.([]FlashMessage)
+	}
+
+	var in []FlashMessage
+	if raw, err := c.Cookie(FlashCookie); err == nil && raw != "" {
+		if b, err := base64.RawURLEncoding.DecodeString(raw); err == nil {
+			json.Unmarshal(b, &in)
+		}
+		// Messages queued during this request keep the cookie alive.
+		if _, queued := c.Get(flashOutKey); !queued {
+			c.setFlashCookie("", -1)
+		}
+	}
+	c.Set(flashInKey, in)
+	return in
+}
+
+// setFlashCookie replaces any flash cookie already set on the response.
+func (c *Context) setFlashCookie(value string, maxAge int) {
+	h := c.Writer.Header()
+	cookies := h.Values("Set-Cookie")
+	h.Del("Set-Cookie")
+	for _, v := range cookies {
+		if !strings.HasPrefix(v, FlashCookie+"=") {
+			h.Add("Set-Cookie", v)
+		}
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     FlashCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// SetCSRFToken records the CSRF token of the request, for CSRF middleware.
+// Templates reach it as "csrf_token".
+func (c *Context) SetCSRFToken(token string) {
+	c.Set(csrfKey, token)
+}
+
+// CSRFToken returns the token set with SetCSRFToken, or "".
+func (c *Context) CSRFToken() string {
+	token, _ := c.GetString(csrfKey)
+	return token
+}
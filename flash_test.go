@@ -0,0 +1,61 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFlashAndTemplateFuncs(t *testing.T) {
+	sl := New()
+	err := sl.LoadTemplates(TemplateConfig{
+		FS: fstest.MapFS{
+			"form.html": {Data: []byte(`{{range flashes}}[{{.Kind}}: {{.Message}}]{{end}}<input value="{{csrf_token}}">`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+
+	sl.POST("/save", func(c *Context) {
+		c.Flash("success", "Saved")
+		c.Flash("info", "Reindexing")
+		http.Redirect(c.Writer, c.Request, "/form", http.StatusSeeOther)
+	})
+	sl.GET("/form", func(c *Context) {
+		c.SetCSRFToken("tok")
+		c.Render(http.StatusOK, "form", nil)
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/save", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != FlashCookie {
+		t.Fatalf("cookies = %v, want a single flash cookie", cookies)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/form", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	want := `[success: Saved][info: Reindexing]<input value="tok">`
+	if rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+	if c := rec.Result().Cookies(); len(c) != 1 || c[0].MaxAge >= 0 {
+		t.Errorf("flash cookie not cleared: %v", c)
+	}
+
+	// Without the cookie nothing is shown again.
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/form", nil))
+	if rec.Body.String() != `<input value="tok">` {
+		t.Errorf("second render = %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,84 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/netip"
+)
+
+// geoKey is the Context key holding the resolved location.
+const geoKey = "sol.geo"
+
+// Geo is the location of a client IP. Empty fields are unknown.
+type Geo struct {
+	// Country is the ISO 3166-1 alpha-2 code, e.g. "DE".
+	Country string
+	// Region is the ISO 3166-2 subdivision code without the country,
+	// e.g. "BY".
+	Region string
+	City   string
+}
+
+// GeoResolver maps IPs to locations, e.g. backed by a MaxMind database
+// or a lookup service.
+type GeoResolver interface {
+	Resolve(ctx context.Context, ip netip.Addr) (Geo, error)
+}
+
+// GeoResolverFunc adapts a function to GeoResolver.
+type GeoResolverFunc func(ctx context.Context, ip netip.Addr) (Geo, error)
+
+func (f GeoResolverFunc) Resolve(ctx context.Context, ip netip.Addr) (Geo, error) {
+	return f(ctx, ip)
+}
+
+// GeoIP resolves the location of the client IP with r and records it for
+// Context.Geo. The IP is TrustedClientIP: forwarding headers count only
+// once SetTrustedProxies is configured, so clients cannot pick their
+// location, and with it dodge BlockCountries, by spoofing them.
+// Resolution errors are logged and leave the location unknown; private
+// and invalid addresses are not looked up.
+func GeoIP(r GeoResolver) HandlerFunc {
+	return func(c *Context) {
+		ip, err := netip.ParseAddr(TrustedClientIP(c.Request))
+		if err == nil && ip.IsGlobalUnicast() && !ip.IsPrivate() {
+			geo, err := r.Resolve(c.Context(), ip.Unmap())
+			if err != nil {
+				log.Printf("[WARN] geoip %s: %v", ip, err)
+			} else {
+				c.Set(geoKey, geo)
+			}
+		}
+		c.Next()
+	}
+}
+
+// Geo returns the client location found by GeoIP, or the zero Geo.
+func (c *Context) Geo() Geo {
+	v, _ := c.Get(geoKey)
+	geo, _ := v.(Geo)
+	return geo
+}
+
+// BlockCountries answers 451 Unavailable For Legal Reasons to clients
+// located in one of countries. It needs GeoIP before it and so locates
+// clients by the peer address unless trusted proxies are configured;
+// clients of unknown location are let through.
+func BlockCountries(countries ...string) HandlerFunc {
+	blocked := make(map[string]bool, len(countries))
+	for _, cc := range countries {
+		blocked[cc] = true
+	}
+	return func(c *Context) {
+		if cc := c.Geo().Country; cc != "" && blocked[cc] {
+			c.AbortWithStatus(http.StatusUnavailableForLegalReasons)
+			return
+		}
+		c.Next()
+	}
+}
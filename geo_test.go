@@ -0,0 +1,70 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"testing"
+)
+
+func TestGeoIP(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	db := map[string]Geo{
+		"203.0.113.7":  {Country: "DE", Region: "BY", City: "Munich"},
+		"198.51.100.1": {Country: "KP"},
+	}
+	var lookups int
+	resolver := GeoResolverFunc(func(ctx context.Context, ip netip.Addr) (Geo, error) {
+		lookups++
+		if g, ok := db[ip.String()]; ok {
+			return g, nil
+		}
+		return Geo{}, errors.New("not found")
+	})
+
+	sl := New()
+	sl.Use(GeoIP(resolver), BlockCountries("KP"))
+	sl.GET("/", func(c *Context) {
+		g := c.Geo()
+		c.String(http.StatusOK, "%s/%s/%s", g.Country, g.Region, g.City)
+	})
+
+	tests := []struct {
+		remote    string
+		forwarded string
+		status    int
+		body      string
+	}{
+		{"203.0.113.7:1234", "", http.StatusOK, "DE/BY/Munich"},
+		{"198.51.100.1:1234", "", http.StatusUnavailableForLegalReasons, ""},
+		{"198.51.100.1:1234", "203.0.113.7", http.StatusUnavailableForLegalReasons, ""},
+		{"192.0.2.55:1234", "", http.StatusOK, "//"},
+		{"10.0.0.1:1234", "", http.StatusOK, "//"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remote
+		if tt.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", tt.forwarded)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Code != tt.status || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s: %d %q, want %d %q", tt.remote, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+	if lookups != 4 {
+		t.Errorf("lookups = %d, want 4 (private addresses skipped)", lookups)
+	}
+}
@@ -0,0 +1,162 @@
+// Package graphql
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+type contextKey struct{}
+
+// FromContext returns the sol Context of the request being resolved,
+// so resolvers can reach route params, the principal or values set by
+// middleware. It returns nil outside a request mounted by this package.
+func FromContext(ctx context.Context) *sol.Context {
+	c, _ := ctx.Value(contextKey{}).(*sol.Context)
+	return c
+}
+
+// Params is a decoded GraphQL request.
+type Params struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ExecutorFunc executes a GraphQL request and returns the JSON encodable
+// result, e.g. a wrapper around graphql-go's graphql.Do.
+type ExecutorFunc func(ctx context.Context, p Params) any
+
+// Router is the part of a sol engine or group used by Mount.
+type Router interface {
+	GET(path string, h ...sol.HandlerFunc) *sol.Route
+	POST(path string, h ...sol.HandlerFunc) *sol.Route
+}
+
+// Config configures a mounted endpoint.
+type Config struct {
+	// GraphiQL serves the GraphiQL IDE to browsers requesting the endpoint
+	// with GET. Enable it in development only.
+	GraphiQL bool
+}
+
+// Handler adapts an http.Handler executor, such as gqlgen's handler.Server.
+func Handler(h http.Handler) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		h.ServeHTTP(c.Writer, withContext(c))
+	}
+}
+
+// Executor adapts an ExecutorFunc, decoding GET query strings and POST
+// JSON bodies into Params and encoding the result as JSON.
+func Executor(exec ExecutorFunc) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		p, err := decodeParams(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]any{
+				"errors": []map[string]string{{"message": err.Error()}},
+			})
+			return
+		}
+		c.JSON(http.StatusOK, exec(withContext(c).Context(), p))
+	}
+}
+
+// Mount registers h on GET and POST path, serving GraphiQL to browsers
+// when enabled.
+func Mount(r Router, path string, h sol.HandlerFunc, config ...Config) {
+	var cfg Config
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	get := h
+	if cfg.GraphiQL {
+		get = func(c *sol.Context) {
+			if c.QueryParam("query") == "" && strings.Contains(c.Header("Accept"), "text/html") {
+				GraphiQL(c.Path())(c)
+				return
+			}
+			h(c)
+		}
+	}
+
+	r.GET(path, get)
+	r.POST(path, h)
+}
+
+func withContext(c *sol.Context) *http.Request {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, c))
+	return c.Request
+}
+
+func decodeParams(c *sol.Context) (Params, error) {
+	var p Params
+
+	if c.Method() == http.MethodGet {
+		p.Query = c.QueryParam("query")
+		p.OperationName = c.QueryParam("operationName")
+		if v := c.QueryParam("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &p.Variables); err != nil {
+				return p, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+	} else {
+		ct := c.Header("Content-Type")
+		switch {
+		case strings.HasPrefix(ct, "application/graphql"):
+			body, err := c.Body()
+			if err != nil {
+				return p, fmt.Errorf("read body: %w", err)
+			}
+			p.Query = string(body)
+		default:
+			if err := json.NewDecoder(c.Request.Body).Decode(&p); err != nil {
+				return p, fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+	}
+
+	if p.Query == "" {
+		return p, fmt.Errorf("missing query")
+	}
+	return p, nil
+}
+
+var graphiqlTmpl = template.Must(template.New("graphiql").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>GraphiQL</title>
+<link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+</head>
+<body style="margin:0">
+<div id="graphiql" style="height:100vh"></div>
+<script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+<script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+<script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+<script>
+const fetcher = GraphiQL.createFetcher({ url: {{.}} });
+ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher }));
+</script>
+</body>
+</html>
+`))
+
+// GraphiQL serves the GraphiQL IDE talking to endpoint.
+func GraphiQL(endpoint string) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		c.SetHeader("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		graphiqlTmpl.Execute(c.Writer, endpoint)
+	}
+}
@@ -0,0 +1,57 @@
+// Package graphql
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestExecutor(t *testing.T) {
+	exec := func(ctx context.Context, p Params) any {
+		c := FromContext(ctx)
+		return map[string]any{"data": map[string]any{
+			"query":  p.Query,
+			"tenant": c.Param("tenant"),
+			"id":     p.Variables["id"],
+		}}
+	}
+
+	sl := sol.New()
+	Mount(sl, "/:tenant/graphql", Executor(exec), Config{GraphiQL: true})
+
+	body := `{"query":"{ user(id: $id) { name } }","variables":{"id":"1"}}`
+	req := httptest.NewRequest(http.MethodPost, "/acme/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	want := `{"data":{"id":"1","query":"{ user(id: $id) { name } }","tenant":"acme"}}` + "\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/acme/graphql", nil)
+	req.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "GraphiQL") {
+		t.Errorf("expected GraphiQL page, got %q", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/acme/graphql", nil)
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing query: status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,54 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RunWithGRPC serves gRPC and HTTP traffic on a single cleartext listener.
+// HTTP/2 requests with an application/grpc content type go to grpcServer,
+// typically a *grpc.Server (which implements http.Handler); everything else
+// is handled by sol. Unencrypted HTTP/2 (h2c) is enabled for this purpose.
+//
+// gRPC streams are subject to the engine timeouts; use WithTimeouts to
+// lift the write timeout for long-lived streams.
+func (sl *Sol) RunWithGRPC(grpcServer http.Handler, addr string) {
+	var p http.Protocols
+	p.SetHTTP1(true)
+	p.SetUnencryptedHTTP2(true)
+
+	sl.server.Protocols = &p
+	sl.server.Handler = grpcHandler(grpcServer, sl.server.Handler)
+	sl.Run(addr)
+}
+
+// RunTLSWithGRPC is the TLS variant of RunWithGRPC, sharing one certificate
+// between gRPC and HTTP traffic.
+func (sl *Sol) RunTLSWithGRPC(grpcServer http.Handler, addr, certFile, keyFile string) {
+	var p http.Protocols
+	p.SetHTTP1(true)
+	p.SetHTTP2(true)
+
+	sl.server.Protocols = &p
+	sl.server.Handler = grpcHandler(grpcServer, sl.server.Handler)
+	sl.RunTLS(addr, certFile, keyFile)
+}
+
+// grpcHandler routes gRPC requests to grpcServer and the rest to next.
+func grpcHandler(grpcServer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGRPC(r) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
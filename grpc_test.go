@@ -0,0 +1,47 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGRPCHandler(t *testing.T) {
+	sl := New()
+	sl.POST("/echo", func(c *Context) {
+		c.String(http.StatusOK, "http")
+	})
+	grpcServer := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("grpc"))
+	})
+	h := grpcHandler(grpcServer, sl)
+
+	tests := []struct {
+		name        string
+		protoMajor  int
+		contentType string
+		want        string
+	}{
+		{"grpc", 2, "application/grpc+proto", "grpc"},
+		{"http2 json", 2, "application/json", "http"},
+		{"http1 grpc content type", 1, "application/grpc", "http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/echo", nil)
+			req.ProtoMajor = tt.protoMajor
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Package i18n
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Bundle holds the message catalogs of every supported locale.
+type Bundle struct {
+	fallback string
+
+	mu       sync.RWMutex
+	catalogs map[string]map[string]string
+}
+
+// New returns an empty Bundle. Missing messages fall back to the fallback locale.
+func New(fallback string) *Bundle {
+	return &Bundle{
+		fallback: normalizeTag(fallback),
+		catalogs: make(map[string]map[string]string),
+	}
+}
+
+// Fallback returns the fallback locale.
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+// AddMessages adds messages to the catalog of locale, replacing existing keys.
+func (b *Bundle) AddMessages(locale string, messages map[string]string) {
+	locale = normalizeTag(locale)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	catalog := b.catalogs[locale]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		b.catalogs[locale] = catalog
+	}
+	for k, v := range messages {
+		catalog[k] = v
+	}
+}
+
+// LoadFile loads a catalog from disk. The locale is taken from the file
+// name, e.g. "locales/zh-CN.json" or "locales/en.toml".
+func (b *Bundle) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	return b.load(path.Base(name), data)
+}
+
+// LoadFS loads every .json and .toml catalog found in dir of fsys.
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := path.Ext(e.Name())
+		if ext != ".json" && ext != ".toml" {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("i18n: %w", err)
+		}
+		if err := b.load(e.Name(), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) load(filename string, data []byte) error {
+	ext := path.Ext(filename)
+	locale := strings.TrimSuffix(filename, ext)
+
+	var (
+		messages map[string]string
+		err      error
+	)
+	switch ext {
+	case ".json":
+		messages, err = parseJSON(data)
+	case ".toml":
+		messages, err = parseTOML(data)
+	default:
+		return fmt.Errorf("i18n: unsupported catalog format %q", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("i18n: %s: %w", filename, err)
+	}
+
+	b.AddMessages(locale, messages)
+	return nil
+}
+
+// Locales returns the loaded locales in sorted order.
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	locales := make([]string, 0, len(b.catalogs))
+	for l := range b.catalogs {
+		locales = append(locales, l)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Translate returns the message for key in locale, formatted with args.
+// It falls back to the base language ("zh" for "zh-CN"), then the fallback
+// locale, and finally to the key itself, which is returned unformatted.
+func (b *Bundle) Translate(locale, key string, args ...any) string {
+	msg, ok := b.lookup(normalizeTag(locale), key)
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func (b *Bundle) lookup(locale, key string) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	candidates := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, b.fallback)
+
+	for _, l := range candidates {
+		if msg, ok := b.catalogs[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Match returns the best supported locale for the given language tags,
+// in order of preference, or the fallback locale if none match.
+func (b *Bundle) Match(tags ...string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := b.catalogs[tag]; ok {
+			return tag
+		}
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			if _, ok := b.catalogs[base]; ok {
+				return base
+			}
+		}
+	}
+	return b.fallback
+}
+
+// normalizeTag turns "zh_cn" or "ZH-cn" into "zh-CN".
+func normalizeTag(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	lang, region, ok := strings.Cut(tag, "-")
+	if !ok {
+		return strings.ToLower(tag)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
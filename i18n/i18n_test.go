@@ -0,0 +1,93 @@
+// Package i18n
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wantnotshould/sol"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	b := New("en")
+	err := b.LoadFS(fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"hello": "Hello, %s", "user": {"name": "Name"}}`)},
+		"locales/zh.toml": {Data: []byte("# Chinese\nhello = \"你好, %s\" # greeting\n[user]\nname = '名字'\n")},
+	}, "locales")
+	if err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	return b
+}
+
+func TestTranslate(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"en", "hello", []any{"Perry"}, "Hello, Perry"},
+		{"zh", "hello", []any{"Perry"}, "你好, Perry"},
+		{"zh-CN", "user.name", nil, "名字"},
+		{"fr", "user.name", nil, "Name"},
+		{"zh", "missing", nil, "missing"},
+		{"zh", "welcome.user", []any{"bob"}, "welcome.user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.key, func(t *testing.T) {
+			if got := b.Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+				t.Errorf("Translate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("fr;q=0.5, zh-CN, en;q=0.8, *;q=0.1, de;q=0")
+	want := []string{"zh-CN", "en", "fr"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseAcceptLanguage = %v, want %v", got, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	sl := sol.New()
+	sl.Use(Middleware(newTestBundle(t)))
+	sl.GET("/", func(c *sol.Context) {
+		c.String(http.StatusOK, "%s|%s", c.Locale(), c.T("hello", "Perry"))
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   string
+	}{
+		{"default", "/", "", "en|Hello, Perry"},
+		{"header", "/", "zh-CN,en;q=0.8", "zh|你好, Perry"},
+		{"query wins", "/?lang=en", "zh", "en|Hello, Perry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept-Language", tt.header)
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
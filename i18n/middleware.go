@@ -0,0 +1,64 @@
+// Package i18n
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package i18n
+
+import "github.com/wantnotshould/sol"
+
+// Config controls where the request locale is read from.
+// Sources are tried in order: query, cookie, then Accept-Language.
+type Config struct {
+	// QueryParam is the query parameter holding the locale, "lang" by default.
+	QueryParam string
+	// Cookie is the cookie holding the locale, "lang" by default.
+	Cookie string
+	// IgnoreHeader disables Accept-Language negotiation.
+	IgnoreHeader bool
+}
+
+// Middleware resolves the request locale and installs the bundle as the
+// Context translator, so handlers and templates can call c.T.
+func Middleware(b *Bundle, config ...Config) sol.HandlerFunc {
+	var cfg Config
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.QueryParam == "" {
+		cfg.QueryParam = "lang"
+	}
+	if cfg.Cookie == "" {
+		cfg.Cookie = "lang"
+	}
+
+	return func(c *sol.Context) {
+		locale := Resolve(c, b, cfg)
+		c.SetLocale(locale, func(key string, args ...any) string {
+			return b.Translate(locale, key, args...)
+		})
+		c.Next()
+	}
+}
+
+// Resolve picks the locale for the request from the sources in cfg.
+func Resolve(c *sol.Context, b *Bundle, cfg Config) string {
+	var tags []string
+
+	if v := c.QueryParam(cfg.QueryParam); v != "" {
+		tags = append(tags, v)
+	}
+	if v, err := c.Cookie(cfg.Cookie); err == nil && v != "" {
+		tags = append(tags, v)
+	}
+	if !cfg.IgnoreHeader {
+		tags = append(tags, ParseAcceptLanguage(c.Header("Accept-Language"))...)
+	}
+
+	return b.Match(tags...)
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language
+// header ordered by quality, highest first.
+func ParseAcceptLanguage(header string) []string {
+	return sol.ParseAcceptLanguage(header)
+}
@@ -0,0 +1,120 @@
+// Package i18n
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSON reads a catalog of string values. Nested objects are
+// flattened into dotted keys: {"user": {"name": "Name"}} → "user.name".
+func parseJSON(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]string)
+	if err := flatten("", raw, messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func flatten(prefix string, in map[string]any, out map[string]string) error {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case string:
+			out[key] = val
+		case map[string]any:
+			if err := flatten(key, val, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("key %q: message must be a string, got %T", key, v)
+		}
+	}
+	return nil
+}
+
+// parseTOML reads the subset of TOML used by message catalogs:
+// comments, [table] headers and key = "string" pairs. Tables are
+// flattened into dotted keys like parseJSON does.
+func parseTOML(data []byte) (map[string]string, error) {
+	messages := make(map[string]string)
+	table := ""
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid table header", lineNo)
+			}
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+
+		key := strings.Trim(strings.TrimSpace(k), `"`)
+		if table != "" {
+			key = table + "." + key
+		}
+
+		value, err := unquoteTOML(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		messages[key] = value
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func unquoteTOML(v string) (string, error) {
+	if len(v) >= 2 && v[0] == '\'' {
+		end := strings.IndexByte(v[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated literal string")
+		}
+		return v[1 : end+1], nil
+	}
+
+	if len(v) >= 2 && v[0] == '"' {
+		// Find the closing quote, skipping escaped ones, to drop trailing comments.
+		for i := 1; i < len(v); i++ {
+			if v[i] == '\\' {
+				i++
+				continue
+			}
+			if v[i] == '"' {
+				return strconv.Unquote(v[:i+1])
+			}
+		}
+		return "", fmt.Errorf("unterminated string")
+	}
+
+	return "", fmt.Errorf("message must be a string")
+}
@@ -5,24 +5,78 @@
 package sol
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"strings"
+	"sync/atomic"
 )
 
+// trustedProxies holds the prefixes set with SetTrustedProxies.
+var trustedProxies atomic.Pointer[[]netip.Prefix]
+
+// SetTrustedProxies restricts ClientIP to honor X-Forwarded-For and
+// X-Real-IP only on requests from proxies, given as IPs or CIDRs. With
+// none, the headers are trusted from any peer. The setting is process
+// wide.
+func SetTrustedProxies(proxies ...string) error {
+	if len(proxies) == 0 {
+		trustedProxies.Store(nil)
+		return nil
+	}
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if prefix, err := netip.ParsePrefix(p); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(p)
+		if err != nil {
+			return fmt.Errorf("sol: invalid trusted proxy %q", p)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	trustedProxies.Store(&prefixes)
+	return nil
+}
+
+// fromTrustedProxy reports whether the peer of r may set forwarding headers.
+func fromTrustedProxy(r *http.Request) bool {
+	prefixes := trustedProxies.Load()
+	if prefixes == nil {
+		return true
+	}
+	ap, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	return isTrustedProxy(*prefixes, ap.Addr())
+}
+
+// isTrustedProxy reports whether addr lies in one of prefixes.
+func isTrustedProxy(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // ClientIP returns the client's real IP address from the request.
-// It considers X-Forwarded-For, X-Real-IP, and RemoteAddr headers.
+// It considers X-Forwarded-For, X-Real-IP, and RemoteAddr headers, the
+// former two only from trusted proxies, see SetTrustedProxies.
 func ClientIP(r *http.Request) string {
+	if !fromTrustedProxy(r) {
+		return remoteIP(r)
+	}
+
 	// Check the X-Forwarded-For header
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		// Handle multiple IPs in the X-Forwarded-For header.
-		if idx := strings.Index(ip, ","); idx > 0 {
-			ip = ip[:idx]
-		}
-		ip = strings.TrimSpace(ip)
-		if isValidIP(ip) {
-			return ip
-		}
+	if ip, ok := forwardedFor(r.Header.Values("X-Forwarded-For")); ok {
+		return ip
 	}
 
 	// Check the X-Real-IP header
@@ -34,6 +88,54 @@ func ClientIP(r *http.Request) string {
 	}
 
 	// Fallback to RemoteAddr if no other headers are found.
+	return remoteIP(r)
+}
+
+// forwardedFor picks the client from X-Forwarded-For. With trusted
+// proxies configured, the hops are walked from the right and the first
+// one that is not a trusted proxy wins, so addresses the client prepended
+// are ignored. Otherwise the leftmost hop is taken.
+func forwardedFor(values []string) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+	hops := strings.Split(strings.Join(values, ","), ",")
+
+	prefixes := trustedProxies.Load()
+	if prefixes == nil {
+		ip := strings.TrimSpace(hops[0])
+		return ip, isValidIP(ip)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(hops[i])
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			// Hops left of a malformed one cannot be trusted.
+			return "", false
+		}
+		if i == 0 || !isTrustedProxy(*prefixes, addr) {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// TrustedClientIP returns the client IP for security decisions such as
+// bans, rate limits and geo blocking. Unlike ClientIP it ignores the
+// forwarding headers until SetTrustedProxies configured the proxies
+// allowed to set them. It then returns the address the outermost trusted
+// proxy saw, so clients cannot pick their own IP by forging
+// X-Forwarded-For entries.
+func TrustedClientIP(r *http.Request) string {
+	if trustedProxies.Load() == nil {
+		return remoteIP(r)
+	}
+	return ClientIP(r)
+}
+
+// remoteIP returns the IP of the peer of r.
+func remoteIP(r *http.Request) string {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		return "unknown"
@@ -0,0 +1,61 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPTrustedProxies(t *testing.T) {
+	if err := SetTrustedProxies("10.0.0.0/8", "::1"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrustedProxies()
+
+	tests := []struct {
+		remote, forwarded, want string
+	}{
+		{"10.1.2.3:4000", "203.0.113.7", "203.0.113.7"},
+		{"[::1]:4000", "203.0.113.7", "203.0.113.7"},
+		{"198.51.100.9:4000", "203.0.113.7", "198.51.100.9"},
+		{"10.1.2.3:4000", "", "10.1.2.3"},
+		{"10.1.2.3:4000", "1.2.3.4, 203.0.113.7", "203.0.113.7"},
+		{"10.1.2.3:4000", "1.2.3.4, 203.0.113.7, 10.9.9.9", "203.0.113.7"},
+		{"10.1.2.3:4000", "10.8.8.8, 10.9.9.9", "10.8.8.8"},
+		{"10.1.2.3:4000", "203.0.113.7, bogus", "10.1.2.3"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tt.remote
+		if tt.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", tt.forwarded)
+		}
+		if got := ClientIP(req); got != tt.want {
+			t.Errorf("ClientIP(%s, XFF %q) = %s, want %s", tt.remote, tt.forwarded, got, tt.want)
+		}
+	}
+
+	if err := SetTrustedProxies("proxy.internal"); err == nil {
+		t.Error("hostname accepted as trusted proxy")
+	}
+}
+
+func TestTrustedClientIP(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.1.2.3:4000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	if got := TrustedClientIP(req); got != "10.1.2.3" {
+		t.Errorf("without trusted proxies: %s, want the peer", got)
+	}
+	if err := SetTrustedProxies("10.0.0.0/8"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrustedProxies()
+	if got := TrustedClientIP(req); got != "203.0.113.7" {
+		t.Errorf("from a trusted proxy: %s, want the forwarded IP", got)
+	}
+}
@@ -0,0 +1,89 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TranslateFunc translates a message key into the request locale.
+type TranslateFunc func(key string, args ...any) string
+
+// SetLocale sets the request locale and the translator used by T.
+// It is normally called by i18n middleware.
+func (c *Context) SetLocale(locale string, translate TranslateFunc) {
+	c.locale = locale
+	c.translate = translate
+}
+
+// Locale returns the locale resolved for the request, or "" if none.
+func (c *Context) Locale() string {
+	return c.locale
+}
+
+// T translates key into the request locale.
+// Without a translator the key itself is returned.
+func (c *Context) T(key string, args ...any) string {
+	if c.translate != nil {
+		return c.translate(key, args...)
+	}
+	return key
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language
+// header ordered by quality, highest first.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var langs []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			langs = append(langs, weighted{tag, q})
+		}
+	}
+
+	slices.SortStableFunc(langs, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// AcceptLanguages returns the language tags of the request, the locale
+// set by i18n middleware first, then Accept-Language by quality.
+func (c *Context) AcceptLanguages() []string {
+	tags := ParseAcceptLanguage(c.Header("Accept-Language"))
+	if c.locale != "" {
+		tags = append([]string{c.locale}, tags...)
+	}
+	return tags
+}
@@ -5,11 +5,59 @@
 package sol
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"runtime"
+	"sync/atomic"
 	"time"
 )
 
+// The constructors wrap accessLogger in a closure of their own, so the
+// handlers they return are named after them and Route.Skip(Logger) works.
+
 func Logger() HandlerFunc {
+	h := accessLogger(log.Default(), LoggerConfig{})
+	return func(c *Context) { h(c) }
+}
+
+// LoggerTo is Logger writing access lines to w instead of the standard
+// logger, e.g. to a RotatingFile.
+func LoggerTo(w io.Writer) HandlerFunc {
+	h := accessLogger(log.New(w, "", log.LstdFlags), LoggerConfig{})
+	return func(c *Context) { h(c) }
+}
+
+// LoggerConfig configures LoggerWith.
+type LoggerConfig struct {
+	// Output receives the access lines, the standard logger if nil.
+	Output io.Writer
+	// Query logs the query string along with the path.
+	Query bool
+	// Headers are request headers to log, e.g. "Referer".
+	Headers []string
+	// Redact masks the logged values, DefaultRedaction if nil.
+	Redact *Redaction
+}
+
+// LoggerWith is Logger with explicit configuration. Query strings and
+// headers are verbose and prone to carry credentials, so logged values
+// go through the Redact rules.
+func LoggerWith(cfg LoggerConfig) HandlerFunc {
+	if cfg.Redact == nil {
+		cfg.Redact = &DefaultRedaction
+	}
+	l := log.Default()
+	if cfg.Output != nil {
+		l = log.New(cfg.Output, "", log.LstdFlags)
+	}
+	h := accessLogger(l, cfg)
+	return func(c *Context) { h(c) }
+}
+
+func accessLogger(l *log.Logger, cfg LoggerConfig) HandlerFunc {
 	return func(c *Context) {
 		start := time.Now()
 
@@ -19,14 +67,147 @@ func Logger() HandlerFunc {
 
 		clientIP := ClientIP(c.Request)
 		userAgent := c.Request.UserAgent()
+		path := c.Path()
+
+		var extra string
+		if cfg.Redact != nil {
+			userAgent = cfg.Redact.String(userAgent)
+			path = cfg.Redact.String(path)
+			if cfg.Query {
+				path = cfg.Redact.URL(c.Request.URL)
+			}
+			if len(cfg.Headers) > 0 {
+				h := cfg.Redact.Header(c.Request.Header)
+				for _, name := range cfg.Headers {
+					if v := h.Get(name); v != "" {
+						extra += " | " + http.CanonicalHeaderKey(name) + ": " + v
+					}
+				}
+			}
+		}
+		if c.ClientDisconnected() {
+			extra += " | client disconnected"
+		}
 
-		log.Printf("[ACCESS] %s | %v | %s | %s %s | %s",
+		l.Printf("[ACCESS] %s | %v | %s | %s %s | %s%s",
 			time.Now().Format("2006/01/02 15:04:05"),
 			duration,
 			clientIP,
 			c.Method(),
-			c.Path(),
+			path,
 			userAgent,
+			extra,
 		)
 	}
 }
+
+// SlowConfig configures SlowLogger.
+type SlowConfig struct {
+	// Threshold marks requests taking longer as slow, 1s by default.
+	Threshold time.Duration
+	// Stack logs all goroutine stacks when a request is still running at
+	// Threshold, showing where it is stuck. Dumping stacks stops the world
+	// briefly, so keep it for debugging.
+	Stack bool
+	// Count, if set, is incremented for every slow request, for metrics.
+	Count *atomic.Int64
+}
+
+// maxStackDump caps the goroutine dump logged by SlowLogger.
+const maxStackDump = 64 << 10
+
+// SlowLogger logs a warning with the route pattern for requests slower
+// than the configured threshold, to help find p99 offenders.
+func SlowLogger(cfg SlowConfig) HandlerFunc {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = time.Second
+	}
+
+	return func(c *Context) {
+		start := time.Now()
+
+		if cfg.Stack {
+			method, path := c.Method(), c.Path()
+			timer := time.AfterFunc(cfg.Threshold, func() {
+				buf := make([]byte, maxStackDump)
+				buf = buf[:runtime.Stack(buf, true)]
+				log.Printf("[SLOW] %s %s still running after %v\n%s", method, path, cfg.Threshold, buf)
+			})
+			defer timer.Stop()
+		}
+
+		c.Next()
+
+		duration := time.Since(start)
+		if duration < cfg.Threshold {
+			return
+		}
+
+		if cfg.Count != nil {
+			cfg.Count.Add(1)
+		}
+
+		route := c.RoutePattern()
+		if route == "" {
+			route = c.Path()
+		}
+		log.Printf("[WARN] slow request: %s %s (route %s) took %v, threshold %v",
+			c.Method(), c.Path(), route, duration, cfg.Threshold)
+	}
+}
+
+// Log levels for SetLogLevel.
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+var logLevels = map[string]int{LevelDebug: 0, LevelInfo: 1, LevelWarn: 2, LevelError: 3}
+
+// SetLogLevel drops standard logger lines below level. Lines are leveled
+// by their tag: [WARN] is warn, [ERROR] and [PANIC] are error, everything
+// else is info.
+func SetLogLevel(level string) error {
+	threshold, ok := logLevels[level]
+	if !ok {
+		return fmt.Errorf("sol: unknown log level %q", level)
+	}
+	w := log.Writer()
+	if lw, ok := w.(*levelWriter); ok {
+		w = lw.w
+	}
+	if threshold <= logLevels[LevelInfo] {
+		log.SetOutput(w)
+		return nil
+	}
+	log.SetOutput(&levelWriter{w: w, min: threshold})
+	return nil
+}
+
+// levelWriter filters log lines by the level of their tag.
+type levelWriter struct {
+	w   io.Writer
+	min int
+}
+
+func (lw *levelWriter) Write(p []byte) (int, error) {
+	if lineLevel(p) < lw.min {
+		return len(p), nil
+	}
+	return lw.w.Write(p)
+}
+
+// lineLevel levels a line by its first tag, which follows the timestamp.
+func lineLevel(p []byte) int {
+	if i := bytes.IndexByte(p, '['); i >= 0 {
+		switch tag := p[i:]; {
+		case bytes.HasPrefix(tag, []byte("[ERROR]")), bytes.HasPrefix(tag, []byte("[PANIC]")):
+			return logLevels[LevelError]
+		case bytes.HasPrefix(tag, []byte("[WARN]")):
+			return logLevels[LevelWarn]
+		}
+	}
+	return logLevels[LevelInfo]
+}
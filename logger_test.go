@@ -0,0 +1,90 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSlowLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var count atomic.Int64
+	sl := New()
+	sl.Use(SlowLogger(SlowConfig{Threshold: 20 * time.Millisecond, Count: &count}))
+	sl.GET("/fast", func(c *Context) {})
+	sl.GET("/users/:id", func(c *Context) { time.Sleep(30 * time.Millisecond) })
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if count.Load() != 1 {
+		t.Errorf("count = %d, want 1", count.Load())
+	}
+	out := buf.String()
+	if !strings.Contains(out, "GET /users/42 (route /users/:id)") {
+		t.Errorf("log missing slow request: %q", out)
+	}
+	if strings.Contains(out, "/fast") {
+		t.Errorf("fast request logged: %q", out)
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	if err := SetLogLevel(LevelWarn); err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("[ACCESS] GET /")
+	log.Printf("[WARN] disk almost full")
+	log.Printf("[ERROR] disk full")
+	if got := buf.String(); strings.Contains(got, "[ACCESS]") || !strings.Contains(got, "[WARN]") || !strings.Contains(got, "[ERROR]") {
+		t.Errorf("warn level output:\n%s", got)
+	}
+
+	buf.Reset()
+	SetLogLevel(LevelInfo)
+	log.Printf("[ACCESS] GET /")
+	if buf.Len() == 0 {
+		t.Error("info line dropped after lowering the level")
+	}
+	if err := SetLogLevel("verbose"); err == nil {
+		t.Error("unknown level accepted")
+	}
+}
+
+func TestLogger_Skip(t *testing.T) {
+	var buf bytes.Buffer
+	sl := New()
+	sl.Use(Logger(), LoggerTo(&buf), LoggerWith(LoggerConfig{Output: &buf}))
+	sl.GET("/healthz", func(c *Context) {}).Skip(Logger, LoggerTo, LoggerWith)
+	sl.GET("/page", func(c *Context) {}).Skip(Logger)
+
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if buf.Len() != 0 {
+		t.Errorf("skipped loggers wrote %q", buf.String())
+	}
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/page", nil))
+	if n := strings.Count(buf.String(), "/page"); n != 2 {
+		t.Errorf("/page logged %d times, want 2 (LoggerTo and LoggerWith): %q", n, buf.String())
+	}
+}
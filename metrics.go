@@ -0,0 +1,201 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// serverStats are the counters behind Stats.
+type serverStats struct {
+	requests  atomic.Uint64
+	inFlight  atomic.Int64
+	streams   atomic.Int64
+	ctxGets   atomic.Uint64
+	ctxAllocs atomic.Uint64
+
+	// conns maps each open connection to its last http.ConnState
+	conns       sync.Map
+	connsNew    atomic.Int64
+	connsActive atomic.Int64
+	connsIdle   atomic.Int64
+	connsClosed atomic.Uint64
+}
+
+// gauge returns the counter of connections in state s, nil for states
+// not counted as open.
+func (st *serverStats) gauge(s http.ConnState) *atomic.Int64 {
+	switch s {
+	case http.StateNew:
+		return &st.connsNew
+	case http.StateActive:
+		return &st.connsActive
+	case http.StateIdle:
+		return &st.connsIdle
+	}
+	return nil
+}
+
+// trackConn is the http.Server.ConnState hook moving conn between the
+// connection gauges.
+func (st *serverStats) trackConn(conn net.Conn, s http.ConnState) {
+	if prev, ok := st.conns.Load(conn); ok {
+		if g := st.gauge(prev.(http.ConnState)); g != nil {
+			g.Add(-1)
+		}
+	}
+	if g := st.gauge(s); g != nil {
+		g.Add(1)
+		st.conns.Store(conn, s)
+		return
+	}
+	// Closed or hijacked, e.g. by a WebSocket upgrade.
+	st.conns.Delete(conn)
+	st.connsClosed.Add(1)
+}
+
+// hookConnState installs connection tracking on server, keeping any
+// ConnState hook already set.
+func (sl *Sol) hookConnState(server *http.Server) {
+	st := sl.serverStats()
+	prev := server.ConnState
+	server.ConnState = func(conn net.Conn, s http.ConnState) {
+		st.trackConn(conn, s)
+		if prev != nil {
+			prev(conn, s)
+		}
+	}
+}
+
+func (r *routerImpl) serverStats() *serverStats {
+	return &r.stats
+}
+
+// Stats is a snapshot of the engine and Go runtime, for capacity planning.
+type Stats struct {
+	Requests    uint64 `json:"requests"`
+	InFlight    int64  `json:"in_flight"`
+	OpenStreams int64  `json:"open_streams"`
+	// ContextGets counts Contexts taken from the pool, ContextAllocs
+	// those that had to be allocated because the pool was empty.
+	ContextGets   uint64 `json:"context_gets"`
+	ContextAllocs uint64 `json:"context_allocs"`
+	// ConnsNew, ConnsActive and ConnsIdle count the open connections by
+	// http.ConnState; ConnsClosed counts closed and hijacked ones.
+	ConnsNew    int64  `json:"conns_new"`
+	ConnsActive int64  `json:"conns_active"`
+	ConnsIdle   int64  `json:"conns_idle"`
+	ConnsClosed uint64 `json:"conns_closed"`
+
+	Goroutines  int           `json:"goroutines"`
+	GOMAXPROCS  int           `json:"gomaxprocs"`
+	HeapAlloc   uint64        `json:"heap_alloc"`
+	HeapObjects uint64        `json:"heap_objects"`
+	Sys         uint64        `json:"sys"`
+	NumGC       uint32        `json:"num_gc"`
+	GCPause     time.Duration `json:"gc_pause"`
+}
+
+// PoolHitRate is the share of Contexts reused from the pool.
+func (s Stats) PoolHitRate() float64 {
+	if s.ContextGets == 0 {
+		return 0
+	}
+	return 1 - float64(s.ContextAllocs)/float64(s.ContextGets)
+}
+
+// Stats returns the current engine and runtime statistics.
+// It reads runtime.MemStats, which briefly stops the world.
+func (sl *Sol) Stats() Stats {
+	st := sl.serverStats()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	return Stats{
+		Requests:      st.requests.Load(),
+		InFlight:      st.inFlight.Load(),
+		OpenStreams:   st.streams.Load(),
+		ContextGets:   st.ctxGets.Load(),
+		ContextAllocs: st.ctxAllocs.Load(),
+		ConnsNew:      st.connsNew.Load(),
+		ConnsActive:   st.connsActive.Load(),
+		ConnsIdle:     st.connsIdle.Load(),
+		ConnsClosed:   st.connsClosed.Load(),
+		Goroutines:    runtime.NumGoroutine(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		HeapAlloc:     ms.HeapAlloc,
+		HeapObjects:   ms.HeapObjects,
+		Sys:           ms.Sys,
+		NumGC:         ms.NumGC,
+		GCPause:       time.Duration(ms.PauseTotalNs),
+	}
+}
+
+// TrackStream counts a long-lived connection such as an SSE stream or a
+// WebSocket among the open streams until the returned func is called.
+// StreamProgress tracks itself.
+func (c *Context) TrackStream() (done func()) {
+	if c.engine == nil {
+		return func() {}
+	}
+	st := c.engine.serverStats()
+	st.streams.Add(1)
+	var once atomic.Bool
+	return func() {
+		if once.CompareAndSwap(false, true) {
+			st.streams.Add(-1)
+		}
+	}
+}
+
+// EnableMetrics serves Stats at path in the Prometheus text format. The
+// auth handlers guard it; EnableMetrics panics when none are given. A
+// scraper on a trusted network can pass a handler that just calls Next.
+func (sl *Sol) EnableMetrics(path string, auth ...HandlerFunc) {
+	if len(auth) == 0 {
+		panic(fmt.Sprintf("cannot register '%s': metrics need an auth handler", path))
+	}
+	h := func(c *Context) {
+		c.SetHeader("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Status(http.StatusOK)
+		c.Writer.Write([]byte(sl.Stats().prometheus()))
+	}
+	sl.GET(path, append(auth[:len(auth):len(auth)], h)...)
+}
+
+func (s Stats) prometheus() string {
+	var b strings.Builder
+	metric := func(name, kind, help string, v any) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, v)
+	}
+
+	metric("sol_http_requests_total", "counter", "Requests served.", s.Requests)
+	metric("sol_http_requests_in_flight", "gauge", "Requests being served.", s.InFlight)
+	metric("sol_open_streams", "gauge", "Open SSE, WebSocket and other long-lived streams.", s.OpenStreams)
+	metric("sol_context_pool_gets_total", "counter", "Contexts taken from the pool.", s.ContextGets)
+	metric("sol_context_pool_allocs_total", "counter", "Contexts allocated on a pool miss.", s.ContextAllocs)
+	metric("sol_context_pool_hit_ratio", "gauge", "Share of Contexts reused from the pool.", s.PoolHitRate())
+	fmt.Fprintf(&b, "# HELP sol_connections Open connections by state.\n# TYPE sol_connections gauge\n")
+	fmt.Fprintf(&b, "sol_connections{state=\"new\"} %d\n", s.ConnsNew)
+	fmt.Fprintf(&b, "sol_connections{state=\"active\"} %d\n", s.ConnsActive)
+	fmt.Fprintf(&b, "sol_connections{state=\"idle\"} %d\n", s.ConnsIdle)
+	metric("sol_connections_closed_total", "counter", "Connections closed or hijacked.", s.ConnsClosed)
+	metric("go_goroutines", "gauge", "Number of goroutines.", s.Goroutines)
+	metric("go_gomaxprocs", "gauge", "Value of GOMAXPROCS.", s.GOMAXPROCS)
+	metric("go_memstats_heap_alloc_bytes", "gauge", "Bytes of allocated heap objects.", s.HeapAlloc)
+	metric("go_memstats_heap_objects", "gauge", "Number of allocated heap objects.", s.HeapObjects)
+	metric("go_memstats_sys_bytes", "gauge", "Bytes obtained from the OS.", s.Sys)
+	metric("go_gc_cycles_total", "counter", "Completed GC cycles.", s.NumGC)
+	metric("go_gc_pause_seconds_total", "counter", "Total GC stop-the-world pause time.", s.GCPause.Seconds())
+	return b.String()
+}
@@ -0,0 +1,115 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnableMetrics(t *testing.T) {
+	sl := New()
+	sl.EnableMetrics("/metrics", func(c *Context) { c.Next() })
+
+	var during Stats
+	sl.GET("/stream", func(c *Context) {
+		done := c.TrackStream()
+		during = sl.Stats()
+		done()
+		done()
+	})
+
+	for range 3 {
+		sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stream", nil))
+	}
+	if during.InFlight != 1 || during.OpenStreams != 1 {
+		t.Errorf("during request: in flight = %d, streams = %d", during.InFlight, during.OpenStreams)
+	}
+
+	st := sl.Stats()
+	if st.Requests != 3 || st.InFlight != 0 || st.OpenStreams != 0 || st.ContextGets != 3 {
+		t.Errorf("Stats = %+v", st)
+	}
+	if st.ContextAllocs == 0 || st.ContextAllocs > st.ContextGets {
+		t.Errorf("ContextAllocs = %d", st.ContextAllocs)
+	}
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE sol_http_requests_total counter\nsol_http_requests_total 4\n",
+		"sol_http_requests_in_flight 1\n",
+		"sol_open_streams 0\n",
+		"# TYPE go_goroutines gauge\n",
+		"go_gc_cycles_total ",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+}
+
+func TestConnectionStats(t *testing.T) {
+	sl := New()
+	var during Stats
+	sl.GET("/", func(c *Context) {
+		during = sl.Stats()
+		c.String(http.StatusOK, "ok")
+	})
+
+	srv := httptest.NewUnstartedServer(nil)
+	sl.WithServer(srv.Config)
+	srv.Start()
+	defer srv.Close()
+
+	client := srv.Client()
+	for range 2 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	if during.ConnsActive != 1 {
+		t.Errorf("during request: active conns = %d, want 1", during.ConnsActive)
+	}
+
+	// The keep-alive connection goes idle, then closed.
+	waitFor := func(cond func(Stats) bool) Stats {
+		deadline := time.Now().Add(time.Second)
+		for {
+			s := sl.Stats()
+			if cond(s) || time.Now().After(deadline) {
+				return s
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	if s := waitFor(func(s Stats) bool { return s.ConnsIdle == 1 }); s.ConnsIdle != 1 || s.ConnsActive != 0 {
+		t.Errorf("after requests: idle = %d, active = %d", s.ConnsIdle, s.ConnsActive)
+	}
+	client.CloseIdleConnections()
+	if s := waitFor(func(s Stats) bool { return s.ConnsClosed == 1 }); s.ConnsClosed != 1 || s.ConnsIdle != 0 {
+		t.Errorf("after close: closed = %d, idle = %d", s.ConnsClosed, s.ConnsIdle)
+	}
+	if m := sl.Stats().prometheus(); !strings.Contains(m, `sol_connections{state="idle"} 0`) {
+		t.Errorf("metrics lack connection gauges:\n%s", m)
+	}
+}
+
+func TestEnableMetrics_NoAuth(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for metrics without auth")
+		}
+	}()
+	New().EnableMetrics("/metrics")
+}
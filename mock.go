@@ -0,0 +1,141 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MockHeader marks responses served by Mock or from mock examples, so
+// clients can tell them from real ones.
+const MockHeader = "X-Sol-Mock"
+
+// Mock returns a handler answering every request with status and body,
+// for routes whose implementation is pending:
+//
+//	sl.GET("/v2/quotes", sol.Mock(http.StatusOK, sampleJSON))
+//
+// A []byte or string body is written as is, as JSON if it is valid JSON;
+// other values are encoded as JSON.
+func Mock(status int, body any) HandlerFunc {
+	return func(c *Context) {
+		c.SetHeader(MockHeader, "true")
+		writeMock(c, status, body)
+	}
+}
+
+func writeMock(c *Context, status int, body any) {
+	var b []byte
+	switch v := body.(type) {
+	case nil:
+		c.Status(status)
+		return
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		c.JSON(status, v)
+		return
+	}
+
+	if json.Valid(b) {
+		c.SetHeader("Content-Type", "application/json; charset=utf-8")
+	} else {
+		c.SetHeader("Content-Type", http.DetectContentType(b))
+	}
+	c.Status(status)
+	c.Writer.Write(b)
+}
+
+// NotImplemented answers 501 Not Implemented. It marks routes that are
+// declared but not written yet; with mocks enabled they serve example
+// responses instead.
+func NotImplemented(c *Context) {
+	if c.engine != nil && c.engine.mocks != nil && c.engine.mocks.serve(c) {
+		return
+	}
+	c.AbortWithStatus(http.StatusNotImplemented)
+}
+
+type mockSource struct {
+	dir string
+}
+
+// WithMocks makes routes handled by NotImplemented serve example
+// responses, so frontend teams can work against an API in progress. The
+// example is the one set with Route.Example with the lowest status, or
+// else the file under dir named after the route, e.g.
+// "mocks/v2/quotes/{id}.get.json" for GET /v2/quotes/:id and
+// "mocks/index.get.json" for GET /, answered with 200. Params are written
+// in braces, ":id" and "*path" as "{id}" and "{path}", so the names are
+// valid on every OS. HEAD routes without examples of their own answer
+// like the GET route of the path. An empty dir serves route examples
+// only.
+func (sl *Sol) WithMocks(dir string) *Sol {
+	sl.mocks = &mockSource{dir: dir}
+	return sl
+}
+
+// serve writes the example of the matched route and reports whether it
+// found one.
+func (m *mockSource) serve(c *Context) bool {
+	route := c.route
+	if route == nil {
+		return false
+	}
+	if route.method == http.MethodHead && len(route.doc.Examples) == 0 {
+		if get, _ := c.engine.Lookup(http.MethodGet, c.Request.URL.Path); get != nil {
+			route = get
+		}
+	}
+
+	if len(route.doc.Examples) > 0 {
+		status := slices.Min(slices.Collect(maps.Keys(route.doc.Examples)))
+		c.SetHeader(MockHeader, "true")
+		writeMock(c, status, route.doc.Examples[status])
+		c.Abort()
+		return true
+	}
+	if m.dir == "" {
+		return false
+	}
+
+	file := filepath.Join(m.dir, mockName(route.path)) + "." + strings.ToLower(route.method) + ".json"
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("[WARN] mock %s: %v", file, err)
+		}
+		return false
+	}
+	c.SetHeader(MockHeader, "true")
+	writeMock(c, http.StatusOK, b)
+	c.Abort()
+	return true
+}
+
+// mockName returns the relative file name of the mocks of path.
+func mockName(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segs {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	if name := filepath.Join(segs...); name != "" {
+		return name
+	}
+	return "index"
+}
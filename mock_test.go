@@ -0,0 +1,84 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMock(t *testing.T) {
+	sl := New()
+	sl.GET("/quotes", Mock(http.StatusOK, `[{"symbol":"SOL"}]`))
+	sl.GET("/users/:id", Mock(http.StatusOK, map[string]int{"id": 1}))
+	sl.GET("/banner", Mock(http.StatusOK, "<p>soon</p>"))
+
+	tests := []struct {
+		path, contentType, body string
+	}{
+		{"/quotes", "application/json; charset=utf-8", `[{"symbol":"SOL"}]`},
+		{"/users/7", "application/json; charset=utf-8", "{\"id\":1}\n"},
+		{"/banner", "text/html; charset=utf-8", "<p>soon</p>"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != http.StatusOK || rec.Header().Get(MockHeader) != "true" ||
+			rec.Header().Get("Content-Type") != tt.contentType || rec.Body.String() != tt.body {
+			t.Errorf("%s: %d %q %q", tt.path, rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+		}
+	}
+}
+
+func TestWithMocks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "v2", "quotes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "v2", "quotes", "{id}.get.json"), []byte(`{"price":42}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	routes := func(sl *Sol) {
+		sl.GET("/v2/quotes/:id", NotImplemented)
+		sl.HEAD("/v2/quotes/:id", NotImplemented)
+		sl.POST("/v2/orders", NotImplemented).
+			Example(http.StatusCreated, map[string]string{"status": "open"}).
+			Example(http.StatusConflict, nil)
+		sl.DELETE("/v2/orders/:id", NotImplemented)
+	}
+
+	tests := []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/v2/quotes/1", http.StatusOK, `{"price":42}`},
+		{http.MethodHead, "/v2/quotes/1", http.StatusOK, ""},
+		{http.MethodPost, "/v2/orders", http.StatusCreated, "{\"status\":\"open\"}\n"},
+		{http.MethodDelete, "/v2/orders/1", http.StatusNotImplemented, ""},
+	}
+
+	sl := New().WithMocks(dir)
+	routes(sl)
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s %s: %d %q", tt.method, tt.path, rec.Code, rec.Body.String())
+		}
+	}
+
+	off := New()
+	routes(off)
+	rec := httptest.NewRecorder()
+	off.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v2/orders", nil))
+	if rec.Code != http.StatusNotImplemented || rec.Header().Get(MockHeader) != "" {
+		t.Errorf("mocks off: %d", rec.Code)
+	}
+}
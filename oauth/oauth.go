@@ -0,0 +1,439 @@
+// Package oauth
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+var (
+	ErrState   = errors.New("oauth: invalid state")
+	ErrIDToken = errors.New("oauth: invalid id token")
+)
+
+// Provider holds the endpoints of an authorization server.
+type Provider struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+}
+
+// Discover reads the provider endpoints from the OIDC discovery document
+// of issuer.
+func Discover(ctx context.Context, issuer string) (Provider, error) {
+	var p Provider
+	u := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return p, fmt.Errorf("oauth: discover: %w", err)
+	}
+	if err := doJSON(http.DefaultClient, req, &p); err != nil {
+		return p, fmt.Errorf("oauth: discover: %w", err)
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return p, fmt.Errorf("oauth: discover: issuer %q does not match %q", p.Issuer, issuer)
+	}
+	return p, nil
+}
+
+// Config configures a Client.
+type Config struct {
+	Provider     Provider
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the absolute URL of the callback handler.
+	RedirectURL string
+	// Scopes requested, "openid profile email" by default.
+	Scopes []string
+	// HTTPClient calls the provider, http.DefaultClient by default.
+	HTTPClient *http.Client
+	// Cookie holds the state of a login in flight, "oauth_state" by default.
+	Cookie string
+	// Insecure drops the Secure cookie attribute, for local development.
+	Insecure bool
+	// OnLogin stores the identity once the callback succeeds, e.g. in the
+	// session or with a remember.Manager. An error answers 500.
+	OnLogin func(c *sol.Context, id *Identity) error
+	// AfterLogin is where the callback redirects when the login did not
+	// carry a return path, "/" by default.
+	AfterLogin string
+}
+
+// Token is the token response of the provider.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+	ExpiresIn    int64     `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"-"`
+}
+
+// Expired reports whether the access token expired, with a small margin.
+func (t *Token) Expired() bool {
+	return !t.Expiry.IsZero() && time.Now().Add(10*time.Second).After(t.Expiry)
+}
+
+// Identity is the user signed in by the provider. It is a sol.Principal.
+type Identity struct {
+	Claims map[string]any
+	Token  *Token
+}
+
+// Subject returns the "sub" claim.
+func (id *Identity) Subject() string { return id.String("sub") }
+
+// Email returns the "email" claim.
+func (id *Identity) Email() string { return id.String("email") }
+
+// Name returns the "name" claim.
+func (id *Identity) Name() string { return id.String("name") }
+
+// String returns a string claim, or "".
+func (id *Identity) String(claim string) string {
+	s, _ := id.Claims[claim].(string)
+	return s
+}
+
+// Client runs the authorization code flow with PKCE.
+type Client struct {
+	cfg Config
+}
+
+// New returns a Client. ClientID, RedirectURL and the provider's auth and
+// token endpoints are required.
+func New(cfg Config) *Client {
+	if cfg.ClientID == "" || cfg.RedirectURL == "" || cfg.Provider.AuthURL == "" || cfg.Provider.TokenURL == "" {
+		panic("oauth: ClientID, RedirectURL, Provider.AuthURL and Provider.TokenURL are required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Cookie == "" {
+		cfg.Cookie = "oauth_state"
+	}
+	if cfg.AfterLogin == "" {
+		cfg.AfterLogin = "/"
+	}
+	return &Client{cfg: cfg}
+}
+
+// loginState is kept in a short-lived cookie between Login and Callback.
+type loginState struct {
+	State    string `json:"s"`
+	Nonce    string `json:"n"`
+	Verifier string `json:"v"`
+	Return   string `json:"r,omitempty"`
+}
+
+// Login redirects to the provider. A relative "return" query parameter is
+// where Callback sends the user afterwards.
+func (cl *Client) Login(c *sol.Context) {
+	st := loginState{Return: localPath(c.QueryParam("return"))}
+	for _, p := range []*string{&st.State, &st.Nonce, &st.Verifier} {
+		v, err := randomString()
+		if err != nil {
+			log.Printf("[ERROR] %v", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		*p = v
+	}
+
+	b, _ := json.Marshal(st)
+	cl.setCookie(c, base64.RawURLEncoding.EncodeToString(b), 600)
+
+	challenge := sha256.Sum256([]byte(st.Verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {cl.cfg.ClientID},
+		"redirect_uri":          {cl.cfg.RedirectURL},
+		"scope":                 {strings.Join(cl.cfg.Scopes, " ")},
+		"state":                 {st.State},
+		"nonce":                 {st.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	http.Redirect(c.Writer, c.Request, withQuery(cl.cfg.Provider.AuthURL, q), http.StatusFound)
+}
+
+// Callback completes the login: it checks the state, exchanges the code,
+// validates the ID token, sets the Identity as the request principal,
+// calls OnLogin and redirects. Failures answer 400 or 502.
+func (cl *Client) Callback(c *sol.Context) {
+	st, err := cl.readState(c)
+	cl.setCookie(c, "", -1)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	if e := c.QueryParam("error"); e != "" {
+		log.Printf("[WARN] oauth: provider error %s: %s", e, c.QueryParam("error_description"))
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	ctx := c.Context()
+	tok, err := cl.Exchange(ctx, c.QueryParam("code"), st.Verifier)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.AbortWithStatus(http.StatusBadGateway)
+		return
+	}
+	id, err := cl.identity(ctx, tok, st.Nonce)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.AbortWithStatus(http.StatusBadGateway)
+		return
+	}
+
+	c.SetPrincipal(id)
+	if cl.cfg.OnLogin != nil {
+		if err := cl.cfg.OnLogin(c, id); err != nil {
+			log.Printf("[ERROR] oauth: login %s: %v", id.Subject(), err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	target := st.Return
+	if target == "" {
+		target = cl.cfg.AfterLogin
+	}
+	http.Redirect(c.Writer, c.Request, target, http.StatusFound)
+}
+
+func (cl *Client) readState(c *sol.Context) (loginState, error) {
+	var st loginState
+	raw, err := c.Cookie(cl.cfg.Cookie)
+	if err != nil {
+		return st, ErrState
+	}
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil || json.Unmarshal(b, &st) != nil {
+		return st, ErrState
+	}
+	if got := c.QueryParam("state"); got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(st.State)) != 1 {
+		return st, ErrState
+	}
+	return st, nil
+}
+
+// Exchange trades an authorization code for tokens.
+func (cl *Client) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	return cl.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cl.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh obtains a new access token with a refresh token. Providers that
+// do not rotate refresh tokens omit it; the old one stays valid then and
+// is carried over.
+func (cl *Client) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	tok, err := cl.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err == nil && tok.RefreshToken == "" {
+		tok.RefreshToken = refreshToken
+	}
+	return tok, err
+}
+
+func (cl *Client) token(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", cl.cfg.ClientID)
+	if cl.cfg.ClientSecret != "" {
+		form.Set("client_secret", cl.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cl.cfg.Provider.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oauth: token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var tok Token
+	if err := doJSON(cl.cfg.HTTPClient, req, &tok); err != nil {
+		return nil, fmt.Errorf("oauth: token: %w", err)
+	}
+	if tok.AccessToken == "" {
+		return nil, fmt.Errorf("oauth: token: response without access_token")
+	}
+	if tok.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	}
+	return &tok, nil
+}
+
+// identity reads the claims of the ID token, or of the userinfo endpoint
+// for plain OAuth2 providers.
+//
+// The ID token comes straight from the token endpoint over TLS, which
+// OIDC Core (3.1.3.7) accepts in place of checking its signature; its
+// issuer, audience, expiry and nonce are still validated.
+func (cl *Client) identity(ctx context.Context, tok *Token, nonce string) (*Identity, error) {
+	id := &Identity{Token: tok}
+
+	if tok.IDToken != "" {
+		claims, err := parseIDToken(tok.IDToken)
+		if err != nil {
+			return nil, err
+		}
+		if err := cl.checkClaims(claims, nonce); err != nil {
+			return nil, err
+		}
+		id.Claims = claims
+	}
+
+	if cl.cfg.Provider.UserInfoURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, cl.cfg.Provider.UserInfoURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("oauth: userinfo: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+
+		var info map[string]any
+		if err := doJSON(cl.cfg.HTTPClient, req, &info); err != nil {
+			return nil, fmt.Errorf("oauth: userinfo: %w", err)
+		}
+		if id.Claims == nil {
+			id.Claims = info
+		} else if info["sub"] == id.Claims["sub"] {
+			for k, v := range info {
+				if _, ok := id.Claims[k]; !ok {
+					id.Claims[k] = v
+				}
+			}
+		}
+	}
+
+	if id.Subject() == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrIDToken)
+	}
+	return id, nil
+}
+
+func (cl *Client) checkClaims(claims map[string]any, nonce string) error {
+	if iss := cl.cfg.Provider.Issuer; iss != "" && claims["iss"] != iss {
+		return fmt.Errorf("%w: issuer %v", ErrIDToken, claims["iss"])
+	}
+	if !hasAudience(claims["aud"], cl.cfg.ClientID) {
+		return fmt.Errorf("%w: audience %v", ErrIDToken, claims["aud"])
+	}
+	if exp, ok := claims["exp"].(float64); !ok || time.Now().After(time.Unix(int64(exp), 0)) {
+		return fmt.Errorf("%w: expired", ErrIDToken)
+	}
+	if claims["nonce"] != nonce {
+		return fmt.Errorf("%w: nonce mismatch", ErrIDToken)
+	}
+	return nil
+}
+
+func parseIDToken(raw string) (map[string]any, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrIDToken)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIDToken, err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(b, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIDToken, err)
+	}
+	return claims, nil
+}
+
+func hasAudience(aud any, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []any:
+		for _, a := range v {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (cl *Client) setCookie(c *sol.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     cl.cfg.Cookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   !cl.cfg.Insecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// localPath keeps only same-site relative paths, so the return parameter
+// cannot be used as an open redirect.
+func localPath(p string) string {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.HasPrefix(p, "/\\") {
+		return ""
+	}
+	return p
+}
+
+func withQuery(base string, q url.Values) string {
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + q.Encode()
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("oauth: generate state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
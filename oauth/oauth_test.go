@@ -0,0 +1,137 @@
+// Package oauth
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package oauth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+// fakeProvider issues tokens for the code "c1" and checks the PKCE verifier.
+func fakeProvider(t *testing.T) *httptest.Server {
+	var challenge, nonce string
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Provider{Issuer: srv.URL, AuthURL: srv.URL + "/auth", TokenURL: srv.URL + "/token"})
+	})
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		challenge, nonce = r.URL.Query().Get("code_challenge"), r.URL.Query().Get("nonce")
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if r.Form.Get("code") != "c1" || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			claims, _ := json.Marshal(map[string]any{
+				"iss": srv.URL, "aud": "app", "sub": "u1", "email": "u1@example.com",
+				"nonce": nonce, "exp": time.Now().Add(time.Minute).Unix(),
+			})
+			idToken := "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".sig"
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "a1", "token_type": "Bearer", "refresh_token": "r1", "id_token": idToken, "expires_in": 3600})
+		case "refresh_token":
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "a2", "token_type": "Bearer", "expires_in": 3600})
+		}
+	})
+	return srv
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	srv := fakeProvider(t)
+	defer srv.Close()
+
+	provider, err := Discover(t.Context(), srv.URL)
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	var loggedIn *Identity
+	cl := New(Config{
+		Provider:    provider,
+		ClientID:    "app",
+		RedirectURL: "https://app.example.com/callback",
+		OnLogin: func(c *sol.Context, id *Identity) error {
+			loggedIn = id
+			return nil
+		},
+	})
+
+	sl := sol.New()
+	sl.GET("/login", cl.Login)
+	sl.GET("/callback", cl.Callback)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/login?return=/dashboard", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("login status = %d", rec.Code)
+	}
+	authURL := rec.Header().Get("Location")
+	resp, err := http.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	u, _ := url.Parse(authURL)
+	state := u.Query().Get("state")
+	stateCookie := rec.Result().Cookies()[0]
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"wrong state", "code=c1&state=other", http.StatusBadRequest},
+		{"ok", "code=c1&state=" + state, http.StatusFound},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/callback?"+tt.query, nil)
+		req.AddCookie(stateCookie)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+		if tt.status == http.StatusFound && rec.Header().Get("Location") != "/dashboard" {
+			t.Errorf("%s: Location = %q", tt.name, rec.Header().Get("Location"))
+		}
+	}
+
+	if loggedIn == nil || loggedIn.Subject() != "u1" || loggedIn.Email() != "u1@example.com" {
+		t.Fatalf("identity = %+v", loggedIn)
+	}
+
+	tok, err := cl.Refresh(t.Context(), loggedIn.Token.RefreshToken)
+	if err != nil || tok.AccessToken != "a2" || tok.RefreshToken != "r1" || tok.Expired() {
+		t.Errorf("Refresh = %+v, %v", tok, err)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"/a?b=1":          "/a?b=1",
+		"":                "",
+		"//evil.com":      "",
+		"/\\evil.com":     "",
+		"https://evil.io": "",
+	} {
+		if got := localPath(in); got != want {
+			t.Errorf("localPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Package openapi
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wantnotshould/sol"
+	"github.com/wantnotshould/sol/validator"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// Info describes the API as a whole.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components,omitzero"`
+}
+
+// PathItem maps lowercase methods to the operations of one path.
+type PathItem map[string]*Operation
+
+// Components holds the schemas of named struct types, referenced from
+// operations by "#/components/schemas/{name}".
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Operation describes one route.
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the body declared with Route.Request.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes one status declared with Route.Response.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the body of a request or response in one content type.
+type MediaType struct {
+	Schema  *Schema `json:"schema"`
+	Example any     `json:"example,omitempty"`
+}
+
+// Schema is the subset of JSON Schema generated from Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Routes is the part of a sol engine Generate reads.
+type Routes interface {
+	Routes() []*sol.Route
+}
+
+// Generate describes the routes of r and the annotations set with
+// Route.Summary, Request, Response, Example and Query. Body types are
+// described by their json tags, query parameters by their form tags;
+// fields tagged validate:"required" are required. Named struct types
+// are shared through components, keyed by their Go type name.
+func Generate(r Routes, info Info) *Document {
+	g := &generator{schemas: make(map[string]*Schema)}
+	doc := &Document{OpenAPI: Version, Info: info, Paths: make(map[string]PathItem)}
+
+	for _, rt := range r.Routes() {
+		path, params := pathParams(rt.Path())
+		item := doc.Paths[path]
+		if item == nil {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(rt.Method())] = g.operation(rt, params)
+	}
+
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// Handler serves the document of r as JSON. It is generated on the first
+// request, once every route is registered:
+//
+//	sl.GET("/openapi.json", openapi.Handler(sl, openapi.Info{Title: "Shop", Version: "1.0"}))
+func Handler(r Routes, info Info) sol.HandlerFunc {
+	var once sync.Once
+	var doc *Document
+	return func(c *sol.Context) {
+		once.Do(func() { doc = Generate(r, info) })
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// pathParams turns sol's ":id" and "*path" segments into "{id}" and
+// "{path}" and returns their path parameters.
+func pathParams(path string) (string, []Parameter) {
+	var params []Parameter
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		name := seg[1:]
+		segs[i] = "{" + name + "}"
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	return strings.Join(segs, "/"), params
+}
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) operation(rt *sol.Route, params []Parameter) *Operation {
+	doc := rt.Doc()
+	op := &Operation{
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		Parameters:  append(params, g.queryParams(rt.QueryType())...),
+		Responses:   make(map[string]Response),
+	}
+
+	if doc.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: g.schema(doc.Request)}},
+		}
+	}
+
+	statuses := make(map[int]bool)
+	for status := range doc.Responses {
+		statuses[status] = true
+	}
+	for status := range doc.Examples {
+		statuses[status] = true
+	}
+	for status := range statuses {
+		res := Response{Description: http.StatusText(status)}
+		t, example := doc.Responses[status], doc.Examples[status]
+		if t == nil && example != nil {
+			t = reflect.TypeOf(example)
+		}
+		if t != nil {
+			res.Content = map[string]MediaType{"application/json": {Schema: g.schema(t), Example: example}}
+		}
+		op.Responses[strconv.Itoa(status)] = res
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["default"] = Response{Description: "Undocumented response"}
+	}
+	return op
+}
+
+// queryParams describes the fields of a Query struct by their form tags.
+func (g *generator) queryParams(t reflect.Type) []Parameter {
+	if t == nil {
+		return nil
+	}
+	var params []Parameter
+	for _, field := range fields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		params = append(params, Parameter{Name: name, In: "query", Required: required(field), Schema: g.schema(field.Type)})
+	}
+	return params
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schema describes t, registering named structs as components.
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s := &Schema{Type: "string"}
+		s.Enum, _ = validator.EnumValues(t)
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := t.Name()
+		if _, ok := g.schemas[name]; !ok {
+			// Register first, so recursive types end in a reference.
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// Interfaces and the like accept any value.
+	return &Schema{}
+}
+
+// object describes the json fields of a struct.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range fields(t) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.schema(field.Type)
+		if required(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fields returns the exported fields of struct t, flattening embedded
+// structs like encoding/json does.
+func fields(t reflect.Type) []reflect.StructField {
+	var out []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			out = append(out, fields(field.Type)...)
+			continue
+		}
+		if field.IsExported() {
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+func required(field reflect.StructField) bool {
+	for rule := range strings.SplitSeq(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Package openapi
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+	_ "github.com/wantnotshould/sol/binding"
+)
+
+type createUser struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email,omitempty"`
+}
+
+type user struct {
+	ID      int64     `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Tags    []string  `json:"tags"`
+	Manager *user     `json:"manager,omitempty"`
+	secret  string
+}
+
+type listUsers struct {
+	Page int    `form:"page" validate:"required"`
+	Sort string `form:"sort"`
+}
+
+func TestGenerate(t *testing.T) {
+	sl := sol.New()
+	sl.POST("/users", func(c *sol.Context) {}).
+		Summary("Create user").
+		Request(createUser{}).
+		Response(http.StatusCreated, user{}).
+		Response(http.StatusConflict, sol.ProblemDetails{})
+	sl.GET("/users/:id", func(c *sol.Context) {}).Response(http.StatusOK, &user{})
+	sl.GET("/users", func(c *sol.Context) {}).Query(&listUsers{})
+
+	doc := Generate(sl, Info{Title: "Users", Version: "1.0"})
+
+	create := doc.Paths["/users"]["post"]
+	if create == nil || create.Summary != "Create user" {
+		t.Fatalf("post /users = %+v", create)
+	}
+	if ref := create.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/createUser" {
+		t.Errorf("request schema ref = %q", ref)
+	}
+	body := doc.Components.Schemas["createUser"]
+	if !reflect.DeepEqual(body.Required, []string{"name"}) || body.Properties["email"].Type != "string" {
+		t.Errorf("request schema = %+v", body)
+	}
+	if ref := create.Responses["201"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/user" {
+		t.Errorf("201 schema ref = %q", ref)
+	}
+	if _, ok := create.Responses["409"]; !ok {
+		t.Errorf("responses = %v", create.Responses)
+	}
+
+	u := doc.Components.Schemas["user"]
+	if u == nil || u.Properties["created"].Format != "date-time" || u.Properties["tags"].Items.Type != "string" ||
+		u.Properties["manager"].Ref != "#/components/schemas/user" || u.Properties["secret"] != nil {
+		t.Errorf("user schema = %+v", u)
+	}
+
+	get := doc.Paths["/users/{id}"]["get"]
+	if get == nil || len(get.Parameters) != 1 || get.Parameters[0] != (Parameter{Name: "id", In: "path", Required: true, Schema: get.Parameters[0].Schema}) {
+		t.Errorf("get /users/{id} = %+v", get)
+	}
+
+	list := doc.Paths["/users"]["get"]
+	if len(list.Parameters) != 2 || list.Parameters[0].Name != "page" || !list.Parameters[0].Required || list.Parameters[1].Required {
+		t.Errorf("query parameters = %+v", list.Parameters)
+	}
+	if _, ok := list.Responses["default"]; !ok {
+		t.Errorf("undocumented route responses = %v", list.Responses)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	sl := sol.New()
+	sl.GET("/openapi.json", Handler(sl, Info{Title: "Users", Version: "1.0"}))
+	sl.GET("/ping", func(c *sol.Context) {}).Response(http.StatusOK, nil)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var doc Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != Version || doc.Info.Title != "Users" || doc.Paths["/ping"]["get"].Responses["200"].Description != "OK" {
+		t.Errorf("doc = %+v", doc)
+	}
+}
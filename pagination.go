@@ -0,0 +1,135 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageDefaults configures Paginate.
+type PageDefaults struct {
+	// PerPage is used without a per_page parameter, 20 by default.
+	PerPage int
+	// MaxPerPage caps per_page, 100 by default.
+	MaxPerPage int
+}
+
+// Page is the page of a list requested with page and per_page, or with
+// cursor, query parameters.
+type Page struct {
+	// Number is the 1-based page number; it stays 1 with a cursor.
+	Number  int
+	PerPage int
+	// Cursor is the opaque position to continue from, "" for the start.
+	Cursor string
+	// Next is the cursor of the following page, set by the handler for
+	// cursor pagination; "" marks the last page.
+	Next string
+}
+
+// Offset returns the number of items before the page.
+func (p Page) Offset() int {
+	return (p.Number - 1) * p.PerPage
+}
+
+// Paginate reads the page of a list request. Missing or invalid values
+// fall back to the first page and the default size, and per_page is
+// capped, so handlers can use the result as is.
+func Paginate(c *Context, defaults PageDefaults) Page {
+	if defaults.PerPage <= 0 {
+		defaults.PerPage = 20
+	}
+	if defaults.MaxPerPage <= 0 {
+		defaults.MaxPerPage = 100
+	}
+
+	p := Page{Number: 1, PerPage: min(defaults.PerPage, defaults.MaxPerPage), Cursor: c.QueryParam("cursor")}
+	if n, err := strconv.Atoi(c.QueryParam("per_page")); err == nil && n > 0 {
+		p.PerPage = min(n, defaults.MaxPerPage)
+	}
+	if n, err := strconv.Atoi(c.QueryParam("page")); err == nil && n > 0 && p.Cursor == "" {
+		p.Number = n
+	}
+	return p
+}
+
+// PageInfo is the "page" member of the JSONPage envelope.
+type PageInfo struct {
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	Total      int    `json:"total,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageEnvelope is the body written by JSONPage.
+type PageEnvelope struct {
+	Data any      `json:"data"`
+	Page PageInfo `json:"page"`
+}
+
+// JSONPage writes items as one page of a list:
+//
+//	{"data": [...], "page": {"page": 2, "per_page": 20, "total": 95, "total_pages": 5}}
+//
+// with an RFC 8288 Link header to the first, previous, next and last
+// pages and X-Total-Count. For cursor pagination, pass a negative total
+// and set page.Next; the envelope then carries next_cursor and the Link
+// header only the next page.
+func (c *Context) JSONPage(status int, items any, total int, page Page) {
+	info := PageInfo{PerPage: page.PerPage}
+	var links []string
+	link := func(rel string, set map[string]string) {
+		links = append(links, "<"+c.pageURL(set)+`>; rel="`+rel+`"`)
+	}
+
+	if total < 0 {
+		info.NextCursor = page.Next
+		if page.Next != "" {
+			link("next", map[string]string{"cursor": page.Next, "page": ""})
+		}
+	} else {
+		info.Page, info.Total = page.Number, total
+		info.TotalPages = (total + page.PerPage - 1) / page.PerPage
+		c.SetHeader("X-Total-Count", strconv.Itoa(total))
+
+		pageLink := func(rel string, n int) {
+			link(rel, map[string]string{"page": strconv.Itoa(n), "cursor": ""})
+		}
+		pageLink("first", 1)
+		if page.Number > 1 {
+			pageLink("prev", min(page.Number-1, max(info.TotalPages, 1)))
+		}
+		if page.Number < info.TotalPages {
+			pageLink("next", page.Number+1)
+		}
+		pageLink("last", max(info.TotalPages, 1))
+	}
+
+	if len(links) > 0 {
+		c.SetHeader("Link", strings.Join(links, ", "))
+	}
+	c.JSON(status, PageEnvelope{Data: items, Page: info})
+}
+
+// pageURL returns the request path and query with the parameters in set
+// replaced, or removed when empty.
+func (c *Context) pageURL(set map[string]string) string {
+	q := url.Values{}
+	for k, v := range c.QueryAll() {
+		q[k] = v
+	}
+	for k, v := range set {
+		if v == "" {
+			q.Del(k)
+		} else {
+			q.Set(k, v)
+		}
+	}
+	u := url.URL{Path: c.Request.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
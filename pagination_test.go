@@ -0,0 +1,75 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Page
+	}{
+		{"", Page{Number: 1, PerPage: 20}},
+		{"page=3&per_page=10", Page{Number: 3, PerPage: 10}},
+		{"page=-1&per_page=abc", Page{Number: 1, PerPage: 20}},
+		{"per_page=1000", Page{Number: 1, PerPage: 50}},
+		{"cursor=abc&page=4", Page{Number: 1, PerPage: 20, Cursor: "abc"}},
+	}
+	for _, tt := range tests {
+		c := &Context{Request: httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)}
+		if got := Paginate(c, PageDefaults{MaxPerPage: 50}); got != tt.want {
+			t.Errorf("Paginate(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestContext_JSONPage(t *testing.T) {
+	sl := New()
+	sl.GET("/items", func(c *Context) {
+		p := Paginate(c, PageDefaults{PerPage: 10})
+		c.JSONPage(http.StatusOK, []int{p.Offset() + 1}, 95, p)
+	})
+	sl.GET("/events", func(c *Context) {
+		p := Paginate(c, PageDefaults{})
+		p.Next = "evt_42"
+		c.JSONPage(http.StatusOK, []string{"evt_41"}, -1, p)
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items?page=2&sort=name", nil))
+
+	var env struct {
+		Data []int    `json:"data"`
+		Page PageInfo `json:"page"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data[0] != 11 || env.Page != (PageInfo{Page: 2, PerPage: 10, Total: 95, TotalPages: 10}) {
+		t.Errorf("envelope = %+v", env)
+	}
+	wantLink := `</items?page=1&sort=name>; rel="first", </items?page=1&sort=name>; rel="prev", ` +
+		`</items?page=3&sort=name>; rel="next", </items?page=10&sort=name>; rel="last"`
+	if got := rec.Header().Get("Link"); got != wantLink {
+		t.Errorf("Link = %s\nwant   %s", got, wantLink)
+	}
+	if rec.Header().Get("X-Total-Count") != "95" {
+		t.Errorf("X-Total-Count = %q", rec.Header().Get("X-Total-Count"))
+	}
+
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events?cursor=evt_40", nil))
+	if got := rec.Header().Get("Link"); got != `</events?cursor=evt_42>; rel="next"` {
+		t.Errorf("cursor Link = %s", got)
+	}
+	if want := `{"data":["evt_41"],"page":{"per_page":20,"next_cursor":"evt_42"}}` + "\n"; rec.Body.String() != want {
+		t.Errorf("cursor body = %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,183 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// maxPanicFrames caps the frames recorded for a PanicError.
+const maxPanicFrames = 64
+
+// snippetLines is the number of source lines shown around the panic line.
+const snippetLines = 5
+
+// StackFrame is a frame of a panicking goroutine.
+type StackFrame struct {
+	Function string
+	File     string
+	Line     int
+	// Framework is set for frames of sol, net/http and the runtime.
+	Framework bool
+}
+
+// callers records the stack of a recovered panic, called from the
+// deferred recover function.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxPanicFrames)
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+// Frames returns the stack of the panic, innermost first, starting at
+// the frame that panicked.
+func (e *PanicError) Frames() []StackFrame {
+	if len(e.pcs) == 0 {
+		return nil
+	}
+	var frames []StackFrame
+	seenPanic := false
+	it := runtime.CallersFrames(e.pcs)
+	for {
+		f, more := it.Next()
+		if seenPanic {
+			frames = append(frames, StackFrame{
+				Function:  f.Function,
+				File:      f.File,
+				Line:      f.Line,
+				Framework: isFrameworkFrame(f),
+			})
+		} else if f.Function == "runtime.gopanic" || f.Function == "runtime.panicmem" {
+			seenPanic = true
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// solDir is the source directory of sol, slash separated like frame
+// files, telling its frames apart from those of the application.
+var solDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file) + "/"
+}()
+
+func isFrameworkFrame(f runtime.Frame) bool {
+	if strings.HasPrefix(f.File, solDir) {
+		return !strings.HasSuffix(f.File, "_test.go")
+	}
+	return strings.HasPrefix(f.Function, "runtime.") ||
+		strings.HasPrefix(f.Function, "net/http.") ||
+		strings.HasPrefix(f.Function, "testing.")
+}
+
+// frameGroup is a run of application frames, or of framework frames
+// collapsed on the debug page.
+type frameGroup struct {
+	Framework bool
+	Frames    []StackFrame
+}
+
+// sourceLine is a line of the snippet shown around the panic.
+type sourceLine struct {
+	Number  int
+	Text    string
+	Current bool
+}
+
+type panicPageData struct {
+	Err       *PanicError
+	Method    string
+	Path      string
+	Location  string
+	Snippet   []sourceLine
+	Groups    []frameGroup
+	RequestID string
+}
+
+var panicPage = template.Must(template.New("panic").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>panic: {{.Err.Value}}</title>
+<style>
+body{font-family:sans-serif;margin:2em}pre{background:#f6f6f6;padding:1em;overflow:auto}
+.cur{background:#fdd}.fw{color:#888}td{padding:0 .5em;font-family:monospace}
+</style></head>
+<body>
+<h1>panic: {{.Err.Value}}</h1>
+<p>{{.Method}} {{.Path}} · handler {{.Err.Handler}} · request {{.RequestID}}</p>
+{{if .Snippet}}<h2>{{.Location}}</h2>
+<pre>{{range .Snippet}}<span{{if .Current}} class="cur"{{end}}>{{printf "%4d" .Number}}  {{.Text}}</span>
+{{end}}</pre>{{end}}
+<h2>Stack</h2>
+{{range .Groups}}{{if .Framework}}<details class="fw"><summary>{{len .Frames}} framework frames</summary>{{end}}
+<table>{{range .Frames}}<tr><td>{{.Function}}</td><td>{{.File}}:{{.Line}}</td></tr>{{end}}</table>
+{{if .Framework}}</details>{{end}}{{end}}
+</body>
+</html>
+`))
+
+// renderPanicPage answers a browser in debug mode with the details of a
+// recovered panic.
+func renderPanicPage(c *Context, e *PanicError) {
+	data := panicPageData{
+		Err:       e,
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		RequestID: e.RequestID,
+	}
+
+	frames := e.Frames()
+	for _, f := range frames {
+		if !f.Framework {
+			data.Location = fmt.Sprintf("%s:%d", f.File, f.Line)
+			data.Snippet = sourceSnippet(f.File, f.Line)
+			break
+		}
+	}
+	for _, f := range frames {
+		if n := len(data.Groups); n > 0 && data.Groups[n-1].Framework == f.Framework {
+			data.Groups[n-1].Frames = append(data.Groups[n-1].Frames, f)
+			continue
+		}
+		data.Groups = append(data.Groups, frameGroup{Framework: f.Framework, Frames: []StackFrame{f}})
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := panicPage.Execute(buf, data); err != nil {
+		log.Printf("[ERROR] panic page: %v", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	c.Writer.WriteHeader(http.StatusInternalServerError)
+	c.Writer.Write(buf.Bytes())
+}
+
+// sourceSnippet returns the lines of file around line, or nil if the
+// source is not available, e.g. on a machine other than the build host.
+func sourceSnippet(file string, line int) []sourceLine {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	lines := bytes.Split(src, []byte("\n"))
+	first, last := max(line-snippetLines, 1), min(line+snippetLines, len(lines))
+
+	snippet := make([]sourceLine, 0, last-first+1)
+	for n := first; n <= last; n++ {
+		snippet = append(snippet, sourceLine{Number: n, Text: string(lines[n-1]), Current: n == line})
+	}
+	return snippet
+}
@@ -0,0 +1,27 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+// Principal is the authenticated identity of a request.
+type Principal interface {
+	// Subject returns the unique identifier of the principal, e.g. a user ID.
+	Subject() string
+}
+
+// RoleHolder is implemented by principals that carry roles.
+type RoleHolder interface {
+	Roles() []string
+}
+
+// SetPrincipal attaches the authenticated principal to the request.
+// It is normally called by auth middleware.
+func (c *Context) SetPrincipal(p Principal) {
+	c.principal = p
+}
+
+// Principal returns the authenticated principal, or nil if there is none.
+func (c *Context) Principal() Principal {
+	return c.principal
+}
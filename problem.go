@@ -0,0 +1,142 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"maps"
+	"net/http"
+
+	"github.com/wantnotshould/sol/validator"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 9457 problem. It is an error, so handlers can
+// report it with Context.Error.
+type ProblemDetails struct {
+	// Type is a URI identifying the problem type, "about:blank" if empty.
+	Type   string
+	Status int
+	Title  string
+	Detail string
+	// Instance is a URI identifying this occurrence, e.g. the request path.
+	Instance string
+	// Extensions are additional members, e.g. "errors" or "balance".
+	Extensions map[string]any
+}
+
+func (p *ProblemDetails) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// StatusCode implements StatusCoder.
+func (p *ProblemDetails) StatusCode() int {
+	return p.status()
+}
+
+// status returns Status, or 500 if it is unset.
+func (p *ProblemDetails) status() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return p.Status
+}
+
+// MarshalJSON flattens the extensions into the problem object. They
+// cannot override the standard members.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(m, p.Extensions)
+	m["type"] = p.Type
+	if p.Type == "" {
+		m["type"] = "about:blank"
+	}
+	m["status"] = p.status()
+	m["title"] = p.Title
+	if p.Title == "" {
+		m["title"] = http.StatusText(p.status())
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	} else {
+		delete(m, "detail")
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	} else {
+		delete(m, "instance")
+	}
+	return json.Marshal(m)
+}
+
+// Problem writes an RFC 9457 problem details response and aborts the
+// chain. An empty title defaults to the status text; extensions are
+// merged into the problem object.
+func (c *Context) Problem(status int, typ, title, detail string, extensions ...map[string]any) {
+	p := &ProblemDetails{Type: typ, Status: status, Title: title, Detail: detail}
+	for _, ext := range extensions {
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any)
+		}
+		maps.Copy(p.Extensions, ext)
+	}
+	c.writeProblem(p)
+}
+
+func (c *Context) writeProblem(p *ProblemDetails) {
+	c.Abort()
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := json.NewEncoder(buf).Encode(p); err != nil {
+		log.Printf("[ERROR] problem encode: %v", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Writer.Header().Set("Content-Type", ProblemContentType)
+	c.Writer.WriteHeader(p.status())
+	c.Writer.Write(buf.Bytes())
+}
+
+// ProblemErrorHandler is an ErrorHandler answering every error with
+// problem details, for API groups:
+//
+//	api := sl.Group("/api")
+//	api.WithErrorHandler(sol.ProblemErrorHandler)
+//
+// A *ProblemDetails is written as is. Other errors get the status of a
+// StatusCoder, or 500; client errors carry the error message as detail
+// and validation errors their failing fields under "errors", while the
+// message of server errors is withheld.
+func ProblemErrorHandler(c *Context, err error) {
+	var p *ProblemDetails
+	if errors.As(err, &p) {
+		c.writeProblem(p)
+		return
+	}
+
+	status := errorStatus(err)
+	p = &ProblemDetails{Status: status, Instance: c.Request.URL.Path}
+	if status < http.StatusInternalServerError {
+		p.Detail = err.Error()
+	}
+
+	var ferrs validator.FieldErrors
+	var verrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &ferrs):
+		p.Extensions = map[string]any{"errors": ferrs}
+	case errors.As(err, &verrs):
+		p.Extensions = map[string]any{"errors": verrs}
+	}
+	c.writeProblem(p)
+}
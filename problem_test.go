@@ -0,0 +1,106 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestContext_Problem(t *testing.T) {
+	sl := New()
+	sl.POST("/transfer", func(c *Context) {
+		c.Problem(http.StatusForbidden, "https://example.com/probs/out-of-credit", "You do not have enough credit.",
+			"Your current balance is 30, but that costs 50.", map[string]any{"balance": 30, "status": 999})
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/transfer", nil))
+
+	if rec.Code != http.StatusForbidden || rec.Header().Get("Content-Type") != ProblemContentType {
+		t.Fatalf("%d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var got map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &got)
+	want := map[string]any{
+		"type":    "https://example.com/probs/out-of-credit",
+		"status":  float64(403),
+		"title":   "You do not have enough credit.",
+		"detail":  "Your current balance is 30, but that costs 50.",
+		"balance": float64(30),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problem = %v\nwant      %v", got, want)
+	}
+}
+
+func TestProblemErrorHandler(t *testing.T) {
+	sl := New()
+	api := sl.Group("/api").WithErrorHandler(ProblemErrorHandler)
+	fail := func(err error) HandlerFunc { return func(c *Context) { c.Error(err) } }
+	api.GET("/v1/missing", fail(statusErr(http.StatusNotFound)))
+	api.GET("/v1/broken", fail(errors.New("db password rejected")))
+	sl.GET("/page", fail(statusErr(http.StatusNotFound)))
+
+	tests := []struct {
+		path, accept string
+		ctype        string
+		want         map[string]any
+	}{
+		{"/api/v1/missing", "", ProblemContentType, map[string]any{
+			"type": "about:blank", "status": float64(404), "title": "Not Found", "detail": "Not Found", "instance": "/api/v1/missing",
+		}},
+		{"/api/v1/broken", "", ProblemContentType, map[string]any{
+			"type": "about:blank", "status": float64(500), "title": "Internal Server Error", "instance": "/api/v1/broken",
+		}},
+		// Outside the group, only clients asking for problems get them.
+		{"/page", "", "text/plain; charset=utf-8", nil},
+		{"/page", ProblemContentType, ProblemContentType, map[string]any{
+			"type": "about:blank", "status": float64(404), "title": "Not Found", "detail": "Not Found", "instance": "/page",
+		}},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+
+		if ct := rec.Header().Get("Content-Type"); ct != tt.ctype {
+			t.Errorf("%s: Content-Type %q, want %q", tt.path, ct, tt.ctype)
+			continue
+		}
+		if tt.want == nil {
+			continue
+		}
+		var got map[string]any
+		json.Unmarshal(rec.Body.Bytes(), &got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: problem = %v\nwant %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestProblemDetails_ZeroStatus(t *testing.T) {
+	sl := New()
+	sl.GET("/default", func(c *Context) { c.Error(&ProblemDetails{Title: "x"}) })
+	api := sl.Group("/api").WithErrorHandler(ProblemErrorHandler)
+	api.GET("/problem", func(c *Context) { c.Error(&ProblemDetails{Title: "x"}) })
+
+	for _, path := range []string{"/default", "/api/problem"} {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var got map[string]any
+		json.Unmarshal(rec.Body.Bytes(), &got)
+		if rec.Code != http.StatusInternalServerError || got["status"] != float64(500) || got["title"] != "x" {
+			t.Errorf("%s: %d %v", path, rec.Code, got)
+		}
+	}
+}
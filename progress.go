@@ -0,0 +1,184 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of a long-running job, sent by StreamProgress.
+type Progress struct {
+	Done    int64  `json:"done"`
+	Total   int64  `json:"total,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ProgressJob does the work behind StreamProgress. It calls report as it
+// advances and stops early once ctx is done.
+type ProgressJob func(ctx context.Context, report func(Progress)) (any, error)
+
+// DefaultProgressInterval is the frame interval StreamProgress uses when
+// given a non-positive one.
+var DefaultProgressInterval = 500 * time.Millisecond
+
+// StreamProgress runs job in the background and streams its progress so
+// browsers can draw a progress bar without WebSockets. Clients accepting
+// text/event-stream get SSE "progress" events followed by a "done" or
+// "error" event; others get JSON lines of the form {"progress":…},
+// then {"result":…} or {"error":"…"}.
+//
+// At most one frame is sent per interval, carrying the latest report.
+// The job receives the request context, so it is cancelled when the client
+// goes away; StreamProgress returns only once the job has. Endpoints
+// running longer than the server write timeout should lift it with
+// WriteTimeout(0).
+func (c *Context) StreamProgress(interval time.Duration, job ProgressJob) {
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+
+	defer c.TrackStream()()
+
+	pw := newProgressWriter(c)
+	h := c.Writer.Header()
+	h.Set("Content-Type", pw.contentType())
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	c.Writer.WriteHeader(http.StatusOK)
+	pw.flush()
+
+	var (
+		mu      sync.Mutex
+		latest  Progress
+		pending bool
+	)
+	report := func(p Progress) {
+		mu.Lock()
+		latest, pending = p, true
+		mu.Unlock()
+	}
+
+	type outcome struct {
+		result any
+		err    error
+	}
+	done := make(chan outcome, 1)
+	ctx := c.Context()
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- outcome{err: fmt.Errorf("progress job panicked: %v", rec)}
+			}
+		}()
+		result, err := job(ctx, report)
+		done <- outcome{result, err}
+	}()
+
+	emit := func() {
+		mu.Lock()
+		p, ok := latest, pending
+		pending = false
+		mu.Unlock()
+		if ok {
+			pw.progress(p)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			emit()
+		case out := <-done:
+			if ctx.Err() != nil {
+				return
+			}
+			emit()
+			if out.err != nil {
+				pw.fail(out.err)
+			} else {
+				pw.result(out.result)
+			}
+			return
+		}
+	}
+}
+
+// progressWriter frames progress events as SSE or JSON lines.
+type progressWriter struct {
+	c   *Context
+	rc  *http.ResponseController
+	sse bool
+}
+
+func newProgressWriter(c *Context) *progressWriter {
+	return &progressWriter{
+		c:   c,
+		rc:  http.NewResponseController(c.Writer),
+		sse: strings.Contains(c.Header("Accept"), "text/event-stream"),
+	}
+}
+
+func (pw *progressWriter) contentType() string {
+	if pw.sse {
+		return "text/event-stream; charset=utf-8"
+	}
+	return "application/x-ndjson; charset=utf-8"
+}
+
+func (pw *progressWriter) progress(p Progress) {
+	pw.frame("progress", p)
+}
+
+func (pw *progressWriter) result(v any) {
+	pw.frame("done", v)
+}
+
+func (pw *progressWriter) fail(err error) {
+	pw.frame("error", err.Error())
+}
+
+func (pw *progressWriter) frame(event string, v any) {
+	var b []byte
+	var err error
+	if pw.sse {
+		if b, err = json.Marshal(v); err == nil {
+			b = fmt.Appendf(nil, "event: %s\ndata: %s\n\n", event, b)
+		}
+	} else {
+		key := event
+		if event == "done" {
+			key = "result"
+		}
+		if b, err = json.Marshal(map[string]any{key: v}); err == nil {
+			b = append(b, '\n')
+		}
+	}
+	if err != nil {
+		log.Printf("[ERROR] progress stream: %v", err)
+		return
+	}
+
+	if _, err := pw.c.Writer.Write(b); err != nil {
+		return
+	}
+	pw.flush()
+}
+
+func (pw *progressWriter) flush() {
+	if err := pw.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("[WARN] progress stream flush: %v", err)
+	}
+}
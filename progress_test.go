@@ -0,0 +1,67 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContext_StreamProgress(t *testing.T) {
+	sl := New()
+	sl.POST("/import", func(c *Context) {
+		fail := c.QueryParam("fail") != ""
+		c.StreamProgress(time.Millisecond, func(ctx context.Context, report func(Progress)) (any, error) {
+			for i := int64(1); i <= 3; i++ {
+				report(Progress{Done: i, Total: 3})
+				time.Sleep(5 * time.Millisecond)
+			}
+			if fail {
+				return nil, errors.New("bad row 3")
+			}
+			return map[string]int{"rows": 3}, nil
+		})
+	})
+
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		ctype  string
+		first  string
+		last   string
+	}{
+		{"ndjson", "/import", "", "application/x-ndjson", `{"progress":{"done":1,"total":3}}`, `{"result":{"rows":3}}`},
+		{"sse", "/import", "text/event-stream", "text/event-stream", "event: progress\ndata: {\"done\":1,\"total\":3}", "event: done\ndata: {\"rows\":3}"},
+		{"error", "/import?fail=1", "", "application/x-ndjson", `{"progress":{"done":1,"total":3}}`, `{"error":"bad row 3"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.ctype) {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.ctype)
+			}
+			sep := "\n"
+			if tt.accept != "" {
+				sep = "\n\n"
+			}
+			frames := strings.Split(strings.TrimSuffix(rec.Body.String(), sep), sep)
+			if frames[0] != tt.first || frames[len(frames)-1] != tt.last {
+				t.Errorf("frames = %q", frames)
+			}
+		})
+	}
+}
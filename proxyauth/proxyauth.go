@@ -0,0 +1,136 @@
+// Package proxyauth
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package proxyauth
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+// User is the identity asserted by the proxy. It is a sol.Principal and a
+// sol.RoleHolder, so authz works with it as with any other auth.
+type User struct {
+	ID     string
+	Email  string
+	Name   string
+	Groups []string
+}
+
+func (u *User) Subject() string { return u.ID }
+func (u *User) Roles() []string { return u.Groups }
+
+// Config configures the middleware. Empty header names disable the field.
+type Config struct {
+	// TrustedProxies are the ranges of the proxies allowed to assert
+	// identities, e.g. netip.MustParsePrefix("10.0.0.0/8"); a single IP is
+	// a full-length prefix such as "192.0.2.1/32". Required.
+	TrustedProxies []netip.Prefix
+	// UserHeader holds the user ID, "X-Auth-Request-User" by default.
+	UserHeader string
+	// EmailHeader, "X-Auth-Request-Email" by default.
+	EmailHeader string
+	// NameHeader, "X-Auth-Request-Preferred-Username" by default.
+	NameHeader string
+	// GroupsHeader holds comma separated groups, "X-Auth-Request-Groups"
+	// by default.
+	GroupsHeader string
+	// Required answers 401 to requests without an asserted identity.
+	Required bool
+	// Principal maps the user to the application principal, e.g. after a
+	// database lookup. The User itself is used by default.
+	Principal func(c *sol.Context, u *User) (sol.Principal, error)
+}
+
+// Middleware sets the principal from identity headers added by an
+// authenticating reverse proxy such as oauth2-proxy. The headers are only
+// believed when the direct peer is a trusted proxy; from anyone else they
+// are removed, so later handlers cannot be fooled by them either.
+func Middleware(cfg Config) sol.HandlerFunc {
+	if len(cfg.TrustedProxies) == 0 {
+		panic("proxyauth: TrustedProxies is required")
+	}
+	trusted := cfg.TrustedProxies
+
+	if cfg.UserHeader == "" {
+		cfg.UserHeader = "X-Auth-Request-User"
+	}
+	if cfg.EmailHeader == "" {
+		cfg.EmailHeader = "X-Auth-Request-Email"
+	}
+	if cfg.NameHeader == "" {
+		cfg.NameHeader = "X-Auth-Request-Preferred-Username"
+	}
+	if cfg.GroupsHeader == "" {
+		cfg.GroupsHeader = "X-Auth-Request-Groups"
+	}
+	headers := []string{cfg.UserHeader, cfg.EmailHeader, cfg.NameHeader, cfg.GroupsHeader}
+
+	return func(c *sol.Context) {
+		if !fromTrusted(c.Request, trusted) {
+			for _, h := range headers {
+				c.Request.Header.Del(h)
+			}
+		} else if id := strings.TrimSpace(c.Header(cfg.UserHeader)); id != "" {
+			u := &User{
+				ID:     id,
+				Email:  strings.TrimSpace(c.Header(cfg.EmailHeader)),
+				Name:   strings.TrimSpace(c.Header(cfg.NameHeader)),
+				Groups: splitList(c.Header(cfg.GroupsHeader)),
+			}
+
+			var p sol.Principal = u
+			if cfg.Principal != nil {
+				var err error
+				if p, err = cfg.Principal(c, u); err != nil {
+					c.String(http.StatusForbidden, "Forbidden")
+					c.Abort()
+					return
+				}
+			}
+			c.SetPrincipal(p)
+		}
+
+		if cfg.Required && c.Principal() == nil {
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// fromTrusted reports whether the direct peer of r is a trusted proxy.
+// Forwarding headers are ignored: they are what is being verified.
+func fromTrusted(r *http.Request, trusted []netip.Prefix) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(v string) []string {
+	var out []string
+	for s := range strings.SplitSeq(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
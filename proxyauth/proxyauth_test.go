@@ -0,0 +1,70 @@
+// Package proxyauth
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package proxyauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestMiddleware(t *testing.T) {
+	sl := sol.New()
+	sl.Use(Middleware(Config{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}}))
+	sl.GET("/me", func(c *sol.Context) {
+		p := c.Principal()
+		if p == nil {
+			c.String(http.StatusOK, "anonymous %s", c.Header("X-Auth-Request-User"))
+			return
+		}
+		c.String(http.StatusOK, "%s %s", p.Subject(), strings.Join(p.(sol.RoleHolder).Roles(), "+"))
+	})
+
+	tests := []struct {
+		name   string
+		remote string
+		user   string
+		want   string
+	}{
+		{"trusted", "10.1.2.3:5000", "alice", "alice admin+dev"},
+		{"trusted ipv6", "[::1]:5000", "alice", "alice admin+dev"},
+		{"untrusted peer", "203.0.113.9:5000", "alice", "anonymous "},
+		{"no identity", "10.1.2.3:5000", "", "anonymous "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			req.RemoteAddr = tt.remote
+			// Forwarding headers must not make an untrusted peer trusted.
+			req.Header.Set("X-Forwarded-For", "10.0.0.1")
+			if tt.user != "" {
+				req.Header.Set("X-Auth-Request-User", tt.user)
+				req.Header.Set("X-Auth-Request-Groups", "admin, dev")
+			}
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRequired(t *testing.T) {
+	sl := sol.New()
+	sl.Use(Middleware(Config{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("192.0.2.1/32")}, Required: true}))
+	sl.GET("/", func(c *sol.Context) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,92 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// QueryBinder binds the query string of a request into a struct pointer
+// for Route.Query. The binding package installs binding.Query when
+// imported; set it to use another decoder.
+var QueryBinder func(c *Context, obj any) error
+
+// queryKey is the Context key of the struct bound by Route.Query.
+const queryKey = "sol.query"
+
+// BindError is reported through Context.Error when Route.Query cannot
+// bind or validate the query string. It answers with 400.
+type BindError struct {
+	Err error
+}
+
+func (e *BindError) Error() string {
+	return "bind query: " + e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements StatusCoder.
+func (e *BindError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Query declares the query parameters of the route as a struct:
+//
+//	sl.GET("/users", listUsers).Query(&ListUsersQuery{})
+//
+// Before the handlers run, each request's query string is bound into a new
+// value of that type and validated with Context.Validate. A failure is
+// reported as a *BindError, answered with 400 by the default error
+// handler. Handlers read the value with BoundQuery.
+func (rt *Route) Query(obj any) *Route {
+	t := reflect.TypeOf(obj)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("cannot register '%s %s': Query needs a struct pointer, got %T", rt.method, rt.path, obj))
+	}
+	if QueryBinder == nil {
+		panic(fmt.Sprintf("cannot register '%s %s': no QueryBinder, import github.com/wantnotshould/sol/binding", rt.method, rt.path))
+	}
+
+	rt.query = t.Elem()
+	rt.middlewares = append(rt.middlewares, bindQuery(rt.query))
+	rt.router.recompose(rt)
+	return rt
+}
+
+// QueryType returns the struct type declared with Query, or nil. It lets
+// documentation generators describe the route's parameters.
+func (rt *Route) QueryType() reflect.Type {
+	return rt.query
+}
+
+func bindQuery(t reflect.Type) HandlerFunc {
+	return func(c *Context) {
+		obj := reflect.New(t).Interface()
+		err := QueryBinder(c, obj)
+		if err == nil {
+			err = c.Validate(obj)
+		}
+		if err != nil {
+			c.Error(&BindError{Err: err})
+			return
+		}
+
+		c.Set(queryKey, obj)
+		c.Next()
+	}
+}
+
+// BoundQuery returns the query struct bound by Route.Query, or nil if the
+// route declared none of type T.
+func BoundQuery[T any](c *Context) *T {
+	v, _ := c.Get(queryKey)
+	obj, _ := v.(*T)
+	return obj
+}
@@ -0,0 +1,141 @@
+// Package ratelimit
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+// Limit allows Requests per Window. The zero Limit is unlimited.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// PerSecond, PerMinute and PerHour build common limits.
+func PerSecond(n int) Limit { return Limit{Requests: n, Window: time.Second} }
+func PerMinute(n int) Limit { return Limit{Requests: n, Window: time.Minute} }
+func PerHour(n int) Limit   { return Limit{Requests: n, Window: time.Hour} }
+
+func (l Limit) unlimited() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+// KeyFunc identifies the client a request counts against.
+// Returning "" exempts the request.
+type KeyFunc func(c *sol.Context) string
+
+// ByIP keys requests by client IP. Forwarding headers are honoured only
+// once sol.SetTrustedProxies is configured; until then the peer address
+// is used, so clients cannot dodge the limit by spoofing X-Forwarded-For.
+func ByIP(c *sol.Context) string {
+	return "ip:" + sol.TrustedClientIP(c.Request)
+}
+
+// ByPrincipal keys requests by the authenticated principal, falling back
+// to the client IP for anonymous requests. Auth middleware must run first.
+func ByPrincipal(c *sol.Context) string {
+	if p := c.Principal(); p != nil {
+		return "principal:" + p.Subject()
+	}
+	return ByIP(c)
+}
+
+// Config configures the rate limit middleware.
+type Config struct {
+	// Key identifies the client, ByIP by default.
+	Key KeyFunc
+	// Limit applies to every key unless Tier is set.
+	Limit Limit
+	// Tier resolves the limit per request, e.g. from the principal's plan,
+	// for tiered API quotas. A zero Limit means unlimited.
+	Tier func(c *sol.Context) Limit
+}
+
+// Middleware enforces fixed window limits per key. Every response carries
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (seconds
+// until the window resets); rejected requests get 429 with Retry-After.
+func Middleware(cfg Config) sol.HandlerFunc {
+	if cfg.Key == nil {
+		cfg.Key = ByIP
+	}
+	s := newStore()
+
+	return func(c *sol.Context) {
+		limit := cfg.Limit
+		if cfg.Tier != nil {
+			limit = cfg.Tier(c)
+		}
+		key := cfg.Key(c)
+		if limit.unlimited() || key == "" {
+			c.Next()
+			return
+		}
+
+		count, reset := s.hit(key, limit.Window, time.Now())
+		remaining := max(limit.Requests-count, 0)
+		resetSecs := strconv.Itoa(int((reset + time.Second - 1) / time.Second))
+
+		h := c.Writer.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("X-RateLimit-Reset", resetSecs)
+
+		if count > limit.Requests {
+			h.Set("Retry-After", resetSecs)
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
+
+// window counts the hits of a key in the current window.
+type window struct {
+	start time.Time
+	size  time.Duration
+	count int
+}
+
+// store holds the windows of all keys, sweeping expired ones now and then
+// so keys of departed clients do not pile up.
+type store struct {
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+func newStore() *store {
+	return &store{windows: make(map[string]*window), lastSweep: time.Now()}
+}
+
+// hit records a request for key and returns the hits in the current
+// window, this one included, and the time until the window resets.
+func (s *store) hit(key string, size time.Duration, now time.Time) (int, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= time.Minute {
+		for k, w := range s.windows {
+			if now.Sub(w.start) >= w.size {
+				delete(s.windows, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	w := s.windows[key]
+	if w == nil || w.size != size || now.Sub(w.start) >= size {
+		w = &window{start: now, size: size}
+		s.windows[key] = w
+	}
+	w.count++
+	return w.count, w.start.Add(size).Sub(now)
+}
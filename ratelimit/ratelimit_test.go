@@ -0,0 +1,128 @@
+// Package ratelimit
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+type user struct {
+	id   string
+	plan string
+}
+
+func (u user) Subject() string { return u.id }
+
+func TestMiddleware_tiers(t *testing.T) {
+	auth := func(c *sol.Context) {
+		if id := c.Header("X-User"); id != "" {
+			c.SetPrincipal(user{id: id, plan: c.Header("X-Plan")})
+		}
+		c.Next()
+	}
+	limit := Middleware(Config{
+		Key: ByPrincipal,
+		Tier: func(c *sol.Context) Limit {
+			if u, ok := c.Principal().(user); ok && u.plan == "pro" {
+				return PerMinute(3)
+			}
+			return PerMinute(1)
+		},
+	})
+
+	sl := sol.New()
+	sl.GET("/api", auth, limit, func(c *sol.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	do := func(id, plan string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("X-User", id)
+		req.Header.Set("X-Plan", plan)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if rec := do("alice", "pro"); rec.Code != want {
+			t.Errorf("pro request %d: status = %d, want %d", i+1, rec.Code, want)
+		}
+	}
+
+	rec := do("bob", "free")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("free request: status = %d", rec.Code)
+	}
+	if got := rec.Header().Get("X-RateLimit-Limit"); got != "1" {
+		t.Errorf("X-RateLimit-Limit = %q, want 1", got)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+
+	rec = do("bob", "free")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("second free request: status = %d, Retry-After = %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestStore_windowReset(t *testing.T) {
+	s := newStore()
+	now := time.Now()
+
+	s.hit("k", time.Second, now)
+	if n, _ := s.hit("k", time.Second, now.Add(500*time.Millisecond)); n != 2 {
+		t.Errorf("count = %d, want 2", n)
+	}
+	if n, reset := s.hit("k", time.Second, now.Add(time.Second)); n != 1 || reset != time.Second {
+		t.Errorf("after reset: count = %d, reset = %v", n, reset)
+	}
+}
+
+func TestByIP_untrustedForwarding(t *testing.T) {
+	sl := sol.New()
+	sl.GET("/", func(c *sol.Context) {
+		c.String(http.StatusOK, "%s", ByIP(c))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Body.String() != "ip:198.51.100.1" {
+		t.Errorf("key = %q, want the peer address", rec.Body.String())
+	}
+}
+
+func TestByIP_spoofedForwardingThroughProxy(t *testing.T) {
+	if err := sol.SetTrustedProxies("10.0.0.0/8"); err != nil {
+		t.Fatal(err)
+	}
+	defer sol.SetTrustedProxies()
+
+	sl := sol.New()
+	sl.GET("/", func(c *sol.Context) {
+		c.String(http.StatusOK, "%s", ByIP(c))
+	})
+
+	// Rotating the forged prefix must not change the key.
+	for _, forged := range []string{"1.2.3.4", "5.6.7.8"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forged+", 203.0.113.7")
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Body.String() != "ip:203.0.113.7" {
+			t.Errorf("forged %s: key = %q, want the address the proxy saw", forged, rec.Body.String())
+		}
+	}
+}
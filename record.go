@@ -0,0 +1,237 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// Recording is a request, and optionally its response, captured by
+// Record. soltest.Replay sends recordings again to check a handler still
+// answers the same.
+type Recording struct {
+	Time     time.Time         `json:"time"`
+	Request  RecordedRequest   `json:"request"`
+	Response *RecordedResponse `json:"response,omitempty"`
+}
+
+// RecordedRequest is the request of a Recording.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request.
+	URL    string       `json:"url"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   RecordedBody `json:"body,omitzero"`
+	// Truncated is set when the body exceeded RecordConfig.MaxBody and
+	// was left out.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RecordedResponse is the response of a Recording.
+type RecordedResponse struct {
+	Status int          `json:"status"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   RecordedBody `json:"body,omitzero"`
+	// Truncated is set when the body exceeded RecordConfig.MaxBody.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RecordedBody is a body kept as text, or base64 for binary content.
+type RecordedBody struct {
+	Text   string `json:"text,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+}
+
+func newRecordedBody(b []byte) RecordedBody {
+	if utf8.Valid(b) {
+		return RecordedBody{Text: string(b)}
+	}
+	return RecordedBody{Base64: base64.StdEncoding.EncodeToString(b)}
+}
+
+// Bytes returns the body content.
+func (b RecordedBody) Bytes() ([]byte, error) {
+	if b.Base64 != "" {
+		return base64.StdEncoding.DecodeString(b.Base64)
+	}
+	return []byte(b.Text), nil
+}
+
+// RecordConfig configures Record.
+type RecordConfig struct {
+	// Dir receives one JSON file per request.
+	Dir string
+	// Responses records the responses as well, for golden-file tests.
+	Responses bool
+	// Filter selects the requests to record, all if nil.
+	Filter Matcher
+	// MaxBody caps the recorded bodies, 1MB by default. Longer responses
+	// are cut; longer request bodies are left out, as a cut body can
+	// neither be redacted reliably nor replayed. Handlers still read the
+	// whole request body.
+	MaxBody int
+	// Redact masks credentials in recorded URLs, headers and bodies,
+	// DefaultRedaction if nil. Use &Redaction{} to record as is.
+	Redact *Redaction
+}
+
+// Record writes requests to files in cfg.Dir in a replayable format,
+// e.g. to turn real traffic from a staging server into regression tests:
+//
+//	sl.Use(sol.Record(sol.RecordConfig{Dir: "testdata/traffic", Responses: true}))
+//
+// Up to MaxBody of the request body is read ahead and handed back to the
+// handlers; Raw routes are recorded without request bodies and without
+// responses. Credentials are redacted, so soltest.ReplayWithHeader can
+// put test ones back in when replaying.
+func Record(cfg RecordConfig) HandlerFunc {
+	if cfg.MaxBody <= 0 {
+		cfg.MaxBody = 1 << 20
+	}
+	if cfg.Redact == nil {
+		cfg.Redact = &DefaultRedaction
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		log.Printf("[ERROR] record: %v", err)
+	}
+	var seq atomic.Uint64
+
+	return func(c *Context) {
+		if cfg.Filter != nil && !cfg.Filter(c) {
+			c.Next()
+			return
+		}
+
+		rec := Recording{
+			Time: time.Now(),
+			Request: RecordedRequest{
+				Method: c.Request.Method,
+				URL:    cfg.Redact.URL(c.Request.URL),
+				Header: cfg.Redact.Header(c.Request.Header),
+			},
+		}
+		if !c.raw {
+			b, truncated, err := peekBody(c, cfg.MaxBody)
+			switch {
+			case err != nil:
+			case truncated:
+				rec.Request.Truncated = true
+			default:
+				rec.Request.Body = newRecordedBody(cfg.Redact.Body(c.Header("Content-Type"), b))
+			}
+		}
+
+		var tee *teeWriter
+		if cfg.Responses && !c.raw {
+			w := c.Writer
+			tee = &teeWriter{ResponseWriter: w, limit: cfg.MaxBody}
+			c.Writer = tee
+			defer func() { c.Writer = w }()
+		}
+
+		c.Next()
+
+		if tee != nil {
+			rec.Response = &RecordedResponse{
+				Status:    c.ResponseStatus(),
+				Header:    cfg.Redact.Header(tee.Header()),
+				Body:      newRecordedBody(tee.body),
+				Truncated: tee.truncated,
+			}
+			if rec.Response.Status == 0 {
+				rec.Response.Status = http.StatusOK
+			}
+		}
+
+		b, err := json.MarshalIndent(rec, "", "  ")
+		if err != nil {
+			log.Printf("[ERROR] record: %v", err)
+			return
+		}
+		name := fmt.Sprintf("%s-%06d-%s.json", rec.Time.Format("20060102T150405"), seq.Add(1), recordName(c))
+		if err := os.WriteFile(filepath.Join(cfg.Dir, name), b, 0o644); err != nil {
+			log.Printf("[ERROR] record: %v", err)
+		}
+	}
+}
+
+// peekBody reads up to limit bytes of the request body and puts them
+// back in front of the rest, so handlers still read the body whole. It
+// reports whether the body is longer than limit.
+func peekBody(c *Context, limit int) ([]byte, bool, error) {
+	if c.bodyRead {
+		return c.body, len(c.body) > limit, nil
+	}
+	body := c.Request.Body
+	if body == nil {
+		return nil, false, nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), body), body}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, len(b) > limit, nil
+}
+
+// recordName names a recording after the request method and path.
+func recordName(c *Context) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, strings.Trim(c.Request.URL.Path, "/"))
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return strings.ToLower(c.Request.Method) + "-" + name
+}
+
+// teeWriter copies the response body written through it.
+type teeWriter struct {
+	http.ResponseWriter
+	body      []byte
+	limit     int
+	truncated bool
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	if room := w.limit - len(w.body); room < len(b) {
+		w.body = append(w.body, b[:max(room, 0)]...)
+		w.truncated = true
+	} else {
+		w.body = append(w.body, b...)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, so streamed responses are recorded
+// without holding them back.
+func (w *teeWriter) Flush() {
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *teeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
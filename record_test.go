@@ -0,0 +1,106 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	sl := New()
+	sl.Use(Record(RecordConfig{Dir: dir, Responses: true, MaxBody: 40, Filter: MatchPath("/api/*")}))
+	sl.POST("/api/login", func(c *Context) {
+		c.String(http.StatusCreated, "welcome%s", strings.Repeat("!", 40))
+	})
+	sl.GET("/healthz", func(c *Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodPost, "/api/login?token=s3cret", strings.NewReader(`{"user":"ada","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc")
+	sl.ServeHTTP(httptest.NewRecorder(), req)
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 || !strings.HasSuffix(files[0], "-post-api_login.json") {
+		t.Fatalf("recordings = %v", files)
+	}
+	b, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec Recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Request.URL != "/api/login?token="+Redacted || rec.Request.Header.Get("Authorization") != Redacted {
+		t.Errorf("request not redacted: %s %v", rec.Request.URL, rec.Request.Header)
+	}
+	if body := rec.Request.Body.Text; !strings.Contains(body, `"user":"ada"`) || strings.Contains(body, "hunter2") {
+		t.Errorf("request body = %s", body)
+	}
+	if res := rec.Response; res == nil || res.Status != http.StatusCreated || res.Body.Text != "welcome"+strings.Repeat("!", 33) || !res.Truncated {
+		t.Errorf("response = %+v", res)
+	}
+}
+
+func TestRecord_limits(t *testing.T) {
+	dir := t.TempDir()
+	sl := New()
+	sl.Use(Record(RecordConfig{Dir: dir, Responses: true, MaxBody: 4}))
+	sl.POST("/upload", func(c *Context) {
+		b, _ := c.Body()
+		c.String(http.StatusOK, "%d", len(b))
+	})
+	sl.GET("/stream", func(c *Context) {
+		c.Status(http.StatusOK)
+		c.Writer.(http.Flusher).Flush()
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("0123456789")))
+	if rec.Body.String() != "10" {
+		t.Errorf("handler read %s bytes, want 10", rec.Body.String())
+	}
+	var upload Recording
+	b, _ := os.ReadFile(recordingFile(t, dir, "post-upload"))
+	if err := json.Unmarshal(b, &upload); err != nil {
+		t.Fatal(err)
+	}
+	if !upload.Request.Truncated || upload.Request.Body != (RecordedBody{}) {
+		t.Errorf("request = %+v", upload.Request)
+	}
+
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if !rec.Flushed {
+		t.Error("flush did not pass through the recorder")
+	}
+}
+
+func recordingFile(t *testing.T, dir, suffix string) string {
+	t.Helper()
+	files, _ := filepath.Glob(filepath.Join(dir, "*-"+suffix+".json"))
+	if len(files) != 1 {
+		t.Fatalf("recordings %s: %v", suffix, files)
+	}
+	return files[0]
+}
+
+func TestRecordedBody(t *testing.T) {
+	for _, b := range [][]byte{[]byte("text"), {0xff, 0x00, 0xfe}} {
+		got, err := newRecordedBody(b).Bytes()
+		if err != nil || string(got) != string(b) {
+			t.Errorf("round trip of %q = %q, %v", b, got, err)
+		}
+	}
+}
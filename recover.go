@@ -10,14 +10,50 @@ import (
 	"runtime/debug"
 )
 
+// Recover recovers panics in later handlers and reports them as a
+// *PanicError through Context.Error, rendered in the negotiated error
+// format. In debug mode, see Options.Mode, browsers get a page with the
+// stack and the source around the panic instead; in release mode the
+// details only go to the log, tagged with the request ID. When the
+// response was already committed, e.g. a panic mid stream, no error can
+// be sent anymore: the connection is dropped so the client sees a
+// truncated response rather than a corrupted one.
 func Recover() HandlerFunc {
 	return func(c *Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				stack := string(debug.Stack())
-				log.Printf("[PANIC] %v\n%s", err, stack)
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				err := &PanicError{
+					Value:     v,
+					Stack:     debug.Stack(),
+					Handler:   c.HandlerName(),
+					RequestID: c.RequestID(),
+					Body:      c.capturedBody(),
+					pcs:       callers(),
+				}
+				var body string
+				if err.Body != "" {
+					body = "\nbody: " + err.Body
+					c.bodyReported = true
+				}
 
-				http.Error(c.Writer, "Internal Server Error", http.StatusInternalServerError)
+				if c.Written() {
+					log.Printf("[PANIC] %v in %s (request %s) after the response was committed, dropping connection%s\n%s", v, err.Handler, err.RequestID, body, err.Stack)
+					c.abortConn = true
+					c.Abort()
+					c.reportError(err, http.StatusInternalServerError)
+					return
+				}
+				log.Printf("[PANIC] %v in %s (request %s)%s\n%s", v, err.Handler, err.RequestID, body, err.Stack)
+
+				// Headers describing the abandoned body do not fit the error.
+				h := c.Writer.Header()
+				for _, k := range []string{"Content-Type", "Content-Length", "Content-Disposition", "Content-Encoding", "ETag", "Last-Modified"} {
+					h.Del(k)
+				}
+				c.Error(err)
 			}
 		}()
 		c.Next()
@@ -0,0 +1,145 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Redacted replaces sensitive values in logs and dumps.
+const Redacted = "[REDACTED]"
+
+// Redaction masks credentials and personal data before requests are
+// logged or dumped.
+type Redaction struct {
+	// QueryParams are names of query parameters to mask, matched case
+	// insensitively as path.Match patterns, e.g. "*token*".
+	QueryParams []string
+	// Headers are names of headers to mask, matched like QueryParams.
+	Headers []string
+	// Patterns mask their matches anywhere in logged values, e.g. emails.
+	Patterns []*regexp.Regexp
+}
+
+// DefaultRedaction masks common credential parameters and headers and
+// email addresses.
+var DefaultRedaction = Redaction{
+	QueryParams: []string{"*token*", "*secret*", "*password*", "*key*", "code", "sig", "signature"},
+	Headers:     []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "*token*", "*api-key*", "*secret*"},
+	Patterns:    []*regexp.Regexp{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+}
+
+func matchesAny(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	return slices.ContainsFunc(patterns, func(p string) bool {
+		ok, _ := path.Match(strings.ToLower(p), name)
+		return ok
+	})
+}
+
+// String masks the Patterns matches in s.
+func (r Redaction) String(s string) string {
+	for _, re := range r.Patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// URL returns the path and query of u with sensitive parameters masked.
+func (r Redaction) URL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return r.String(u.Path)
+	}
+	q := u.Query()
+	for name, values := range q {
+		sensitive := matchesAny(r.QueryParams, name)
+		for i, v := range values {
+			if sensitive {
+				values[i] = Redacted
+			} else {
+				values[i] = r.String(v)
+			}
+		}
+	}
+	// Encoding escapes the brackets of Redacted; keep them readable.
+	query := strings.ReplaceAll(q.Encode(), url.QueryEscape(Redacted), Redacted)
+	return r.String(u.Path) + "?" + query
+}
+
+// Header returns a copy of h with sensitive headers masked.
+func (r Redaction) Header(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		masked := make([]string, len(values))
+		for i, v := range values {
+			if matchesAny(r.Headers, name) {
+				masked[i] = Redacted
+			} else {
+				masked[i] = r.String(v)
+			}
+		}
+		out[name] = masked
+	}
+	return out
+}
+
+// Body returns b with sensitive fields masked. JSON and form bodies have
+// the values of fields named like QueryParams masked; Patterns apply to
+// any body.
+func (r Redaction) Body(contentType string, b []byte) []byte {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var v any
+		if json.Unmarshal(b, &v) == nil {
+			if out, err := json.Marshal(r.maskJSON(v)); err == nil {
+				b = out
+			}
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(string(b)); err == nil {
+			u := url.URL{RawQuery: form.Encode()}
+			b = []byte(strings.TrimPrefix(r.URL(&u), "?"))
+		}
+	}
+	return []byte(r.String(string(b)))
+}
+
+// maskJSON masks the sensitive fields of a decoded JSON value.
+func (r Redaction) maskJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if matchesAny(r.QueryParams, k) {
+				v[k] = Redacted
+			} else {
+				v[k] = r.maskJSON(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = r.maskJSON(v[i])
+		}
+	}
+	return v
+}
+
+// DumpRequest renders the request line and headers of req, redacted, for
+// debug logs. The body is not included.
+func (r Redaction) DumpRequest(req *http.Request) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s %s\r\nHost: %s\r\n", req.Method, r.URL(req.URL), req.Proto, req.Host)
+	r.Header(req.Header).Write(&b)
+	return b.Bytes()
+}
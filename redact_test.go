@@ -0,0 +1,68 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedaction(t *testing.T) {
+	r := DefaultRedaction
+
+	req := httptest.NewRequest(http.MethodGet, "/reset?access_token=s3cr3t&page=2&email=ann@example.com", nil)
+	if got, want := r.URL(req.URL), "/reset?access_token=[REDACTED]&email=[REDACTED]&page=2"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("X-Api-Key", "k1")
+	req.Header.Set("From", "bob@example.org")
+	req.Header.Set("Accept", "text/html")
+	h := r.Header(req.Header)
+	for name, want := range map[string]string{
+		"Authorization": Redacted,
+		"X-Api-Key":     Redacted,
+		"From":          Redacted,
+		"Accept":        "text/html",
+	} {
+		if got := h.Get(name); got != want {
+			t.Errorf("Header %s = %q, want %q", name, got, want)
+		}
+	}
+	if req.Header.Get("Authorization") != "Bearer abc" {
+		t.Error("Header modified the request")
+	}
+
+	dump := string(r.DumpRequest(req))
+	if strings.Contains(dump, "abc") || strings.Contains(dump, "s3cr3t") || !strings.HasPrefix(dump, "GET /reset?") {
+		t.Errorf("DumpRequest leaks or is malformed:\n%s", dump)
+	}
+}
+
+func TestLoggerWith(t *testing.T) {
+	var buf bytes.Buffer
+	sl := New()
+	sl.Use(LoggerWith(LoggerConfig{Output: &buf, Query: true, Headers: []string{"authorization", "referer"}}))
+	sl.GET("/login", func(c *Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/login?password=hunter2&next=/home", nil)
+	req.Header.Set("Authorization", "Basic Zm9vOmJhcg==")
+	req.Header.Set("Referer", "https://example.com/")
+	sl.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := buf.String()
+	for _, want := range []string{"/login?next=%2Fhome&password=[REDACTED]", "Authorization: [REDACTED]", "Referer: https://example.com/"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log line lacks %q:\n%s", want, line)
+		}
+	}
+	if strings.Contains(line, "hunter2") || strings.Contains(line, "Zm9v") {
+		t.Errorf("log line leaks credentials:\n%s", line)
+	}
+}
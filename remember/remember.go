@@ -0,0 +1,238 @@
+// Package remember
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package remember
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+var (
+	ErrNotFound = errors.New("remember: token not found")
+	ErrExpired  = errors.New("remember: token expired")
+	// ErrTheft means a series was presented with an outdated token: the
+	// cookie was copied and used by someone else since it was issued.
+	ErrTheft = errors.New("remember: token reuse detected")
+)
+
+// Token is a persistent login as kept by a Store. The series identifies
+// the login and stays stable; the token rotates on every use and only its
+// hash is stored.
+type Token struct {
+	Series  string
+	Hash    []byte
+	Subject string
+	Expires time.Time
+	// PrevHash is the hash of the token replaced at Rotated. It stays
+	// valid for Config.Grace, so parallel requests carrying the same
+	// cookie are not mistaken for theft.
+	PrevHash []byte
+	Rotated  time.Time
+}
+
+// Config configures a Manager.
+type Config struct {
+	// Cookie is the cookie name, "remember_me" by default.
+	Cookie string
+	// MaxAge is how long a login is remembered, 30 days by default.
+	// Each use extends it.
+	MaxAge time.Duration
+	// Grace is how long the previous token is still accepted after a
+	// rotation, 10s by default. Parallel page loads send the cookie
+	// before either response replaces it.
+	Grace time.Duration
+	// Insecure drops the Secure attribute, for local development over HTTP.
+	Insecure bool
+	// Load resolves a subject to the principal set on the request.
+	// Returning an error rejects the login, e.g. for a disabled account.
+	Load func(ctx context.Context, subject string) (sol.Principal, error)
+	// OnTheft is called after a stolen token was detected and every login
+	// of the subject was revoked, e.g. to notify the user.
+	OnTheft func(c *sol.Context, subject string)
+}
+
+// Manager issues and checks remember-me cookies using the series and
+// rotating token scheme: a reused token reveals a stolen cookie, and all
+// logins of its subject are revoked.
+type Manager struct {
+	store Store
+	cfg   Config
+}
+
+// New returns a Manager backed by store. Config.Load is required.
+func New(store Store, config Config) *Manager {
+	if config.Load == nil {
+		panic("remember: Config.Load is required")
+	}
+	if config.Cookie == "" {
+		config.Cookie = "remember_me"
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = 30 * 24 * time.Hour
+	}
+	if config.Grace <= 0 {
+		config.Grace = 10 * time.Second
+	}
+	return &Manager{store: store, cfg: config}
+}
+
+// Login starts a persistent login for subject and sets the cookie,
+// typically after a password check with "remember me" ticked.
+func (m *Manager) Login(c *sol.Context, subject string) error {
+	series, err := randomString()
+	if err != nil {
+		return err
+	}
+	return m.issue(c, Token{Series: series, Subject: subject})
+}
+
+// issue rotates the token of t, saves it and sets the cookie.
+func (m *Manager) issue(c *sol.Context, t Token) error {
+	value, err := randomString()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if t.Hash != nil {
+		t.PrevHash, t.Rotated = t.Hash, now
+	}
+	t.Hash = hashToken(value)
+	t.Expires = now.Add(m.cfg.MaxAge)
+
+	if err := m.store.Save(c.Context(), t); err != nil {
+		return fmt.Errorf("remember: save token: %w", err)
+	}
+	m.setCookie(c, t.Series+":"+value, int(m.cfg.MaxAge/time.Second))
+	return nil
+}
+
+// Middleware logs in requests without a principal that carry a valid
+// cookie, setting the principal and rotating the token. Invalid cookies
+// are cleared and the request continues anonymously.
+func (m *Manager) Middleware() sol.HandlerFunc {
+	return func(c *sol.Context) {
+		if c.Principal() == nil {
+			if err := m.authenticate(c); err != nil && !errors.Is(err, http.ErrNoCookie) {
+				m.clearCookie(c)
+				if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrExpired) {
+					log.Printf("[WARN] remember: %v", err)
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+func (m *Manager) authenticate(c *sol.Context) error {
+	raw, err := c.Cookie(m.cfg.Cookie)
+	if err != nil {
+		return err
+	}
+	series, value, ok := strings.Cut(raw, ":")
+	if !ok || series == "" || value == "" {
+		return ErrNotFound
+	}
+
+	ctx := c.Context()
+	t, err := m.store.Get(ctx, series)
+	if err != nil {
+		return err
+	}
+	if time.Now().After(t.Expires) {
+		m.store.Delete(ctx, series)
+		return ErrExpired
+	}
+	hash := hashToken(value)
+	// A request racing the one that rotated the token: accept it, and
+	// leave the cookie to the response carrying the new token.
+	rotatedAway := t.PrevHash != nil && subtle.ConstantTimeCompare(t.PrevHash, hash) == 1 &&
+		time.Since(t.Rotated) < m.cfg.Grace
+	if rotatedAway {
+		p, err := m.cfg.Load(ctx, t.Subject)
+		if err != nil {
+			return fmt.Errorf("remember: load %s: %w", t.Subject, err)
+		}
+		c.SetPrincipal(p)
+		return nil
+	}
+	if subtle.ConstantTimeCompare(t.Hash, hash) != 1 {
+		if err := m.store.DeleteSubject(ctx, t.Subject); err != nil {
+			return fmt.Errorf("remember: revoke %s: %w", t.Subject, err)
+		}
+		if m.cfg.OnTheft != nil {
+			m.cfg.OnTheft(c, t.Subject)
+		}
+		return fmt.Errorf("%w for %s", ErrTheft, t.Subject)
+	}
+
+	p, err := m.cfg.Load(ctx, t.Subject)
+	if err != nil {
+		m.store.Delete(ctx, series)
+		return fmt.Errorf("remember: load %s: %w", t.Subject, err)
+	}
+	if err := m.issue(c, t); err != nil {
+		return err
+	}
+	c.SetPrincipal(p)
+	return nil
+}
+
+// Logout forgets the login of the request's cookie.
+func (m *Manager) Logout(c *sol.Context) error {
+	m.clearCookie(c)
+	raw, err := c.Cookie(m.cfg.Cookie)
+	if err != nil {
+		return nil
+	}
+	series, _, _ := strings.Cut(raw, ":")
+	return m.store.Delete(c.Context(), series)
+}
+
+// LogoutEverywhere forgets every persistent login of subject, on all
+// devices, and clears the cookie of the current request.
+func (m *Manager) LogoutEverywhere(c *sol.Context, subject string) error {
+	m.clearCookie(c)
+	return m.store.DeleteSubject(c.Context(), subject)
+}
+
+func (m *Manager) setCookie(c *sol.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     m.cfg.Cookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   !m.cfg.Insecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (m *Manager) clearCookie(c *sol.Context) {
+	m.setCookie(c, "", -1)
+}
+
+func hashToken(value string) []byte {
+	sum := sha256.Sum256([]byte(value))
+	return sum[:]
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("remember: generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
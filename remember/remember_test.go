@@ -0,0 +1,162 @@
+// Package remember
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package remember
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+type user string
+
+func (u user) Subject() string { return string(u) }
+
+func TestRememberMe(t *testing.T) {
+	store := NewMemoryStore()
+	var stolen []string
+	m := New(store, Config{
+		Load: func(_ context.Context, subject string) (sol.Principal, error) {
+			return user(subject), nil
+		},
+		OnTheft: func(c *sol.Context, subject string) { stolen = append(stolen, subject) },
+	})
+
+	sl := sol.New()
+	sl.Use(m.Middleware())
+	sl.POST("/login", func(c *sol.Context) {
+		if err := m.Login(c, "42"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	sl.GET("/me", func(c *sol.Context) {
+		if p := c.Principal(); p != nil {
+			c.String(http.StatusOK, "%s", p.Subject())
+			return
+		}
+		c.String(http.StatusUnauthorized, "anonymous")
+	})
+
+	do := func(method, path string, cookie *http.Cookie) (string, *http.Cookie) {
+		req := httptest.NewRequest(method, path, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		for _, ck := range rec.Result().Cookies() {
+			if ck.Name == "remember_me" {
+				return rec.Body.String(), ck
+			}
+		}
+		return rec.Body.String(), nil
+	}
+
+	_, first := do(http.MethodPost, "/login", nil)
+	if first == nil || !first.Secure || !first.HttpOnly {
+		t.Fatalf("login cookie = %v", first)
+	}
+
+	body, second := do(http.MethodGet, "/me", first)
+	if body != "42" {
+		t.Fatalf("remembered request: body = %q", body)
+	}
+	if second == nil || second.Value == first.Value {
+		t.Fatalf("token not rotated: %v", second)
+	}
+
+	// Replaying the old token after the grace window reveals the theft
+	// and revokes every login.
+	backdateRotation(t, store, first)
+	body, cleared := do(http.MethodGet, "/me", first)
+	if body != "anonymous" || cleared == nil || cleared.MaxAge >= 0 {
+		t.Errorf("replayed token: body = %q, cookie = %v", body, cleared)
+	}
+	if len(stolen) != 1 || stolen[0] != "42" {
+		t.Errorf("OnTheft calls = %v", stolen)
+	}
+	if body, _ := do(http.MethodGet, "/me", second); body != "anonymous" {
+		t.Errorf("current token still valid after theft: %q", body)
+	}
+}
+
+func TestLogoutEverywhere(t *testing.T) {
+	store := NewMemoryStore()
+	m := New(store, Config{
+		Load: func(_ context.Context, subject string) (sol.Principal, error) {
+			return user(subject), nil
+		},
+	})
+
+	ctx := context.Background()
+	for _, series := range []string{"a", "b"} {
+		store.Save(ctx, Token{Series: series, Subject: "42"})
+	}
+	store.Save(ctx, Token{Series: "c", Subject: "7"})
+
+	c := &sol.Context{Request: httptest.NewRequest(http.MethodPost, "/logout", nil), Writer: httptest.NewRecorder()}
+	if err := m.LogoutEverywhere(c, "42"); err != nil {
+		t.Fatal(err)
+	}
+	for series, want := range map[string]error{"a": ErrNotFound, "b": ErrNotFound, "c": nil} {
+		if _, err := store.Get(ctx, series); err != want {
+			t.Errorf("Get(%s) err = %v, want %v", series, err, want)
+		}
+	}
+}
+
+// backdateRotation moves the last rotation of the cookie's series out of
+// the grace window.
+func backdateRotation(t *testing.T, store Store, cookie *http.Cookie) {
+	t.Helper()
+	series, _, _ := strings.Cut(cookie.Value, ":")
+	tok, err := store.Get(context.Background(), series)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok.Rotated = tok.Rotated.Add(-time.Minute)
+	store.Save(context.Background(), tok)
+}
+
+func TestRememberMe_ParallelRequests(t *testing.T) {
+	var stolen int
+	m := New(NewMemoryStore(), Config{
+		Load: func(_ context.Context, subject string) (sol.Principal, error) {
+			return user(subject), nil
+		},
+		OnTheft: func(*sol.Context, string) { stolen++ },
+	})
+	sl := sol.New()
+	sl.Use(m.Middleware())
+	sl.POST("/login", func(c *sol.Context) { m.Login(c, "42") })
+	sl.GET("/me", func(c *sol.Context) {
+		if p := c.Principal(); p != nil {
+			c.String(http.StatusOK, "%s", p.Subject())
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/login", nil))
+	cookie := rec.Result().Cookies()[0]
+
+	// Two page loads sent with the same cookie before either response.
+	for i := range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Body.String() != "42" {
+			t.Errorf("request %d: body = %q", i+1, rec.Body.String())
+		}
+	}
+	if stolen != 0 {
+		t.Errorf("parallel requests reported as theft")
+	}
+}
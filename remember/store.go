@@ -0,0 +1,70 @@
+// Package remember
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package remember
+
+import (
+	"context"
+	"sync"
+)
+
+// Store persists remember-me tokens. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the token of a series, or ErrNotFound.
+	Get(ctx context.Context, series string) (Token, error)
+	// Save inserts the token or replaces the one of the same series.
+	Save(ctx context.Context, t Token) error
+	// Delete removes a series. Deleting a missing series is not an error.
+	Delete(ctx context.Context, series string) error
+	// DeleteSubject removes every series of a subject.
+	DeleteSubject(ctx context.Context, subject string) error
+}
+
+// MemoryStore keeps tokens in memory. Tokens are lost on restart, so it
+// suits tests and single instance deployments.
+type MemoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]Token)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, series string) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tokens[series]
+	if !ok {
+		return Token{}, ErrNotFound
+	}
+	return t, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, t Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[t.Series] = t
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, series string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, series)
+	return nil
+}
+
+func (s *MemoryStore) DeleteSubject(_ context.Context, subject string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for series, t := range s.tokens {
+		if t.Subject == subject {
+			delete(s.tokens, series)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// Renderer encodes a response body. Renderers mirror the binders of the
+// binding package, so a handler can answer in the format it accepts. They
+// are used directly with RenderWith or by name with RenderAs; Render is
+// taken by templates.
+type Renderer interface {
+	ContentType() string
+	Render(w io.Writer, obj any) error
+}
+
+// Built-in renderers.
+var (
+	JSONRenderer Renderer = jsonRenderer{}
+	FormRenderer Renderer = formRenderer{}
+)
+
+// renderers maps format names to renderers, see RegisterRenderer.
+var renderers = map[string]Renderer{
+	"json":       JSONRenderer,
+	"form":       FormRenderer,
+	"urlencoded": FormRenderer,
+}
+
+// RegisterRenderer makes r available to RenderAs under name, e.g.
+// "msgpack", replacing a renderer of the same name. It must be called
+// before serving starts, e.g. in init.
+func RegisterRenderer(name string, r Renderer) {
+	renderers[name] = r
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) ContentType() string { return "application/json; charset=utf-8" }
+
+func (jsonRenderer) Render(w io.Writer, obj any) error {
+	return json.NewEncoder(w).Encode(obj)
+}
+
+// formRenderer writes application/x-www-form-urlencoded bodies, as OAuth
+// style token endpoints require. It accepts url.Values, map[string]string,
+// map[string][]string and structs, whose fields are named by their form tag.
+type formRenderer struct{}
+
+func (formRenderer) ContentType() string { return "application/x-www-form-urlencoded" }
+
+func (formRenderer) Render(w io.Writer, obj any) error {
+	values, err := formValues(obj)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, values.Encode())
+	return err
+}
+
+func formValues(obj any) (url.Values, error) {
+	switch v := obj.(type) {
+	case url.Values:
+		return v, nil
+	case map[string][]string:
+		return url.Values(v), nil
+	case map[string]string:
+		values := make(url.Values, len(v))
+		for k, s := range v {
+			values.Set(k, s)
+		}
+		return values, nil
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(obj))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form render: unsupported type %T", obj)
+	}
+
+	values := make(url.Values)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("form")
+		if tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Slice {
+			for j := 0; j < fv.Len(); j++ {
+				values.Add(tag, formatValue(fv.Index(j)))
+			}
+			continue
+		}
+		values.Set(tag, formatValue(fv))
+	}
+	return values, nil
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// RenderWith encodes obj with r and writes it with the given status.
+// Encoding happens before anything is written, so a failure yields a
+// clean 500.
+func (c *Context) RenderWith(status int, r Renderer, obj any) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := r.Render(buf, obj); err != nil {
+		log.Printf("[ERROR] render %s: %v", r.ContentType(), err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", r.ContentType())
+	c.Writer.WriteHeader(status)
+	c.Writer.Write(buf.Bytes())
+}
+
+// RenderAs encodes obj with the renderer registered under format, e.g.
+// "json" or "form", and writes it with the given status. An unknown
+// format is logged and answered with 500.
+func (c *Context) RenderAs(status int, format string, obj any) {
+	r, ok := renderers[format]
+	if !ok {
+		log.Printf("[ERROR] render: no renderer registered for %q", format)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.RenderWith(status, r, obj)
+}
+
+// Form writes obj as an application/x-www-form-urlencoded body, see FormRenderer.
+func (c *Context) Form(status int, obj any) {
+	c.RenderWith(status, FormRenderer, obj)
+}
@@ -0,0 +1,93 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestContext_Form(t *testing.T) {
+	type token struct {
+		AccessToken string   `form:"access_token"`
+		ExpiresIn   int      `form:"expires_in"`
+		Scope       []string `form:"scope"`
+		Secret      string
+	}
+
+	tests := []struct {
+		name   string
+		obj    any
+		status int
+		body   string
+	}{
+		{"struct", token{"abc", 3600, []string{"read", "write"}, "x"}, http.StatusOK, "access_token=abc&expires_in=3600&scope=read&scope=write"},
+		{"values", url.Values{"error": {"invalid_grant"}}, http.StatusOK, "error=invalid_grant"},
+		{"map", map[string]string{"a": "1 2"}, http.StatusOK, "a=1+2"},
+		{"unsupported", 42, http.StatusInternalServerError, "Internal Server Error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := &Context{Writer: rec, Request: httptest.NewRequest(http.MethodPost, "/token", nil)}
+			c.Form(http.StatusOK, tt.obj)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+			}
+			if tt.status == http.StatusOK && rec.Header().Get("Content-Type") != "application/x-www-form-urlencoded" {
+				t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+type textRenderer struct{}
+
+func (textRenderer) ContentType() string { return "text/plain; charset=utf-8" }
+
+func (textRenderer) Render(w io.Writer, obj any) error {
+	_, err := fmt.Fprint(w, obj)
+	return err
+}
+
+func TestContext_RenderAs(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	RegisterRenderer("text", textRenderer{})
+	defer delete(renderers, "text")
+
+	tests := []struct {
+		format      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"urlencoded", http.StatusOK, "application/x-www-form-urlencoded", "a=1"},
+		{"json", http.StatusOK, "application/json; charset=utf-8", "{\"a\":\"1\"}\n"},
+		{"text", http.StatusOK, "text/plain; charset=utf-8", "map[a:1]"},
+		{"yaml", http.StatusInternalServerError, "text/plain; charset=utf-8", "Internal Server Error\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c := &Context{Writer: rec, Request: httptest.NewRequest(http.MethodGet, "/", nil)}
+		c.RenderAs(http.StatusOK, tt.format, map[string]string{"a": "1"})
+
+		if rec.Code != tt.status || rec.Header().Get("Content-Type") != tt.contentType || rec.Body.String() != tt.body {
+			t.Errorf("%s: %d %q %q", tt.format, rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ErrorReport describes a server error for an ErrorReporter.
+type ErrorReport struct {
+	Err    error
+	Status int
+	// Stack and Frames are set for recovered panics.
+	Stack  []byte
+	Frames []StackFrame
+
+	Time      time.Time
+	RequestID string
+	TraceID   string
+	Method    string
+	// Path is the request path, Route the pattern of the matched route.
+	Path      string
+	Route     string
+	ClientIP  string
+	UserAgent string
+	// Subject identifies the authenticated user, if any.
+	Subject string
+	// Header holds the request headers, redacted.
+	Header http.Header
+	// Body is the captured request body, see Sol.WithBodyCapture.
+	Body string
+}
+
+// ErrorReporter forwards server errors to a service such as Sentry or
+// Rollbar. It is called on the request goroutine and should hand reports
+// off without blocking.
+type ErrorReporter interface {
+	Report(ctx context.Context, r *ErrorReport)
+}
+
+// ErrorReporterFunc adapts a function to ErrorReporter.
+type ErrorReporterFunc func(ctx context.Context, r *ErrorReport)
+
+func (f ErrorReporterFunc) Report(ctx context.Context, r *ErrorReport) {
+	f(ctx, r)
+}
+
+// WithErrorReporter sends errors reported with Context.Error and
+// recovered panics to r when they answer with a 5xx status. Client
+// errors are not reported.
+func (sl *Sol) WithErrorReporter(r ErrorReporter) *Sol {
+	sl.reporter = r
+	return sl
+}
+
+// reportError passes err to the engine ErrorReporter, if any.
+func (c *Context) reportError(err error, status int) {
+	if c.engine == nil || c.engine.reporter == nil || status < http.StatusInternalServerError {
+		return
+	}
+
+	redact := &DefaultRedaction
+	if c.engine.capture != nil {
+		redact = c.engine.capture.redact
+	}
+	r := &ErrorReport{
+		Err:       err,
+		Status:    status,
+		Time:      time.Now(),
+		RequestID: c.RequestID(),
+		TraceID:   c.TraceID(),
+		Method:    c.Method(),
+		Path:      c.Path(),
+		Route:     c.RoutePattern(),
+		ClientIP:  ClientIP(c.Request),
+		UserAgent: c.Request.UserAgent(),
+		Header:    redact.Header(c.Request.Header),
+		Body:      c.capturedBody(),
+	}
+	var perr *PanicError
+	if errors.As(err, &perr) {
+		r.Stack, r.Frames = perr.Stack, perr.Frames()
+	}
+	if p := c.Principal(); p != nil {
+		r.Subject = p.Subject()
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			log.Printf("[ERROR] error reporter panicked: %v", v)
+		}
+	}()
+	c.engine.reporter.Report(c.Context(), r)
+}
+
+// errorStatus is the status an error answers with: that of a
+// StatusCoder, or 500.
+func errorStatus(err error) int {
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
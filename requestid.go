@@ -0,0 +1,42 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+// requestIDKey is the Context key holding the request ID.
+const requestIDKey = "sol.request_id"
+
+// RequestIDHeader carries request IDs in from proxies.
+var RequestIDHeader = "X-Request-Id"
+
+// maxRequestID caps the length of accepted incoming request IDs.
+const maxRequestID = 128
+
+// RequestID returns the ID of the request: the incoming RequestIDHeader if
+// it is well formed, else a random ID generated on first use.
+func (c *Context) RequestID() string {
+	if id, ok := c.GetString(requestIDKey); ok {
+		return id
+	}
+	id := c.Header(RequestIDHeader)
+	if !validRequestID(id) {
+		id = randomHex(16)
+	}
+	c.Set(requestIDKey, id)
+	return id
+}
+
+// validRequestID accepts short IDs of printable ASCII without spaces, so
+// they are safe to log and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestID {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	sl := New()
+	sl.GET("/", func(c *Context) { c.String(http.StatusOK, "%s", c.RequestID()) })
+
+	tests := []struct {
+		incoming string
+		keep     bool
+	}{
+		{"", false},
+		{"abc-123", true},
+		{"has space", false},
+		{strings.Repeat("x", maxRequestID+1), false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.incoming != "" {
+			req.Header.Set(RequestIDHeader, tt.incoming)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+
+		id := rec.Body.String()
+		if id == "" {
+			t.Errorf("incoming %q: empty request ID", tt.incoming)
+		}
+		if (id == tt.incoming) != tt.keep {
+			t.Errorf("incoming %q: got %q, keep = %v", tt.incoming, id, tt.keep)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTagsKey is the Context key holding the tags set with CacheTags.
+const cacheTagsKey = "sol.cache.tags"
+
+// DefaultCacheEntries bounds the number of responses a ResponseCache holds.
+var DefaultCacheEntries = 10000
+
+// ResponseCache stores GET responses cached by CacheResponses, so write
+// endpoints can purge them by path, route pattern or tag.
+type ResponseCache struct {
+	mu      sync.RWMutex
+	entries map[string]*cachedResponse
+	// tags maps a tag to the keys of the entries carrying it
+	tags map[string]map[string]struct{}
+}
+
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+	path    string
+	pattern string
+	tags    []string
+	// public is set for responses marked Cache-Control: public, the only
+	// ones shared with requests carrying credentials
+	public bool
+}
+
+// Cache returns the engine response cache.
+func (sl *Sol) Cache() *ResponseCache {
+	sl.cacheOnce.Do(func() {
+		sl.cache = &ResponseCache{
+			entries: make(map[string]*cachedResponse),
+			tags:    make(map[string]map[string]struct{}),
+		}
+	})
+	return sl.cache
+}
+
+// Cache returns the response cache of the engine serving the request.
+func (c *Context) Cache() *ResponseCache {
+	return c.engine.Cache()
+}
+
+// CacheTags tags the response being cached, e.g. "user:42", so that
+// ResponseCache.InvalidateTags purges it when the data changes.
+func (c *Context) CacheTags(tags ...string) {
+	var all []string
+	if v, ok := c.Get(cacheTagsKey); ok {
+		all = v.([]string)
+	}
+	c.Set(cacheTagsKey, append(all, tags...))
+}
+
+// CacheResponses caches successful GET responses of the handlers after it
+// for ttl, keyed by request URI; HEAD requests to routes registered for
+// HEAD are answered from the same entries. Responses setting cookies,
+// carrying Vary or marked no-store or private are not cached, nor are
+// Raw routes. Requests with an Authorization or Cookie header are only
+// cached, and only served from the cache, if the response is marked
+// Cache-Control: public (RFC 9111, section 3.5), so one user's response
+// never reaches another. Served entries carry "X-Cache: HIT".
+func CacheResponses(ttl time.Duration) HandlerFunc {
+	return func(c *Context) {
+		method := c.Method()
+		if (method != http.MethodGet && method != http.MethodHead) || c.IsRaw() {
+			c.Next()
+			return
+		}
+
+		rc := c.Cache()
+		key := c.Request.URL.RequestURI()
+		credentials := c.Request.Header.Get("Authorization") != "" || c.Request.Header.Get("Cookie") != ""
+		if e := rc.get(key); e != nil && (e.public || !credentials) {
+			h := c.Writer.Header()
+			for k, v := range e.header {
+				h[k] = slices.Clone(v)
+			}
+			h.Set("X-Cache", "HIT")
+			c.Writer.WriteHeader(e.status)
+			if method == http.MethodGet {
+				c.Writer.Write(e.body)
+			}
+			c.Abort()
+			return
+		}
+		if method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := c.Writer
+		bw := &bufferWriter{ResponseWriter: w, body: getBuffer()}
+		defer putBuffer(bw.body)
+
+		c.Writer = bw
+		defer func() { c.Writer = w }()
+
+		c.Next()
+
+		status := bw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		public := publicResponse(w.Header())
+		if status == http.StatusOK && cacheable(w.Header()) && (public || !credentials) {
+			var tags []string
+			if v, ok := c.Get(cacheTagsKey); ok {
+				tags = v.([]string)
+			}
+			rc.put(key, &cachedResponse{
+				status:  status,
+				header:  w.Header().Clone(),
+				body:    slices.Clone(bw.body.Bytes()),
+				expires: time.Now().Add(ttl),
+				path:    c.Path(),
+				pattern: c.RoutePattern(),
+				tags:    tags,
+				public:  public,
+			})
+		}
+
+		w.Header().Set("X-Cache", "MISS")
+		if w.Header().Get("Content-Length") != "" {
+			w.Header().Set("Content-Length", strconv.Itoa(bw.body.Len()))
+		}
+		w.WriteHeader(status)
+		w.Write(bw.body.Bytes())
+	}
+}
+
+func cacheable(h http.Header) bool {
+	if h.Get("Set-Cookie") != "" || h.Get("Vary") != "" {
+		return false
+	}
+	cc := strings.ToLower(h.Get("Cache-Control"))
+	return !strings.Contains(cc, "no-store") && !strings.Contains(cc, "private")
+}
+
+func publicResponse(h http.Header) bool {
+	for directive := range strings.SplitSeq(h.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "public") {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the live entry of key, dropping it if it expired.
+func (rc *ResponseCache) get(key string) *cachedResponse {
+	rc.mu.RLock()
+	e := rc.entries[key]
+	rc.mu.RUnlock()
+	if e == nil {
+		return nil
+	}
+	if time.Now().After(e.expires) {
+		rc.mu.Lock()
+		if rc.entries[key] == e {
+			rc.remove(key)
+		}
+		rc.mu.Unlock()
+		return nil
+	}
+	return e
+}
+
+func (rc *ResponseCache) put(key string, e *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= DefaultCacheEntries {
+		now := time.Now()
+		for k, old := range rc.entries {
+			if now.After(old.expires) {
+				rc.remove(k)
+			}
+		}
+		if len(rc.entries) >= DefaultCacheEntries {
+			return
+		}
+	}
+
+	rc.remove(key)
+	rc.entries[key] = e
+	for _, tag := range e.tags {
+		if rc.tags[tag] == nil {
+			rc.tags[tag] = make(map[string]struct{})
+		}
+		rc.tags[tag][key] = struct{}{}
+	}
+}
+
+// remove deletes an entry and its tag references. rc.mu must be held.
+func (rc *ResponseCache) remove(key string) {
+	e, ok := rc.entries[key]
+	if !ok {
+		return
+	}
+	delete(rc.entries, key)
+	for _, tag := range e.tags {
+		delete(rc.tags[tag], key)
+		if len(rc.tags[tag]) == 0 {
+			delete(rc.tags, tag)
+		}
+	}
+}
+
+// Invalidate removes the responses whose route pattern equals pattern,
+// e.g. "/users/:id", or whose request path matches it as a path.Match
+// glob, e.g. "/users/*". It returns the number of responses removed.
+func (rc *ResponseCache) Invalidate(pattern string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	n := 0
+	for key, e := range rc.entries {
+		if ok, _ := path.Match(pattern, e.path); ok || e.pattern == pattern {
+			rc.remove(key)
+			n++
+		}
+	}
+	return n
+}
+
+// InvalidateTags removes the responses tagged with any of tags and
+// returns their number.
+func (rc *ResponseCache) InvalidateTags(tags ...string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	n := 0
+	for _, tag := range tags {
+		for key := range rc.tags[tag] {
+			rc.remove(key)
+			n++
+		}
+	}
+	return n
+}
+
+// Purge empties the cache.
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	clear(rc.entries)
+	clear(rc.tags)
+}
@@ -0,0 +1,119 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheResponses(t *testing.T) {
+	sl := New()
+
+	calls := map[string]int{}
+	api := sl.Group("/", CacheResponses(time.Minute))
+	user := func(c *Context) {
+		calls[c.Param("id")]++
+		c.CacheTags("user:" + c.Param("id"))
+		c.String(http.StatusOK, "user %s v%d", c.Param("id"), calls[c.Param("id")])
+	}
+	api.GET("/users/:id", user)
+	api.HEAD("/users/:id", user)
+	api.GET("/private", func(c *Context) {
+		calls["private"]++
+		c.CacheControl(Cache{Private: true})
+		c.String(http.StatusOK, "secret")
+	})
+	sl.PUT("/users/:id", func(c *Context) {
+		c.Cache().InvalidateTags("user:" + c.Param("id"))
+	})
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+	expect := func(path, body, xcache string) {
+		t.Helper()
+		rec := get(http.MethodGet, path)
+		if rec.Body.String() != body || rec.Header().Get("X-Cache") != xcache {
+			t.Errorf("GET %s = %q (%s), want %q (%s)", path, rec.Body.String(), rec.Header().Get("X-Cache"), body, xcache)
+		}
+	}
+
+	expect("/users/1", "user 1 v1", "MISS")
+	expect("/users/1", "user 1 v1", "HIT")
+	expect("/users/2", "user 2 v1", "MISS")
+	if rec := get(http.MethodHead, "/users/1"); rec.Header().Get("X-Cache") != "HIT" || rec.Body.Len() != 0 {
+		t.Errorf("HEAD: X-Cache = %q, body = %q", rec.Header().Get("X-Cache"), rec.Body.String())
+	}
+
+	get(http.MethodPut, "/users/1")
+	expect("/users/1", "user 1 v2", "MISS")
+	expect("/users/2", "user 2 v1", "HIT")
+
+	if n := sl.Cache().Invalidate("/users/:id"); n != 2 {
+		t.Errorf("Invalidate(pattern) removed %d, want 2", n)
+	}
+	expect("/users/2", "user 2 v2", "MISS")
+	if n := sl.Cache().Invalidate("/users/*"); n != 1 {
+		t.Errorf("Invalidate(glob) removed %d, want 1", n)
+	}
+
+	get(http.MethodGet, "/private")
+	get(http.MethodGet, "/private")
+	if calls["private"] != 2 {
+		t.Errorf("private response cached: %d calls", calls["private"])
+	}
+}
+
+func TestCacheResponses_Credentials(t *testing.T) {
+	sl := New()
+	api := sl.Group("/", CacheResponses(time.Minute))
+	api.GET("/me", func(c *Context) {
+		c.String(http.StatusOK, "user %s", c.Header("Authorization"))
+	})
+	api.GET("/logo", func(c *Context) {
+		c.SetHeader("Cache-Control", "public, max-age=60")
+		c.String(http.StatusOK, "logo")
+	})
+
+	get := func(path, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	get("/me", "alice")
+	if rec := get("/me", "bob"); rec.Body.String() != "user bob" {
+		t.Errorf("GET /me as bob = %q", rec.Body.String())
+	}
+	if rec := get("/me", ""); rec.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("anonymous GET /me was served a credentialed response")
+	}
+
+	get("/logo", "alice")
+	if rec := get("/logo", "bob"); rec.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("public response was not shared: X-Cache = %q", rec.Header().Get("X-Cache"))
+	}
+}
+
+func TestResponseCache_DropsExpired(t *testing.T) {
+	sl := New()
+	rc := sl.Cache()
+	rc.put("/a", &cachedResponse{status: http.StatusOK, expires: time.Now().Add(-time.Second), tags: []string{"t"}})
+	if rc.get("/a") != nil {
+		t.Fatal("expired entry served")
+	}
+	if len(rc.entries) != 0 || len(rc.tags) != 0 {
+		t.Errorf("expired entry kept: %d entries, %d tags", len(rc.entries), len(rc.tags))
+	}
+}
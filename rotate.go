@@ -0,0 +1,182 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateConfig configures a RotatingFile.
+type RotateConfig struct {
+	// Filename is the file written to. Rotated files are kept next to it
+	// as name-<timestamp>.ext.
+	Filename string
+	// MaxSize rotates the file once it would grow past this many bytes,
+	// 100MB by default. A negative value disables size based rotation.
+	MaxSize int64
+	// Interval rotates the file when it has been open this long,
+	// e.g. 24h for daily files. Zero disables time based rotation.
+	Interval time.Duration
+	// MaxBackups is the number of rotated files kept, zero keeps all.
+	MaxBackups int
+	// MaxAge removes rotated files older than this, zero keeps all.
+	MaxAge time.Duration
+}
+
+// RotatingFile is an io.WriteCloser appending to a file that is rotated
+// by size and age, with retention of the rotated files. It is safe for
+// concurrent use.
+type RotatingFile struct {
+	cfg RotateConfig
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingFile opens cfg.Filename for appending, creating it and its
+// directory as needed.
+func NewRotatingFile(cfg RotateConfig) (*RotatingFile, error) {
+	if cfg.Filename == "" {
+		return nil, fmt.Errorf("rotating file: empty filename")
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = 100 << 20
+	}
+
+	f := &RotatingFile{cfg: cfg}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.cfg.Filename), 0o755); err != nil {
+		return fmt.Errorf("rotating file: %w", err)
+	}
+
+	file, err := os.OpenFile(f.cfg.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("rotating file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("rotating file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	return nil
+}
+
+// Write appends p, rotating first when the size or interval limit is hit.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	overSize := f.cfg.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.cfg.MaxSize
+	overAge := f.cfg.Interval > 0 && time.Since(f.opened) >= f.cfg.Interval
+	if overSize || overAge {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the current file, moves it aside and opens a new one.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("rotating file: %w", err)
+	}
+	f.file = nil
+
+	if err := os.Rename(f.cfg.Filename, f.backupName(time.Now())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotating file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.prune()
+	return nil
+}
+
+// backupName returns the rotated name for t, e.g. access-20260102T150405.000.log.
+// The timestamp sorts lexically, which prune relies on.
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.cfg.Filename)
+	base := strings.TrimSuffix(f.cfg.Filename, ext)
+	return base + "-" + t.Format("20060102T150405.000") + ext
+}
+
+// prune removes rotated files beyond MaxBackups or older than MaxAge.
+func (f *RotatingFile) prune() {
+	if f.cfg.MaxBackups <= 0 && f.cfg.MaxAge <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(f.cfg.Filename)
+	pattern := strings.TrimSuffix(f.cfg.Filename, ext) + "-*" + ext
+	backups, err := filepath.Glob(pattern)
+	if err != nil {
+		return
+	}
+	// Newest first.
+	slices.Sort(backups)
+	slices.Reverse(backups)
+
+	for i, name := range backups {
+		remove := f.cfg.MaxBackups > 0 && i >= f.cfg.MaxBackups
+		if !remove && f.cfg.MaxAge > 0 {
+			if info, err := os.Stat(name); err == nil && time.Since(info.ModTime()) > f.cfg.MaxAge {
+				remove = true
+			}
+		}
+		if remove {
+			os.Remove(name)
+		}
+	}
+}
+
+// Close closes the current file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
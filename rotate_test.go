@@ -0,0 +1,67 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "logs", "access.log")
+
+	f, err := NewRotatingFile(RotateConfig{Filename: name, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		// Keep backup timestamps distinct.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	current, _ := os.ReadFile(name)
+	if string(current) != "dddddddd\n" {
+		t.Errorf("current = %q", current)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "logs", "access-*.log"))
+	if len(backups) != 2 {
+		t.Fatalf("backups = %v, want 2", backups)
+	}
+	newest, _ := os.ReadFile(backups[1])
+	if string(newest) != "cccccccc\n" {
+		t.Errorf("newest backup = %q", newest)
+	}
+}
+
+func TestLoggerTo(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "access.log")
+	f, err := NewRotatingFile(RotateConfig{Filename: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sl := New()
+	sl.Use(LoggerTo(f))
+	sl.GET("/users", func(c *Context) {})
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	b, _ := os.ReadFile(name)
+	if !strings.Contains(string(b), "[ACCESS]") || !strings.Contains(string(b), "GET /users") {
+		t.Errorf("log = %q", b)
+	}
+}
@@ -0,0 +1,96 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// RouteDoc describes a route for documentation generators such as the
+// openapi package, which turns it into spec schemas. Sol itself does not
+// act on it.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	// Request is the type of the request body, nil if none was declared.
+	Request reflect.Type
+	// Responses maps status codes to response body types; a nil type
+	// declares a status without a body.
+	Responses map[int]reflect.Type
+	// Examples maps status codes to example response bodies.
+	Examples map[int]any
+}
+
+// Summary sets the one-line summary of the route:
+//
+//	sl.POST("/users", createUser).
+//		Summary("Create user").
+//		Request(CreateUserReq{}).
+//		Response(http.StatusCreated, UserResp{}).
+//		Response(http.StatusConflict, sol.ProblemDetails{})
+func (rt *Route) Summary(s string) *Route {
+	rt.doc.Summary = s
+	return rt
+}
+
+// Description sets the longer description of the route.
+func (rt *Route) Description(s string) *Route {
+	rt.doc.Description = s
+	return rt
+}
+
+// Request declares the type of the request body, given as a value or a
+// pointer.
+func (rt *Route) Request(obj any) *Route {
+	rt.doc.Request = docType(obj)
+	return rt
+}
+
+// Response declares the body type answered with status; obj may be nil
+// for responses without a body. It can be called once per status.
+func (rt *Route) Response(status int, obj any) *Route {
+	if status < 100 || status > 599 {
+		panic(fmt.Sprintf("cannot register '%s %s': invalid response status %d", rt.method, rt.path, status))
+	}
+	if rt.doc.Responses == nil {
+		rt.doc.Responses = make(map[int]reflect.Type)
+	}
+	rt.doc.Responses[status] = docType(obj)
+	return rt
+}
+
+// Example sets an example body answered with status. Besides
+// documenting the route, examples are served in place of NotImplemented
+// handlers when mocks are enabled, see Sol.WithMocks.
+func (rt *Route) Example(status int, body any) *Route {
+	if status < 100 || status > 599 {
+		panic(fmt.Sprintf("cannot register '%s %s': invalid example status %d", rt.method, rt.path, status))
+	}
+	if rt.doc.Examples == nil {
+		rt.doc.Examples = make(map[int]any)
+	}
+	rt.doc.Examples[status] = body
+	return rt
+}
+
+// Doc returns the route's annotations. Together with QueryType and
+// Routes it is all a generator needs to describe the API.
+func (rt *Route) Doc() RouteDoc {
+	doc := rt.doc
+	doc.Responses = maps.Clone(rt.doc.Responses)
+	doc.Examples = maps.Clone(rt.doc.Examples)
+	return doc
+}
+
+// docType returns the type of obj with pointers removed, or nil.
+func docType(obj any) reflect.Type {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -5,45 +5,211 @@
 package sol
 
 import (
+	"embed"
 	"fmt"
-	"maps"
+	"io/fs"
+	"log"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 )
 
 type router interface {
 	http.Handler
-	GET(path string, handlers ...HandlerFunc)
-	POST(path string, handlers ...HandlerFunc)
-	PUT(path string, handlers ...HandlerFunc)
-	DELETE(path string, handlers ...HandlerFunc)
-	PATCH(path string, handlers ...HandlerFunc)
-	OPTIONS(path string, handlers ...HandlerFunc)
-	HEAD(path string, handlers ...HandlerFunc)
+	GET(path string, handlers ...HandlerFunc) *Route
+	POST(path string, handlers ...HandlerFunc) *Route
+	PUT(path string, handlers ...HandlerFunc) *Route
+	DELETE(path string, handlers ...HandlerFunc) *Route
+	PATCH(path string, handlers ...HandlerFunc) *Route
+	OPTIONS(path string, handlers ...HandlerFunc) *Route
+	HEAD(path string, handlers ...HandlerFunc) *Route
 
 	Group(prefix string, middlewares ...HandlerFunc) *group
 	Use(middlewares ...HandlerFunc)
+	Prepend(middlewares ...HandlerFunc)
+	UseAfter(middlewares ...HandlerFunc)
 	NotFound(handler HandlerFunc)
-}
+	Routes() []*Route
+	Lookup(method, path string) (*Route, map[string]string)
+
+	StaticFS(prefix string, fsys fs.FS)
+	StaticEmbed(prefix string, efs embed.FS, root string)
+	SPA(efs embed.FS, root string, excludes ...string)
 
-// node represents a radix tree node.
-// https://en.wikipedia.org/wiki/Radix_tree
-type node struct {
-	children   map[string]*node
-	paramChild *node
-	handlers   []HandlerFunc
-	isEnd      bool
-	paramName  string
+	serverStats() *serverStats
 }
 
 // routerImpl router implementation
 type routerImpl struct {
-	// trees method -> root node
-	trees       map[string]*node
+	// matcher finds the route of a request, see Router
+	matcher Router
+	// methods that have routes, for 405 answers
+	methods     []string
+	middlewares []HandlerFunc
+	// after run once the chain is done, see UseAfter
+	after    []HandlerFunc
+	notFound HandlerFunc
+	pool     sync.Pool
+	stats    serverStats
+	// routes in registration order
+	routes []*Route
+
+	// chains are composed once, before the first request is served
+	freezeOnce sync.Once
+	frozen     bool
+}
+
+// Route is a registered route. It is returned by the registration
+// methods so per-route options can be chained onto it.
+//
+// The route only records its own handlers; the full chain is composed
+// from the engine and group middleware when the router is frozen, so
+// middleware added with Use after the route was registered still applies.
+// Chains run engine middleware first, then those of the outermost group
+// down to the route's own group, then route middleware such as Timeout,
+// then the handlers.
+type Route struct {
+	method string
+	path   string
+
+	router *routerImpl
+	// group the route was registered on, nil for engine routes
+	group *group
+	// middlewares are route level middleware such as Timeout
 	middlewares []HandlerFunc
-	notFound    HandlerFunc
-	pool        sync.Pool
+	handlers    []HandlerFunc
+	// skip holds the function names of engine/group middleware left out
+	skip []string
+	// composed is the full chain served, set when the router is frozen
+	composed []HandlerFunc
+	// templates is the template set of the route's group, nil for the engine's
+	templates *templateSet
+	// errorHandler is the error handler of the route's group, nil for the engine's
+	errorHandler ErrorHandler
+	// query is the struct type declared with Query
+	query reflect.Type
+	// raw is set by Raw
+	raw bool
+	// doc holds the annotations set by Summary, Request and Response
+	doc RouteDoc
+}
+
+// Method returns the HTTP method of the route.
+func (rt *Route) Method() string {
+	return rt.method
+}
+
+// Path returns the normalized path pattern of the route.
+func (rt *Route) Path() string {
+	return rt.path
+}
+
+// Handlers returns the names of the route's handler chain, middleware first.
+func (rt *Route) Handlers() []string {
+	chain := rt.composed
+	if !rt.router.frozen {
+		chain = rt.chain()
+	}
+
+	names := make([]string, len(chain))
+	for i, h := range chain {
+		names[i] = handlerName(h)
+	}
+	return names
+}
+
+// Timeout bounds the route's handling time, see Timeout.
+func (rt *Route) Timeout(d time.Duration) *Route {
+	rt.middlewares = append([]HandlerFunc{Timeout(d)}, rt.middlewares...)
+	rt.router.recompose(rt)
+	return rt
+}
+
+// Raw marks the route as raw: middleware that buffers, caches, rewrites
+// or captures bodies, such as Transform and CacheResponses, passes its
+// requests through untouched. Use it for reverse proxy and streaming
+// routes. Custom middleware can honor it with Context.IsRaw.
+func (rt *Route) Raw() *Route {
+	rt.raw = true
+	return rt
+}
+
+// Skip leaves engine and group middleware out of the route's chain, e.g.
+// keeping health checks out of access logs:
+//
+//	sl.GET("/healthz", health).Skip(sol.Logger)
+//
+// Each argument is either a middleware constructor such as Logger, which
+// skips every middleware it built, or a HandlerFunc previously passed to Use.
+// Middleware are told apart by function name, so closures from the same
+// function literal are skipped together.
+func (rt *Route) Skip(middlewares ...any) *Route {
+	for _, m := range middlewares {
+		v := reflect.ValueOf(m)
+		if v.Kind() != reflect.Func {
+			panic(fmt.Sprintf("cannot skip %T on '%s %s': not a function", m, rt.method, rt.path))
+		}
+		rt.skip = append(rt.skip, funcName(v.Pointer()))
+	}
+	rt.router.recompose(rt)
+	return rt
+}
+
+// skipped reports whether h was excluded with Skip.
+func (rt *Route) skipped(h HandlerFunc) bool {
+	if len(rt.skip) == 0 {
+		return false
+	}
+
+	name := funcName(reflect.ValueOf(h).Pointer())
+	for _, s := range rt.skip {
+		// Closures returned by a constructor are named "pkg.Ctor.funcN".
+		if name == s || strings.HasPrefix(name, s+".func") {
+			return true
+		}
+	}
+	return false
+}
+
+// compose installs the route's chain, template set and error handler.
+func (rt *Route) compose(chain []HandlerFunc) {
+	rt.composed = chain
+	if rt.group != nil {
+		rt.templates = rt.group.templateSet()
+		rt.errorHandler = rt.group.errorHandlerFor()
+	}
+}
+
+// chain composes the route's full handler chain.
+func (rt *Route) chain() []HandlerFunc {
+	var mids []HandlerFunc
+	if rt.group != nil {
+		mids = rt.group.collectMiddlewares()
+	} else {
+		mids = rt.router.middlewares
+	}
+
+	chain := make([]HandlerFunc, 0, len(mids)+len(rt.middlewares)+len(rt.handlers))
+	for _, m := range mids {
+		if !rt.skipped(m) {
+			chain = append(chain, m)
+		}
+	}
+	chain = append(chain, rt.middlewares...)
+	chain = append(chain, rt.handlers...)
+
+	if len(chain) > maxHandlers {
+		panic(fmt.Sprintf(
+			"cannot register '%s %s': %d handlers (middleware included) exceed the limit of %d",
+			rt.method, rt.path, len(chain), maxHandlers,
+		))
+	}
+	return chain
 }
 
 type group struct {
@@ -51,21 +217,20 @@ type group struct {
 	middlewares []HandlerFunc
 	parent      *group
 	router      *routerImpl
+	// templates are set with LoadTemplates
+	templates *templateSet
+	// errorHandler is set with WithErrorHandler
+	errorHandler ErrorHandler
 }
 
-func newRouter() router {
+func newRouter(engine *Sol, matcher Router) router {
 	r := &routerImpl{
-		trees: make(map[string]*node),
-		notFound: func(c *Context) {
-			c.Writer.WriteHeader(http.StatusNotFound)
-			c.Writer.Write([]byte("404 page not found\n"))
-		},
+		matcher:  matcher,
+		notFound: notFound,
 	}
 	r.pool.New = func() any {
-		return &Context{
-			params: make(map[string]string, 4),
-			data:   make(map[string]any, 10),
-		}
+		r.stats.ctxAllocs.Add(1)
+		return &Context{engine: engine}
 	}
 	return r
 }
@@ -86,156 +251,128 @@ func normalizePath(path string) string {
 		path = "/" + path
 	}
 
-	if path != "/" {
-		path = strings.TrimSuffix(path, "/")
+	// Trim spaces left before trailing slashes as well, so a normalized
+	// path normalizes to itself.
+	path = strings.TrimRightFunc(path, func(r rune) bool { return r == '/' || unicode.IsSpace(r) })
+	if path == "" {
+		return "/"
 	}
-
 	return path
 }
 
-func (r *routerImpl) getTree(method string) *node {
-	if r.trees[method] == nil {
-		r.trees[method] = &node{
-			children: make(map[string]*node),
-		}
+func (r *routerImpl) addRoute(method, path string, g *group, handlers []HandlerFunc) *Route {
+	rt := &Route{
+		method:   method,
+		path:     normalizePath(path),
+		router:   r,
+		group:    g,
+		handlers: handlers,
 	}
-	return r.trees[method]
-}
-
-func (r *routerImpl) insert(method, path string, combined []HandlerFunc) {
-	path = normalizePath(path)
-	root := r.getTree(method)
+	// Fail at registration rather than at the first request.
+	chain := rt.chain()
 
-	if path == "/" {
-		root.isEnd = true
-		root.handlers = combined
-		return
+	r.matcher.Add(rt)
+	if !slices.Contains(r.methods, method) {
+		r.methods = append(r.methods, method)
 	}
-
-	segments := strings.Split(path[1:], "/")
-	cur := root
-
-	for _, segment := range segments {
-		isParam := segment[0] == ':'
-		var child *node
-
-		if isParam {
-			paramName := segment[1:]
-			if cur.paramChild != nil {
-				if cur.paramChild.paramName != paramName {
-					panic(fmt.Sprintf(
-						"cannot register '%s': parameter name ':%s' conflicts with existing ':%s' in previously registered path",
-						path, paramName, cur.paramChild.paramName,
-					))
-				}
-			} else {
-				cur.paramChild = &node{
-					paramName: paramName,
-				}
-			}
-			child = cur.paramChild
-		} else {
-			if cur.children == nil {
-				cur.children = make(map[string]*node)
-			}
-
-			if _, ok := cur.children[segment]; !ok {
-				cur.children[segment] = &node{
-					children: make(map[string]*node),
-				}
-			}
-			child = cur.children[segment]
-		}
-
-		cur = child
+	r.routes = append(r.routes, rt)
+	if r.frozen {
+		rt.compose(chain)
 	}
-
-	// At this point, len(segments) must be greater than 0
-	cur.isEnd = true
-	cur.handlers = combined
+	return rt
 }
 
-func (r *routerImpl) search(method, path string) ([]HandlerFunc, map[string]string) {
-	path = normalizePath(path)
-	root := r.trees[method]
-	if root == nil {
-		return nil, nil
-	}
-
-	if path == "/" {
-		if root.isEnd {
-			return root.handlers, nil
-		}
-		return nil, nil
-	}
-
-	segments := strings.Split(path[1:], "/")
-	params := make(map[string]string)
-	cur := root
-
-	for _, segment := range segments {
-		if cur.children != nil {
-			if child, ok := cur.children[segment]; ok {
-				cur = child
-				continue
-			}
+// freeze composes the handler chain of every route. It runs once, before
+// the first request, so serving a request does no chain work at all.
+func (r *routerImpl) freeze() {
+	r.freezeOnce.Do(func() {
+		for _, rt := range r.routes {
+			rt.compose(rt.chain())
 		}
+		r.frozen = true
+	})
+}
 
-		if cur.paramChild != nil {
-			cur = cur.paramChild
-			params[cur.paramName] = segment
-			continue
-		}
-
-		return nil, nil
-	}
-
-	if cur.isEnd {
-		return cur.handlers, params
+// recompose refreshes a route's chain when it changes after freezing.
+func (r *routerImpl) recompose(rt *Route) {
+	if r.frozen {
+		rt.compose(rt.chain())
 	}
+}
 
-	return nil, nil
+// Routes returns the registered routes in registration order.
+func (r *routerImpl) Routes() []*Route {
+	return slices.Clone(r.routes)
 }
 
-func (r *routerImpl) addRoute(method, path string, middlewares, handlers []HandlerFunc) {
-	// If middlewares is nil, use an empty slice instead.
-	if middlewares == nil {
-		middlewares = []HandlerFunc{}
+// Lookup returns the route that serves method and path and its params,
+// or nil. It matches like a request would, without running handlers, so
+// tests and fuzz targets can check routing on its own.
+func (r *routerImpl) Lookup(method, path string) (*Route, map[string]string) {
+	req := &http.Request{Method: method, URL: &url.URL{Path: path}, Header: make(http.Header)}
+	var params map[string]string
+	rt := r.matcher.Match(method, req, &params)
+	if rt == nil {
+		return nil, nil
 	}
-
-	combined := make([]HandlerFunc, 0, len(middlewares)+len(handlers))
-	combined = append(combined, middlewares...)
-	combined = append(combined, handlers...)
-
-	r.insert(method, path, combined)
+	return rt, params
 }
 
-func (r *routerImpl) GET(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodGet, path, r.middlewares, h)
+func (r *routerImpl) GET(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodGet, path, nil, h)
 }
-func (r *routerImpl) POST(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodPost, path, r.middlewares, h)
+func (r *routerImpl) POST(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodPost, path, nil, h)
 }
-func (r *routerImpl) PUT(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodPut, path, r.middlewares, h)
+func (r *routerImpl) PUT(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodPut, path, nil, h)
 }
-func (r *routerImpl) DELETE(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodDelete, path, r.middlewares, h)
+func (r *routerImpl) DELETE(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodDelete, path, nil, h)
 }
-func (r *routerImpl) PATCH(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodPatch, path, r.middlewares, h)
+func (r *routerImpl) PATCH(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodPatch, path, nil, h)
 }
-func (r *routerImpl) OPTIONS(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodOptions, path, r.middlewares, h)
+func (r *routerImpl) OPTIONS(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodOptions, path, nil, h)
 }
-func (r *routerImpl) HEAD(path string, h ...HandlerFunc) {
-	r.addRoute(http.MethodHead, path, r.middlewares, h)
+func (r *routerImpl) HEAD(path string, h ...HandlerFunc) *Route {
+	return r.addRoute(http.MethodHead, path, nil, h)
 }
 
+// Use appends engine middleware. It applies to every route, including
+// those registered before the call, as long as it happens before the
+// first request is served.
 func (r *routerImpl) Use(m ...HandlerFunc) {
+	if r.frozen {
+		log.Printf("[WARN] Use called after serving started, middleware is ignored")
+		return
+	}
 	r.middlewares = append(r.middlewares, m...)
 }
 
+// Prepend inserts engine middleware ahead of those already added, for
+// middleware that must run first, e.g. a request ID before the logger.
+func (r *routerImpl) Prepend(m ...HandlerFunc) {
+	if r.frozen {
+		log.Printf("[WARN] Prepend called after serving started, middleware is ignored")
+		return
+	}
+	r.middlewares = append(slices.Clone(m), r.middlewares...)
+}
+
+// UseAfter appends trailing middleware. They run after the handler chain
+// of every request, matched or not, even when a handler aborted or
+// panicked, which code placed after c.Next() cannot rely on. They suit
+// logging and metrics; calling c.Next() from them is a no-op.
+func (r *routerImpl) UseAfter(m ...HandlerFunc) {
+	if r.frozen {
+		log.Printf("[WARN] UseAfter called after serving started, middleware is ignored")
+		return
+	}
+	r.after = append(r.after, m...)
+}
+
 func (r *routerImpl) Group(prefix string, m ...HandlerFunc) *group {
 	return &group{
 		prefix:      normalizePath(prefix),
@@ -245,14 +382,28 @@ func (r *routerImpl) Group(prefix string, m ...HandlerFunc) *group {
 }
 
 func (r *routerImpl) acquireCtx(w http.ResponseWriter, req *http.Request, h []HandlerFunc) *Context {
+	r.stats.ctxGets.Add(1)
 	ctx := r.pool.Get().(*Context)
-	ctx.Writer = w
+	ctx.rw.reset(w)
+	ctx.Writer = &ctx.rw
+	ctx.abortConn = false
 	ctx.Request = req
 	ctx.handlers = h
 	ctx.index = -1
 	ctx.aborted = false
-	clear(ctx.params)
-	clear(ctx.data)
+	ctx.clientGone = false
+	ctx.locale = ""
+	ctx.translate = nil
+	ctx.principal = nil
+	ctx.body = nil
+	ctx.bodyRead = false
+	ctx.bodyReported = false
+	ctx.queryCache = nil
+	ctx.route = nil
+	ctx.pattern = ""
+	ctx.templates = nil
+	ctx.errorHandler = nil
+	ctx.raw = false
 
 	return ctx
 }
@@ -260,49 +411,109 @@ func (r *routerImpl) acquireCtx(w http.ResponseWriter, req *http.Request, h []Ha
 func (r *routerImpl) releaseCtx(ctx *Context) {
 	ctx.handlers = nil
 	ctx.Writer = nil
+	ctx.rw.reset(nil)
 	ctx.Request = nil
+	// params and data are allocated on first use, drop them so idle
+	// pooled contexts stay small.
+	ctx.params = nil
+	ctx.data = nil
 	r.pool.Put(ctx)
 }
 
+func notFound(c *Context) {
+	c.AbortWithStatus(http.StatusNotFound)
+}
+
+// methodNotAllowed answers 405 with the Allow header listing the methods
+// registered for the path, or returns false when there are none.
+func (r *routerImpl) methodNotAllowed(c *Context) bool {
+	var allow []string
+	for _, method := range r.methods {
+		if method == c.Request.Method {
+			continue
+		}
+		var params map[string]string
+		if r.matcher.Match(method, c.Request, &params) != nil {
+			allow = append(allow, method)
+		}
+	}
+	if len(allow) == 0 {
+		return false
+	}
+
+	slices.Sort(allow)
+	c.Writer.Header().Set("Allow", strings.Join(allow, ", "))
+	c.AbortWithStatus(http.StatusMethodNotAllowed)
+	return true
+}
+
 func (r *routerImpl) NotFound(handler HandlerFunc) {
 	if handler == nil {
-		handler = func(c *Context) {
-			c.Writer.WriteHeader(http.StatusNotFound)
-			c.Writer.Write([]byte("404 page not found\n"))
-		}
+		handler = notFound
 	}
 	r.notFound = handler
 }
 
 func (r *routerImpl) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	handlers, params := r.search(req.Method, req.URL.Path)
-	if handlers == nil {
-		ctx := r.acquireCtx(w, req, []HandlerFunc{r.notFound})
-		ctx.Next()
-		r.releaseCtx(ctx)
-		return
+	r.freeze()
+	r.stats.requests.Add(1)
+	r.stats.inFlight.Add(1)
+	defer r.stats.inFlight.Add(-1)
+
+	ctx := r.acquireCtx(w, req, nil)
+
+	if rt := r.matcher.Match(req.Method, req, &ctx.params); rt != nil {
+		ctx.handlers = rt.composed
+		ctx.route = rt
+		ctx.pattern = rt.path
+		ctx.templates = rt.templates
+		ctx.errorHandler = rt.errorHandler
+		ctx.raw = rt.raw
+	} else if !r.methodNotAllowed(ctx) {
+		ctx.handlers = []HandlerFunc{r.notFound}
 	}
 
-	ctx := r.acquireCtx(w, req, handlers)
-	maps.Copy(ctx.params, params)
+	r.handle(ctx)
+	ctx.runDeferred()
+	ctx.reportServerError()
+	abort := ctx.abortConn
+	r.releaseCtx(ctx)
+
+	if abort {
+		// Tell net/http to drop the connection instead of finishing the
+		// response as if it were complete.
+		panic(http.ErrAbortHandler)
+	}
+}
 
+// handle runs the handler chain, then the trailing middleware.
+func (r *routerImpl) handle(ctx *Context) {
+	if len(r.after) > 0 {
+		defer func() {
+			for _, h := range r.after {
+				h(ctx)
+			}
+		}()
+	}
 	ctx.Next()
-	r.releaseCtx(ctx)
 }
 
+// collectMiddlewares returns the engine middleware followed by those of
+// each group from the outermost one down to g.
 func (g *group) collectMiddlewares() []HandlerFunc {
-	var mids []HandlerFunc
-	current := g
-	for current != nil {
-		mids = append(mids, current.middlewares...)
-		current = current.parent
+	var groups []*group
+	for current := g; current != nil; current = current.parent {
+		groups = append(groups, current)
 	}
 
-	mids = append(mids, g.router.middlewares...)
+	mids := slices.Clone(g.router.middlewares)
+	for _, current := range slices.Backward(groups) {
+		mids = append(mids, current.middlewares...)
+	}
 	return mids
 }
 
-func (g *group) add(method, path string, h ...HandlerFunc) {
+func (g *group) add(method, path string, h ...HandlerFunc) *Route {
 	fullPath := g.prefix
 	if path = normalizePath(path); path != "/" {
 		if !strings.HasSuffix(fullPath, "/") {
@@ -311,17 +522,119 @@ func (g *group) add(method, path string, h ...HandlerFunc) {
 		fullPath += strings.TrimPrefix(path, "/")
 	}
 
-	middlewares := g.collectMiddlewares()
-	g.router.addRoute(method, fullPath, middlewares, h)
+	return g.router.addRoute(method, fullPath, g, h)
+}
+
+func (g *group) GET(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodGet, path, h...)
+}
+func (g *group) POST(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodPost, path, h...)
+}
+func (g *group) PUT(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodPut, path, h...)
+}
+func (g *group) DELETE(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodDelete, path, h...)
+}
+func (g *group) PATCH(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodPatch, path, h...)
+}
+func (g *group) OPTIONS(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodOptions, path, h...)
+}
+func (g *group) HEAD(path string, h ...HandlerFunc) *Route {
+	return g.add(http.MethodHead, path, h...)
+}
+
+// Use appends group middleware. Like the engine's Use, it also applies
+// to routes registered on the group before the call.
+func (g *group) Use(m ...HandlerFunc) {
+	if g.router.frozen {
+		log.Printf("[WARN] Use called after serving started, middleware is ignored")
+		return
+	}
+	g.middlewares = append(g.middlewares, m...)
+}
+
+// Prepend inserts group middleware ahead of those already added to the
+// group. Engine and parent group middleware still run before them.
+func (g *group) Prepend(m ...HandlerFunc) {
+	if g.router.frozen {
+		log.Printf("[WARN] Prepend called after serving started, middleware is ignored")
+		return
+	}
+	g.middlewares = append(slices.Clone(m), g.middlewares...)
+}
+
+// Timeout bounds the handling time of routes registered on the group, see Timeout.
+func (g *group) Timeout(d time.Duration) *group {
+	if g.router.frozen {
+		log.Printf("[WARN] Timeout called after serving started, it is ignored")
+		return g
+	}
+	g.middlewares = append([]HandlerFunc{Timeout(d)}, g.middlewares...)
+	return g
+}
+
+// StaticFS serves the files of fsys under prefix within the group, behind
+// the group middleware.
+func (g *group) StaticFS(prefix string, fsys fs.FS) {
+	h := serveFS(fsys)
+	prefix = normalizePath(prefix)
+
+	g.GET(prefix+"/*filepath", h)
+	g.HEAD(prefix+"/*filepath", h)
+}
+
+// StaticEmbed serves the root directory of an embedded file system under
+// prefix within the group.
+func (g *group) StaticEmbed(prefix string, efs embed.FS, root string) {
+	g.StaticFS(prefix, subFS(efs, root))
+}
+
+// LoadTemplates gives the group and its subgroups a template set of their
+// own, isolated from the engine's: Context.Render in their handlers only
+// sees these pages and layouts. See Sol.LoadTemplates.
+func (g *group) LoadTemplates(cfg TemplateConfig) error {
+	if g.router.frozen {
+		return fmt.Errorf("templates: LoadTemplates called after serving started")
+	}
+	ts, err := newTemplateSet(cfg)
+	if err != nil {
+		return err
+	}
+	g.templates = ts
+	return nil
+}
+
+// WithErrorHandler replaces the engine error handler for errors reported
+// in routes of the group and its subgroups, e.g. ProblemErrorHandler for
+// an API next to HTML pages.
+func (g *group) WithErrorHandler(h ErrorHandler) *group {
+	g.errorHandler = h
+	return g
 }
 
-func (g *group) GET(path string, h ...HandlerFunc)     { g.add(http.MethodGet, path, h...) }
-func (g *group) POST(path string, h ...HandlerFunc)    { g.add(http.MethodPost, path, h...) }
-func (g *group) PUT(path string, h ...HandlerFunc)     { g.add(http.MethodPut, path, h...) }
-func (g *group) DELETE(path string, h ...HandlerFunc)  { g.add(http.MethodDelete, path, h...) }
-func (g *group) PATCH(path string, h ...HandlerFunc)   { g.add(http.MethodPatch, path, h...) }
-func (g *group) OPTIONS(path string, h ...HandlerFunc) { g.add(http.MethodOptions, path, h...) }
-func (g *group) HEAD(path string, h ...HandlerFunc)    { g.add(http.MethodHead, path, h...) }
+// errorHandlerFor returns the error handler of the nearest group that has one.
+func (g *group) errorHandlerFor() ErrorHandler {
+	for current := g; current != nil; current = current.parent {
+		if current.errorHandler != nil {
+			return current.errorHandler
+		}
+	}
+	return nil
+}
+
+// templateSet returns the templates of the nearest group that has some.
+func (g *group) templateSet() *templateSet {
+	for current := g; current != nil; current = current.parent {
+		if current.templates != nil {
+			return current.templates
+		}
+	}
+	return nil
+}
 
 func (g *group) Group(sub string, m ...HandlerFunc) *group {
 	newPrefix := g.prefix
@@ -6,8 +6,14 @@ package sol
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRouter_normalizePath(t *testing.T) {
@@ -28,6 +34,7 @@ func TestRouter_normalizePath(t *testing.T) {
 		{"/users/123", "/users/123"},
 		{"//home//////////////", "/home"},
 		{"/////////////////", "/"},
+		{"/users/ /", "/users"},
 	}
 
 	for _, tt := range tests {
@@ -60,3 +67,365 @@ func TestRouter_normalizePath(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_search(t *testing.T) {
+	tr := newTreeRouter()
+	r := newRouter(nil, tr).(*routerImpl)
+
+	routes := []string{
+		"/",
+		"/users",
+		"/users/new",
+		"/users/:id",
+		"/users/:id/posts",
+		"/users/:id/posts/:post",
+		"/uploads",
+		"/static/*filepath",
+		"/static/robots.txt",
+		"/files/:name/raw",
+		"/files/*rest",
+	}
+	for _, path := range routes {
+		p := path
+		r.GET(path, func(c *Context) { c.Set("route", p) })
+	}
+	r.freeze()
+
+	tests := []struct {
+		path   string
+		route  string
+		params map[string]string
+	}{
+		{"/", "/", map[string]string{}},
+		{"/users", "/users", map[string]string{}},
+		{"/users/", "/users", map[string]string{}},
+		{"/users/new", "/users/new", map[string]string{}},
+		{"/users/newer", "/users/:id", map[string]string{"id": "newer"}},
+		{"/users/42", "/users/:id", map[string]string{"id": "42"}},
+		{"/users/42/posts", "/users/:id/posts", map[string]string{"id": "42"}},
+		{"/users/42/posts/7", "/users/:id/posts/:post", map[string]string{"id": "42", "post": "7"}},
+		{"/uploads", "/uploads", map[string]string{}},
+		{"/static/robots.txt", "/static/robots.txt", map[string]string{}},
+		{"/static/css/app.css", "/static/*filepath", map[string]string{"filepath": "css/app.css"}},
+		{"/files/a/raw", "/files/:name/raw", map[string]string{"name": "a"}},
+		{"/files/a/b", "/files/*rest", map[string]string{"rest": "a/b"}},
+		{"/user", "", nil},
+		{"/users/42/comments", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var params map[string]string
+			rt := tr.search(http.MethodGet, tt.path, &params)
+			if params == nil {
+				params = map[string]string{}
+			}
+
+			if tt.route == "" {
+				if rt != nil {
+					t.Fatalf("expected no match, got one")
+				}
+				return
+			}
+			if rt == nil {
+				t.Fatalf("expected match for %s", tt.route)
+			}
+
+			c := &Context{}
+			rt.composed[0](c)
+			if got, _ := c.GetString("route"); got != tt.route {
+				t.Errorf("matched %q, want %q", got, tt.route)
+			}
+			if !maps.Equal(params, tt.params) {
+				t.Errorf("params = %v, want %v", params, tt.params)
+			}
+		})
+	}
+}
+
+func TestRouter_paramConflict(t *testing.T) {
+	r := newRouter(nil, newTreeRouter()).(*routerImpl)
+	r.GET("/users/:id", func(c *Context) {})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for conflicting parameter names")
+		}
+	}()
+	r.GET("/users/:name/posts", func(c *Context) {})
+}
+
+func BenchmarkRouter_search(b *testing.B) {
+	tr := newTreeRouter()
+	r := newRouter(nil, tr).(*routerImpl)
+	for _, path := range []string{
+		"/api/v1/users",
+		"/api/v1/users/:id",
+		"/api/v1/users/:id/posts",
+		"/api/v1/orders/:id/items/:item",
+		"/api/v1/settings/profile/notifications/email",
+	} {
+		r.GET(path, func(c *Context) {})
+	}
+	r.freeze()
+	var params map[string]string
+
+	b.Run("static", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			tr.search(http.MethodGet, "/api/v1/settings/profile/notifications/email", &params)
+		}
+	})
+	b.Run("params", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			clear(params)
+			tr.search(http.MethodGet, "/api/v1/orders/42/items/7", &params)
+		}
+	})
+}
+
+func TestRouter_longHandlerChain(t *testing.T) {
+	sl := New()
+
+	calls := 0
+	chain := make([]HandlerFunc, 300)
+	for i := range chain {
+		chain[i] = func(c *Context) { calls++ }
+	}
+	sl.GET("/long", chain...)
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/long", nil))
+	if calls != len(chain) {
+		t.Errorf("calls = %d, want %d", calls, len(chain))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for handler chain over the limit")
+		}
+	}()
+	sl.GET("/too-long", make([]HandlerFunc, maxHandlers+1)...)
+}
+
+func TestRouter_lateMiddleware(t *testing.T) {
+	sl := New()
+
+	var order []string
+	mw := func(name string) HandlerFunc {
+		return func(c *Context) { order = append(order, name) }
+	}
+
+	sl.GET("/a", mw("handler"))
+	api := sl.Group("/api")
+	api.GET("/b", mw("handler"))
+
+	// Registered after the routes, still applied to them.
+	sl.Use(mw("engine"))
+	api.Use(mw("group"))
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/a", []string{"engine", "handler"}},
+		{"/api/b", []string{"engine", "group", "handler"}},
+	}
+	for _, tt := range tests {
+		order = nil
+		sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if !slices.Equal(order, tt.want) {
+			t.Errorf("%s: order = %v, want %v", tt.path, order, tt.want)
+		}
+	}
+
+	// Once serving started the chains are frozen.
+	sl.Use(mw("late"))
+	order = nil
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/a", nil))
+	if !slices.Equal(order, []string{"engine", "handler"}) {
+		t.Errorf("order after freeze = %v", order)
+	}
+}
+
+func countingMiddleware(calls *int) HandlerFunc {
+	return func(c *Context) {
+		*calls++
+		c.Next()
+	}
+}
+
+func TestRoute_Skip(t *testing.T) {
+	sl := New()
+
+	var counted, other int
+	sl.Use(countingMiddleware(&counted))
+	direct := func(c *Context) { other++ }
+	sl.Use(direct)
+
+	sl.GET("/healthz", func(c *Context) {}).Skip(countingMiddleware)
+	sl.GET("/metrics", func(c *Context) {}).Skip(direct)
+	sl.GET("/users", func(c *Context) {})
+
+	for _, path := range []string{"/healthz", "/metrics", "/users"} {
+		sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if counted != 2 {
+		t.Errorf("countingMiddleware ran %d times, want 2", counted)
+	}
+	if other != 2 {
+		t.Errorf("direct middleware ran %d times, want 2", other)
+	}
+}
+
+func TestRoute_Raw(t *testing.T) {
+	sl := New()
+	sl.Use(CacheResponses(time.Minute))
+	sl.Use(Transform(func(c *Context, res *BufferedResponse) {
+		res.Body.WriteString(" (transformed)")
+	}))
+
+	var raw bool
+	handler := func(c *Context) {
+		raw = c.IsRaw()
+		c.String(http.StatusOK, "body")
+	}
+	sl.GET("/proxy", handler).Raw()
+	sl.GET("/page", handler)
+
+	tests := []struct {
+		path, body, cache string
+		raw               bool
+	}{
+		{"/proxy", "body", "", true},
+		{"/page", "body (transformed)", "MISS", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Body.String() != tt.body || rec.Header().Get("X-Cache") != tt.cache || raw != tt.raw {
+			t.Errorf("%s: body %q, X-Cache %q, IsRaw %v", tt.path, rec.Body.String(), rec.Header().Get("X-Cache"), raw)
+		}
+	}
+}
+
+func TestRoute_Doc(t *testing.T) {
+	type createUserReq struct{ Name string }
+	type userResp struct{ ID int }
+
+	sl := New()
+	rt := sl.POST("/users", func(c *Context) {}).
+		Summary("Create user").
+		Description("Creates a user account.").
+		Request(&createUserReq{}).
+		Response(http.StatusCreated, userResp{}).
+		Response(http.StatusNoContent, nil)
+
+	doc := rt.Doc()
+	if doc.Summary != "Create user" || doc.Description != "Creates a user account." {
+		t.Errorf("doc = %+v", doc)
+	}
+	if doc.Request != reflect.TypeFor[createUserReq]() {
+		t.Errorf("Request = %v", doc.Request)
+	}
+	if doc.Responses[http.StatusCreated] != reflect.TypeFor[userResp]() || doc.Responses[http.StatusNoContent] != nil || len(doc.Responses) != 2 {
+		t.Errorf("Responses = %v", doc.Responses)
+	}
+
+	delete(doc.Responses, http.StatusCreated)
+	if len(rt.Doc().Responses) != 2 {
+		t.Error("modifying Doc changed the route")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Response(0) did not panic")
+		}
+	}()
+	rt.Response(0, nil)
+}
+
+func TestRouter_UseAfter(t *testing.T) {
+	sl := New()
+
+	var ran []string
+	sl.UseAfter(func(c *Context) {
+		ran = append(ran, c.Path())
+	})
+	sl.GET("/ok", func(c *Context) {})
+	sl.GET("/abort", func(c *Context) { c.AbortWithStatus(http.StatusForbidden) }, func(c *Context) {})
+	sl.GET("/panic", func(c *Context) { panic("boom") })
+
+	for _, path := range []string{"/ok", "/abort", "/panic", "/missing"} {
+		sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if want := []string{"/ok", "/abort", "/panic", "/missing"}; !slices.Equal(ran, want) {
+		t.Errorf("ran = %v, want %v", ran, want)
+	}
+}
+
+// foldRouter matches exact paths case-insensitively.
+type foldRouter map[string]*Route
+
+func (f foldRouter) Add(rt *Route) {
+	f[rt.Method()+" "+strings.ToLower(rt.Path())] = rt
+}
+
+func (f foldRouter) Match(method string, req *http.Request, params *map[string]string) *Route {
+	return f[method+" "+strings.ToLower(req.URL.Path)]
+}
+
+func TestNewWithRouter(t *testing.T) {
+	sl := NewWithRouter(foldRouter{})
+
+	var calls int
+	sl.Use(countingMiddleware(&calls))
+	sl.GET("/Users", func(c *Context) { c.String(http.StatusOK, "%s", c.RoutePattern()) })
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "/users", http.StatusOK, "/Users"},
+		{http.MethodGet, "/USERS", http.StatusOK, "/Users"},
+		{http.MethodPost, "/users", http.StatusMethodNotAllowed, "Method Not Allowed\n"},
+		{http.MethodGet, "/missing", http.StatusNotFound, "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("%s %s: got %d %q, want %d %q", tt.method, tt.path, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("middleware ran %d times, want 2", calls)
+	}
+}
+
+func TestRouter_middlewareOrder(t *testing.T) {
+	sl := New()
+
+	var order []string
+	mw := func(name string) HandlerFunc {
+		return func(c *Context) { order = append(order, name) }
+	}
+
+	api := sl.Group("/api", mw("api"))
+	v1 := api.Group("/v1", mw("v1"))
+	v1.GET("/users", mw("handler"))
+
+	sl.Use(mw("logger"))
+	sl.Prepend(mw("request-id"))
+	v1.Prepend(mw("v1-first"))
+
+	sl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+	want := []string{"request-id", "logger", "api", "v1-first", "v1", "handler"}
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
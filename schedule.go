@@ -0,0 +1,214 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every runs fn every interval until the server shuts down.
+// Runs never overlap: a slow run delays the next one.
+func (sl *Sol) Every(interval time.Duration, fn func(ctx context.Context)) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("invalid schedule interval %v", interval))
+	}
+
+	sl.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runJob(ctx, "every "+interval.String(), fn)
+			}
+		}
+	})
+}
+
+// Cron runs fn on a standard five field cron schedule
+// ("minute hour day-of-month month day-of-week", local time) until the
+// server shuts down. It panics if spec is invalid.
+func (sl *Sol) Cron(spec string, fn func(ctx context.Context)) {
+	sched, err := ParseCron(spec)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	sl.Go(func(ctx context.Context) {
+		for {
+			next := sched.Next(time.Now())
+			if next.IsZero() {
+				log.Printf("[WARN] cron %s: no next run, stopping", spec)
+				return
+			}
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				runJob(ctx, "cron "+spec, fn)
+			}
+		}
+	})
+}
+
+// runJob runs a scheduled job, logging panics instead of stopping the schedule.
+func runJob(ctx context.Context, name string, fn func(ctx context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("[PANIC] job %s: %v", name, err)
+		}
+	}()
+
+	fn(ctx)
+}
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record unrestricted fields, since
+	// day-of-month and day-of-week match if either one does.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, 0 and 7 are Sunday
+}
+
+// ParseCron parses a five field cron expression. Fields accept "*",
+// numbers, ranges ("1-5"), steps ("*/15", "0-30/10") and lists ("1,15").
+func ParseCron(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron spec '%s': expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron spec '%s': %v", spec, err)
+		}
+		bits[i] = b
+	}
+
+	// Fold Sunday as 7 into 0.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	// Like cron, a stepped star such as "*/2" still counts as a star.
+	s := &CronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	// Valid fields can still describe no date at all, e.g. February 30.
+	if s.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid cron spec '%s': never matches", spec)
+	}
+	return s, nil
+}
+
+func parseCronField(field string, fr cronField) (uint64, error) {
+	var bits uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", part)
+			}
+			step = n
+		}
+
+		lo, hi := fr.min, fr.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("invalid value '%s'", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("invalid value '%s'", part)
+				}
+			} else if hasStep {
+				hi = fr.max
+			}
+		}
+
+		if lo < fr.min || hi > fr.max || lo > hi {
+			return 0, fmt.Errorf("value '%s' out of range %d-%d", part, fr.min, fr.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after t that matches the schedule.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Any valid schedule matches within five years (leap days included).
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domOK := s.dom&(1<<uint(t.Day())) != 0
+	dowOK := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
@@ -0,0 +1,51 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, time.January, 30, 10, 17, 42, 0, time.UTC) // Friday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 30, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 30, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 1, 31, 3, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"30 8 1 * 7", time.Date(2026, 2, 1, 8, 30, 0, 0, time.UTC)},
+		{"0 3 */2 * 1", time.Date(2026, 2, 9, 3, 0, 0, 0, time.UTC)},
+		{"0 3 13 * */7", time.Date(2026, 9, 13, 3, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if got := s.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 30 2 *", "0 0 31 4,6 *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) expected error", spec)
+		}
+	}
+	if _, err := ParseCron("0 0 29 2 *"); err != nil {
+		t.Errorf("leap day rejected: %v", err)
+	}
+}
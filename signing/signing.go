@@ -0,0 +1,264 @@
+// Package signing
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+var (
+	ErrMissingSignature = errors.New("signing: missing signature")
+	ErrInvalidSignature = errors.New("signing: invalid signature")
+	ErrUnknownKey       = errors.New("signing: unknown key")
+	ErrInvalidTimestamp = errors.New("signing: invalid timestamp")
+	ErrExpired          = errors.New("signing: timestamp outside tolerance")
+	ErrReplayed         = errors.New("signing: nonce already used")
+	ErrBodyTooLarge     = errors.New("signing: body too large")
+)
+
+// DefaultTolerance is the accepted clock skew between services.
+const DefaultTolerance = 5 * time.Minute
+
+// DefaultMaxBody bounds the body read to check a signature.
+const DefaultMaxBody = 10 << 20
+
+// Headers carrying the signature.
+const (
+	KeyIDHeader     = "X-Key-Id"
+	TimestampHeader = "X-Timestamp"
+	NonceHeader     = "X-Nonce"
+	SignatureHeader = "X-Signature"
+)
+
+// keyIDKey is the Context key holding the verified key ID.
+const keyIDKey = "sol.signing.key"
+
+// Signer signs outgoing requests with HMAC-SHA256.
+type Signer struct {
+	// KeyID names the secret, so the receiver can rotate keys.
+	KeyID  string
+	Secret string
+}
+
+// Sign adds the signature headers to req. The body is read and replaced,
+// so Sign must be called after the body is set.
+func (s *Signer) Sign(req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("signing: read body: %w", err)
+		}
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("signing: generate nonce: %w", err)
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	n := hex.EncodeToString(nonce)
+	req.Header.Set(KeyIDHeader, s.KeyID)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(NonceHeader, n)
+	req.Header.Set(SignatureHeader, sign(s.Secret, req.Method, req.URL.RequestURI(), ts, n, body))
+	return nil
+}
+
+// Transport returns a RoundTripper signing every request before passing
+// it to base, http.DefaultTransport if nil.
+func (s *Signer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		if err := s.Sign(req); err != nil {
+			return nil, err
+		}
+		return base.RoundTrip(req)
+	})
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// sign returns the hex signature over method, request URI, timestamp,
+// nonce and the body hash, one per line.
+func sign(secret, method, uri, ts, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%x", method, uri, ts, nonce, sum)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Config configures the verifying middleware.
+type Config struct {
+	// Keys maps key IDs to secrets. Keeping the old key during a rotation
+	// lets callers switch at their own pace.
+	Keys map[string]string
+	// Tolerance bounds the age of the timestamp, DefaultTolerance if zero.
+	Tolerance time.Duration
+	// Nonces remembers used nonces, an in-memory cache by default.
+	// Instances behind a load balancer need a shared one.
+	Nonces NonceStore
+	// MaxBody bounds the body hashed before the signature is checked,
+	// DefaultMaxBody if zero, so unsigned callers cannot exhaust memory.
+	MaxBody int64
+}
+
+// NonceStore records nonces to reject replays.
+type NonceStore interface {
+	// Use records nonce until expires and reports whether it was unused.
+	Use(nonce string, expires time.Time) bool
+}
+
+// Verifier checks signed requests.
+type Verifier struct {
+	cfg Config
+}
+
+// NewVerifier returns a Verifier for cfg.
+func NewVerifier(cfg Config) *Verifier {
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = DefaultTolerance
+	}
+	if cfg.Nonces == nil {
+		cfg.Nonces = NewMemoryNonces()
+	}
+	if cfg.MaxBody <= 0 {
+		cfg.MaxBody = DefaultMaxBody
+	}
+	return &Verifier{cfg: cfg}
+}
+
+// Verify checks the signature of the request and consumes its nonce.
+// It returns the ID of the key that signed it. Bodies over MaxBody fail
+// with ErrBodyTooLarge.
+func (v *Verifier) Verify(c *sol.Context) (string, error) {
+	keyID := c.Header(KeyIDHeader)
+	ts, nonce, sig := c.Header(TimestampHeader), c.Header(NonceHeader), c.Header(SignatureHeader)
+	if sig == "" || nonce == "" {
+		return "", ErrMissingSignature
+	}
+	secret, ok := v.cfg.Keys[keyID]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", ErrInvalidTimestamp
+	}
+	signedAt := time.Unix(sec, 0)
+	now := time.Now()
+	if d := now.Sub(signedAt); d > v.cfg.Tolerance || d < -v.cfg.Tolerance {
+		return "", ErrExpired
+	}
+
+	if c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, v.cfg.MaxBody)
+	}
+	body, err := c.Body()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", ErrBodyTooLarge
+		}
+		return "", fmt.Errorf("signing: read body: %w", err)
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	expected, _ := hex.DecodeString(sign(secret, c.Method(), c.Request.URL.RequestURI(), ts, nonce, body))
+	if !hmac.Equal(got, expected) {
+		return "", ErrInvalidSignature
+	}
+
+	// Past the tolerance the timestamp check rejects the request anyway.
+	if !v.cfg.Nonces.Use(keyID+":"+nonce, signedAt.Add(v.cfg.Tolerance)) {
+		return "", ErrReplayed
+	}
+	return keyID, nil
+}
+
+// Middleware rejects requests that are not correctly signed with 401.
+func Middleware(cfg Config) sol.HandlerFunc {
+	v := NewVerifier(cfg)
+	return func(c *sol.Context) {
+		keyID, err := v.Verify(c)
+		if errors.Is(err, ErrBodyTooLarge) {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			log.Printf("[WARN] %v | %s %s", err, c.Method(), c.Path())
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+		c.Set(keyIDKey, keyID)
+		c.Next()
+	}
+}
+
+// KeyID returns the ID of the key that signed the request, or "".
+func KeyID(c *sol.Context) string {
+	id, _ := c.GetString(keyIDKey)
+	return id
+}
+
+// MemoryNonces is an in-memory NonceStore. Expired nonces are swept as
+// new ones come in.
+type MemoryNonces struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewMemoryNonces returns an empty MemoryNonces.
+func NewMemoryNonces() *MemoryNonces {
+	return &MemoryNonces{nonces: make(map[string]time.Time)}
+}
+
+func (m *MemoryNonces) Use(nonce string, expires time.Time) bool {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.After(m.nextSweep) {
+		for n, exp := range m.nonces {
+			if now.After(exp) {
+				delete(m.nonces, n)
+			}
+		}
+		m.nextSweep = now.Add(time.Minute)
+	}
+
+	if exp, ok := m.nonces[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	m.nonces[nonce] = expires
+	return true
+}
@@ -0,0 +1,119 @@
+// Package signing
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package signing
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestSignedRequests(t *testing.T) {
+	sl := sol.New()
+	sl.Use(Middleware(Config{Keys: map[string]string{"billing": "s3cret"}}))
+	charge := func(c *sol.Context) {
+		body, _ := c.Body()
+		c.String(http.StatusOK, "%s %s", KeyID(c), body)
+	}
+	sl.POST("/charges", charge)
+	sl.PUT("/charges", charge)
+
+	signer := &Signer{KeyID: "billing", Secret: "s3cret"}
+	newReq := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/charges?id=1", strings.NewReader(`{"amount":5}`))
+		if err := signer.Sign(req); err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	req := newReq()
+	replay := req.Clone(req.Context())
+	replay.Body = io.NopCloser(strings.NewReader(`{"amount":5}`))
+	if rec := do(req); rec.Code != http.StatusOK || rec.Body.String() != `billing {"amount":5}` {
+		t.Fatalf("signed request: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(replay); rec.Code != http.StatusUnauthorized {
+		t.Errorf("replayed request: status = %d, want 401", rec.Code)
+	}
+
+	tamper := map[string]func(*http.Request){
+		"body":     func(r *http.Request) { r.Body = io.NopCloser(strings.NewReader(`{"amount":500}`)) },
+		"path":     func(r *http.Request) { r.URL.RawQuery = "id=2" },
+		"method":   func(r *http.Request) { r.Method = http.MethodPut },
+		"key":      func(r *http.Request) { r.Header.Set(KeyIDHeader, "other") },
+		"unsigned": func(r *http.Request) { r.Header.Del(SignatureHeader) },
+		"timestamp": func(r *http.Request) {
+			r.Header.Set(TimestampHeader, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+		},
+	}
+	for name, fn := range tamper {
+		req := newReq()
+		fn(req)
+		if rec := do(req); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s tampered: status = %d, want 401", name, rec.Code)
+		}
+	}
+}
+
+func TestSignerTransport(t *testing.T) {
+	v := NewVerifier(Config{Keys: map[string]string{"k1": "secret"}})
+	sl := sol.New()
+	sl.POST("/", func(c *sol.Context) {
+		if _, err := v.Verify(c); err != nil {
+			c.String(http.StatusUnauthorized, "%v", err)
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	srv := httptest.NewServer(sl)
+	defer srv.Close()
+
+	client := &http.Client{Transport: (&Signer{KeyID: "k1", Secret: "secret"}).Transport(nil)}
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, body = %s", resp.StatusCode, body)
+	}
+}
+
+func TestVerifyMaxBody(t *testing.T) {
+	sl := sol.New()
+	sl.Use(Middleware(Config{Keys: map[string]string{"k1": "secret"}, MaxBody: 16}))
+	sl.POST("/", func(c *sol.Context) { c.String(http.StatusOK, "ok") })
+
+	signer := &Signer{KeyID: "k1", Secret: "secret"}
+	for _, tt := range []struct {
+		body   string
+		status int
+	}{
+		{"small", http.StatusOK},
+		{strings.Repeat("x", 17), http.StatusRequestEntityTooLarge},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+		if err := signer.Sign(req); err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%d byte body: status = %d, want %d", len(tt.body), rec.Code, tt.status)
+		}
+	}
+}
@@ -7,13 +7,16 @@ package sol
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"html/template"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -23,22 +26,75 @@ type Sol struct {
 	server   *http.Server
 	stop     chan struct{}
 	stopOnce sync.Once
+	running  atomic.Bool
+	// stopped is closed once a Run shutdown completes with stopErr
+	stopped   chan struct{}
+	stopErr   error
+	conns     Connections
+	opts      Options
+	templates *templateSet
+	tasks     *taskRunner
+
+	events     *EventBus
+	eventsOnce sync.Once
+
+	cache     *ResponseCache
+	cacheOnce sync.Once
+
+	admin *admin
+
+	// capture configures the request bodies attached to error reports
+	capture *bodyCapture
+	// reporter receives server errors, see WithErrorReporter
+	reporter ErrorReporter
+	// mocks serves examples for NotImplemented routes, see WithMocks
+	mocks *mockSource
+
+	// errorPage renders default error responses for browsers
+	errorPage *template.Template
+	// errorHandler answers errors reported with Context.Error
+	errorHandler ErrorHandler
+}
+
+// Timeouts holds the timeouts applied to the underlying http.Server.
+// A zero value means no timeout, following http.Server semantics.
+type Timeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+// DefaultTimeouts are the timeouts used by New.
+var DefaultTimeouts = Timeouts{
+	ReadHeader: 10 * time.Second,
+	Read:       30 * time.Second,
+	Write:      30 * time.Second,
+	Idle:       90 * time.Second,
 }
 
 func New() *Sol {
-	router := newRouter()
+	return NewWithRouter(newTreeRouter())
+}
+
+// NewWithRouter is New with a custom Router matching requests to routes.
+// Registration, middleware, Context and the server work as with New.
+func NewWithRouter(r Router) *Sol {
+	if r == nil {
+		panic("sol: NewWithRouter called with a nil Router")
+	}
+
 	sl := &Sol{
-		router: router,
-		stop:   make(chan struct{}),
-		server: &http.Server{
-			ReadHeaderTimeout: 10 * time.Second,
-			ReadTimeout:       30 * time.Second,
-			WriteTimeout:      30 * time.Second,
-			IdleTimeout:       90 * time.Second,
-		},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		server:  &http.Server{},
+		tasks:   newTaskRunner(),
 	}
+	sl.router = newRouter(sl, r)
+	sl.WithTimeouts(DefaultTimeouts)
 
 	sl.server.Handler = sl
+	sl.hookConnState(sl.server)
 	sl.Use(Recover())
 
 	return sl
@@ -49,11 +105,23 @@ func (sl *Sol) WithLogger() *Sol {
 	return sl
 }
 
+// WithTimeouts replaces the server timeouts.
+// Endpoints that stream (SSE, long downloads) can instead lift the write
+// timeout per request with Context.SetWriteDeadline or the WriteTimeout middleware.
+func (sl *Sol) WithTimeouts(t Timeouts) *Sol {
+	sl.server.ReadHeaderTimeout = t.ReadHeader
+	sl.server.ReadTimeout = t.Read
+	sl.server.WriteTimeout = t.Write
+	sl.server.IdleTimeout = t.Idle
+	return sl
+}
+
 func (sl *Sol) WithServer(server *http.Server) *Sol {
 	if server != nil {
 		if server.Handler == nil {
 			server.Handler = sl
 		}
+		sl.hookConnState(server)
 		sl.server = server
 	}
 	return sl
@@ -81,20 +149,37 @@ func formatListenURL(addr string, isTLS bool) string {
 	return fmt.Sprintf("%s://%s:%s", scheme, host, port)
 }
 
+// Run serves until SIGINT, SIGTERM or Stop. The address is addr, else
+// Options.Addr, else SOL_ADDR, else ":23719". With a TLS certificate in
+// the Options, it serves HTTPS like RunTLS.
 func (sl *Sol) Run(addr ...string) {
-	runAddr := ":23719"
-
+	var runAddr string
 	if len(addr) > 0 && addr[0] != "" {
 		runAddr = addr[0]
+	} else if sl.opts.Addr != "" {
+		runAddr = sl.opts.Addr
 	} else if env := os.Getenv("SOL_ADDR"); env != "" {
 		runAddr = env
 	}
 
+	if sl.opts.TLSCert != "" {
+		sl.RunTLS(runAddr, sl.opts.TLSCert, sl.opts.TLSKey)
+		return
+	}
+	if runAddr == "" {
+		runAddr = ":23719"
+	}
+
 	sl.server.Addr = runAddr
+	sl.running.Store(true)
+	ln, err := sl.listen(runAddr)
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
 	log.Printf("🌌 Sol starting on %s", formatListenURL(runAddr, false))
 
 	go func() {
-		if err := sl.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := sl.server.Serve(ln); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server error: %v", err)
 		}
 	}()
@@ -102,6 +187,26 @@ func (sl *Sol) Run(addr ...string) {
 	sl.waitStopSignal()
 }
 
+// Serve serves on l until Shutdown, without installing signal handlers,
+// for applications that embed sol and own the process lifecycle. It
+// returns nil once shut down.
+func (sl *Sol) Serve(l net.Listener) error {
+	if err := sl.server.Serve(sl.limitListener(l)); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Start listens on the TCP address addr and serves like Serve.
+func (sl *Sol) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	sl.server.Addr = addr
+	return sl.Serve(ln)
+}
+
 func (sl *Sol) RunTLS(addr, certFile, keyFile string) {
 	if addr == "" {
 		addr = ":443"
@@ -112,10 +217,15 @@ func (sl *Sol) RunTLS(addr, certFile, keyFile string) {
 		MinVersion: tls.VersionTLS12,
 	}
 
+	sl.running.Store(true)
+	ln, err := sl.listen(addr)
+	if err != nil {
+		log.Fatalf("TLS Server error: %v", err)
+	}
 	log.Printf("🌌 Sol starting on %s", formatListenURL(addr, true))
 
 	go func() {
-		if err := sl.server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		if err := sl.server.ServeTLS(ln, certFile, keyFile); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("TLS Server error: %v", err)
 		}
 	}()
@@ -126,6 +236,7 @@ func (sl *Sol) RunTLS(addr, certFile, keyFile string) {
 func (sl *Sol) waitStopSignal() {
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
 
 	select {
 	case <-sl.stop:
@@ -139,13 +250,84 @@ func (sl *Sol) waitStopSignal() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := sl.server.Shutdown(ctx); err != nil {
+	sl.stopErr = sl.shutdown(ctx)
+	close(sl.stopped)
+}
+
+// shutdown drains and stops the server, then waits for background tasks.
+func (sl *Sol) shutdown(ctx context.Context) error {
+	if d := sl.conns.DrainDelay; d > 0 {
+		log.Printf("Draining connections for %v...", d)
+		sl.server.SetKeepAlivesEnabled(false)
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+		}
+	}
+
+	st := sl.serverStats()
+	if n := st.connsActive.Load(); n > 0 {
+		log.Printf("Waiting on %d active connections...", n)
+	}
+	stopReport := sl.reportDraining(ctx, 5*time.Second)
+	err := sl.server.Shutdown(ctx)
+	stopReport()
+	if err != nil {
 		log.Printf("Forced shutdown: %v", err)
 	} else {
 		log.Println("Server stopped gracefully.")
 	}
+
+	if terr := sl.tasks.shutdown(ctx); terr != nil {
+		log.Printf("Background tasks did not finish: %v", terr)
+		err = errors.Join(err, terr)
+	}
+	return err
+}
+
+// reportDraining logs the connections still active every interval until
+// the returned func is called or ctx is done.
+func (sl *Sol) reportDraining(ctx context.Context, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				st := sl.serverStats()
+				log.Printf("Still waiting on %d active connections (%d new, %d idle)...",
+					st.connsActive.Load(), st.connsNew.Load(), st.connsIdle.Load())
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Shutdown stops the server and returns once it is fully stopped:
+// in-flight requests have completed and background tasks have finished.
+// It returns ctx.Err() if ctx is done first. A server started with Run or
+// RunTLS goes on shutting down within its own 30 second limit; one
+// started with Serve or Start is shut down within ctx.
+func (sl *Sol) Shutdown(ctx context.Context) error {
+	if !sl.running.Load() {
+		return sl.shutdown(ctx)
+	}
+	sl.Stop()
+	select {
+	case <-sl.stopped:
+		return sl.stopErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
+// Stop asks a running server to shut down and returns immediately. Use
+// Shutdown to wait for it.
 func (sl *Sol) Stop() {
 	sl.stopOnce.Do(func() {
 		close(sl.stop)
@@ -0,0 +1,118 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	sl := New()
+	started, release := make(chan struct{}), make(chan struct{})
+	var finished bool
+	sl.GET("/slow", func(c *Context) {
+		close(started)
+		<-release
+		finished = true
+		c.String(http.StatusOK, "done")
+	})
+
+	runDone := make(chan struct{})
+	go func() {
+		sl.Run(addr)
+		close(runDone)
+	}()
+
+	go func() {
+		for {
+			resp, err := http.Get("http://" + addr + "/slow")
+			if err == nil {
+				resp.Body.Close()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request never reached the handler")
+	}
+
+	// Shutdown gives up when its context ends first...
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sl.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Shutdown with in-flight request = %v, want DeadlineExceeded", err)
+	}
+
+	// ...and otherwise waits for the in-flight request.
+	close(release)
+	if err := sl.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+	if !finished {
+		t.Error("Shutdown returned before the in-flight request finished")
+	}
+	select {
+	case <-runDone:
+	case <-time.After(time.Second):
+		t.Error("Run did not return")
+	}
+}
+
+func TestServe(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sl := New()
+	sl.GET("/", func(c *Context) { c.String(http.StatusOK, "ok") })
+
+	served := make(chan error, 1)
+	go func() { served <- sl.Serve(ln) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+
+	if err := sl.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown = %v", err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve = %v, want nil after Shutdown", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Serve did not return")
+	}
+}
@@ -0,0 +1,71 @@
+// Package soltest
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package soltest
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+// FuzzRoutes fuzzes the route matching of sl, so changes to patterns can
+// be checked against the whole route table:
+//
+//	func FuzzRoutes(f *testing.F) { soltest.FuzzRoutes(f, newServer()) }
+//
+// The corpus is seeded with a path for each route. For every input path
+// and registered method it checks that matching does not panic, that the
+// matched route has the method, and that the path rebuilt from the
+// matched pattern and params matches the same route with the same params.
+func FuzzRoutes(f *testing.F, sl *sol.Sol) {
+	var methods []string
+	for _, rt := range sl.Routes() {
+		f.Add(buildPath(rt.Path(), nil))
+		if !slices.Contains(methods, rt.Method()) {
+			methods = append(methods, rt.Method())
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		for _, method := range methods {
+			rt, params := sl.Lookup(method, path)
+			if rt == nil {
+				continue
+			}
+			if rt.Method() != method {
+				t.Fatalf("%s %q matched route %s %s", method, path, rt.Method(), rt.Path())
+			}
+
+			again := buildPath(rt.Path(), params)
+			rt2, params2 := sl.Lookup(method, again)
+			if rt2 != rt || !maps.Equal(params, params2) {
+				got := "no route"
+				if rt2 != nil {
+					got = rt2.Path()
+				}
+				t.Fatalf("%s %q matched %s with %v, but rebuilt %q matched %s with %v",
+					method, path, rt.Path(), params, again, got, params2)
+			}
+		}
+	})
+}
+
+// buildPath fills the params of pattern, using "x" for missing ones.
+func buildPath(pattern string, params map[string]string) string {
+	segs := strings.Split(pattern, "/")
+	for i, seg := range segs {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			v, ok := params[seg[1:]]
+			if !ok {
+				v = "x"
+			}
+			segs[i] = v
+		}
+	}
+	return strings.Join(segs, "/")
+}
@@ -0,0 +1,118 @@
+// Package soltest
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package soltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+// Replay sends each recording in dir, as written by sol.Record, to h in
+// file order, one subtest per file. Recordings with a response are golden
+// files: the status, Content-Type and body must match, JSON bodies
+// compared by value. Recordings without one only have to be served
+// without a 5xx. Recordings whose request body was too long to record
+// are skipped.
+func Replay(t *testing.T, h http.Handler, dir string) {
+	t.Helper()
+	ReplayWithHeader(t, h, dir, nil)
+}
+
+// ReplayWithHeader is Replay with header set on every request over the
+// recorded values. Record redacts credentials, so routes behind auth
+// need test ones put back in:
+//
+//	soltest.ReplayWithHeader(t, sl, "testdata/traffic", http.Header{
+//		"Authorization": {"Bearer " + testToken},
+//	})
+func ReplayWithHeader(t *testing.T, h http.Handler, dir string, header http.Header) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("replay %s: %v", dir, err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("replay %s: no recordings", dir)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rec sol.Recording
+			if err := json.Unmarshal(b, &rec); err != nil {
+				t.Fatalf("decode recording: %v", err)
+			}
+			replay(t, h, rec, header)
+		})
+	}
+}
+
+func replay(t testing.TB, h http.Handler, rec sol.Recording, header http.Header) {
+	t.Helper()
+	name := rec.Request.Method + " " + rec.Request.URL
+	if rec.Request.Truncated {
+		t.Skipf("%s: request body was too long to record", name)
+	}
+
+	body, err := rec.Request.Body.Bytes()
+	if err != nil {
+		t.Fatalf("%s: request body: %v", name, err)
+	}
+	req := httptest.NewRequest(rec.Request.Method, baseURL+rec.Request.URL, bytes.NewReader(body))
+	for k, v := range rec.Request.Header {
+		req.Header[k] = v
+	}
+	for k, v := range header {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	want := rec.Response
+	if want == nil {
+		if w.Code >= http.StatusInternalServerError {
+			t.Errorf("%s: status = %d", name, w.Code)
+		}
+		return
+	}
+
+	if w.Code != want.Status {
+		t.Errorf("%s: status = %d, want %d", name, w.Code, want.Status)
+	}
+	if got, ct := w.Header().Get("Content-Type"), want.Header.Get("Content-Type"); got != ct {
+		t.Errorf("%s: Content-Type = %q, want %q", name, got, ct)
+	}
+	wantBody, err := want.Body.Bytes()
+	if err != nil {
+		t.Fatalf("%s: response body: %v", name, err)
+	}
+	got := w.Body.Bytes()
+	if want.Truncated && len(got) > len(wantBody) {
+		got = got[:len(wantBody)]
+	}
+	if !sameBody(got, wantBody) {
+		t.Errorf("%s: body = %q, want %q", name, got, wantBody)
+	}
+}
+
+// sameBody compares bodies, by value if both are JSON.
+func sameBody(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) == nil && json.Unmarshal(b, &vb) == nil {
+		return reflect.DeepEqual(va, vb)
+	}
+	return bytes.Equal(a, b)
+}
@@ -0,0 +1,148 @@
+// Package soltest
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package soltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Response wraps a recorded response with chainable assertions.
+type Response struct {
+	t        testing.TB
+	name     string
+	Recorder *httptest.ResponseRecorder
+}
+
+// Status asserts the response status code.
+func (r *Response) Status(code int) *Response {
+	r.t.Helper()
+	if r.Recorder.Code != code {
+		r.t.Errorf("%s: status = %d, want %d", r.name, r.Recorder.Code, code)
+	}
+	return r
+}
+
+// Header asserts the value of a response header.
+func (r *Response) Header(key, value string) *Response {
+	r.t.Helper()
+	if got := r.Recorder.Header().Get(key); got != value {
+		r.t.Errorf("%s: header %s = %q, want %q", r.name, key, got, value)
+	}
+	return r
+}
+
+// Body asserts the exact response body.
+func (r *Response) Body(body string) *Response {
+	r.t.Helper()
+	if got := r.Recorder.Body.String(); got != body {
+		r.t.Errorf("%s: body = %q, want %q", r.name, got, body)
+	}
+	return r
+}
+
+// BodyContains asserts that the response body contains substr.
+func (r *Response) BodyContains(substr string) *Response {
+	r.t.Helper()
+	if got := r.Recorder.Body.String(); !strings.Contains(got, substr) {
+		r.t.Errorf("%s: body %q does not contain %q", r.name, got, substr)
+	}
+	return r
+}
+
+// JSON decodes the response body into v.
+func (r *Response) JSON(v any) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), v); err != nil {
+		r.t.Errorf("%s: decode json body: %v", r.name, err)
+	}
+	return r
+}
+
+// JSONPath asserts the value found at path in the JSON body.
+// Paths look like "$.user.name" or "$.items[0].id".
+func (r *Response) JSONPath(path string, want any) *Response {
+	r.t.Helper()
+
+	var doc any
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), &doc); err != nil {
+		r.t.Errorf("%s: decode json body: %v", r.name, err)
+		return r
+	}
+
+	got, err := lookupPath(doc, path)
+	if err != nil {
+		r.t.Errorf("%s: %s: %v", r.name, path, err)
+		return r
+	}
+
+	// Round-trip want so numbers and structs compare like decoded JSON.
+	b, err := json.Marshal(want)
+	if err != nil {
+		r.t.Errorf("%s: encode expected value: %v", r.name, err)
+		return r
+	}
+	var expected any
+	json.Unmarshal(b, &expected)
+
+	if !reflect.DeepEqual(got, expected) {
+		r.t.Errorf("%s: %s = %v, want %v", r.name, path, got, want)
+	}
+	return r
+}
+
+// lookupPath walks a decoded JSON document following a "$.a.b[0]" path.
+func lookupPath(doc any, path string) (any, error) {
+	path = strings.TrimPrefix(path, "$")
+	cur := doc
+
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			key := path[:end]
+			path = path[end:]
+
+			obj, ok := cur.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot index %T with key %q", cur, key)
+			}
+			if cur, ok = obj[key]; !ok {
+				return nil, fmt.Errorf("key %q not found", key)
+			}
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index")
+			}
+			idx, err := strconv.Atoi(path[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q", path[1:end])
+			}
+			path = path[end+1:]
+
+			arr, ok := cur.([]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot index %T with [%d]", cur, idx)
+			}
+			if idx < 0 || idx >= len(arr) {
+				return nil, fmt.Errorf("index %d out of range", idx)
+			}
+			cur = arr[idx]
+		default:
+			return nil, fmt.Errorf("unexpected %q", path)
+		}
+	}
+	return cur, nil
+}
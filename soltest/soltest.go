@@ -0,0 +1,186 @@
+// Package soltest
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package soltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// baseURL is the origin used for requests and the cookie jar.
+const baseURL = "http://example.com"
+
+// Client sends requests to a handler in-process and keeps cookies between them.
+type Client struct {
+	handler http.Handler
+	jar     *cookiejar.Jar
+}
+
+// New returns a Client for the given handler, typically a *sol.Sol.
+func New(h http.Handler) *Client {
+	jar, _ := cookiejar.New(nil)
+	return &Client{handler: h, jar: jar}
+}
+
+// Request is a request under construction.
+type Request struct {
+	client *Client
+	method string
+	path   string
+	header http.Header
+	query  url.Values
+	body   io.Reader
+	err    error
+}
+
+func (cl *Client) newRequest(method, path string) *Request {
+	return &Request{
+		client: cl,
+		method: method,
+		path:   path,
+		header: make(http.Header),
+		query:  make(url.Values),
+	}
+}
+
+func (cl *Client) GET(path string) *Request     { return cl.newRequest(http.MethodGet, path) }
+func (cl *Client) POST(path string) *Request    { return cl.newRequest(http.MethodPost, path) }
+func (cl *Client) PUT(path string) *Request     { return cl.newRequest(http.MethodPut, path) }
+func (cl *Client) DELETE(path string) *Request  { return cl.newRequest(http.MethodDelete, path) }
+func (cl *Client) PATCH(path string) *Request   { return cl.newRequest(http.MethodPatch, path) }
+func (cl *Client) OPTIONS(path string) *Request { return cl.newRequest(http.MethodOptions, path) }
+func (cl *Client) HEAD(path string) *Request    { return cl.newRequest(http.MethodHead, path) }
+
+// Cookies returns the cookies currently held by the client's jar.
+func (cl *Client) Cookies() []*http.Cookie {
+	u, _ := url.Parse(baseURL)
+	return cl.jar.Cookies(u)
+}
+
+// WithHeader sets a request header.
+func (r *Request) WithHeader(key, value string) *Request {
+	r.header.Set(key, value)
+	return r
+}
+
+// WithQuery adds a query string parameter.
+func (r *Request) WithQuery(key, value string) *Request {
+	r.query.Add(key, value)
+	return r
+}
+
+// WithCookie adds a cookie to this request only.
+func (r *Request) WithCookie(name, value string) *Request {
+	r.header.Add("Cookie", (&http.Cookie{Name: name, Value: value}).String())
+	return r
+}
+
+// WithBody sets a raw request body with the given content type.
+func (r *Request) WithBody(contentType string, body []byte) *Request {
+	r.header.Set("Content-Type", contentType)
+	r.body = bytes.NewReader(body)
+	return r
+}
+
+// WithJSON encodes v as the JSON request body.
+func (r *Request) WithJSON(v any) *Request {
+	b, err := json.Marshal(v)
+	if err != nil {
+		r.err = fmt.Errorf("soltest: encode json body: %w", err)
+		return r
+	}
+	return r.WithBody("application/json", b)
+}
+
+// WithForm sets a URL-encoded form body.
+func (r *Request) WithForm(values url.Values) *Request {
+	return r.WithBody("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// File is a file part of a multipart body.
+type File struct {
+	Field    string
+	Filename string
+	Content  []byte
+}
+
+// WithMultipart sets a multipart/form-data body from fields and files.
+func (r *Request) WithMultipart(fields map[string]string, files ...File) *Request {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			r.err = fmt.Errorf("soltest: write field %s: %w", k, err)
+			return r
+		}
+	}
+	for _, f := range files {
+		part, err := mw.CreateFormFile(f.Field, f.Filename)
+		if err != nil {
+			r.err = fmt.Errorf("soltest: create file %s: %w", f.Field, err)
+			return r
+		}
+		part.Write(f.Content)
+	}
+	if err := mw.Close(); err != nil {
+		r.err = fmt.Errorf("soltest: close multipart writer: %w", err)
+		return r
+	}
+
+	return r.WithBody(mw.FormDataContentType(), buf.Bytes())
+}
+
+// Do sends the request and returns the recorded response.
+func (r *Request) Do() (*httptest.ResponseRecorder, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	target := baseURL + r.path
+	if len(r.query) > 0 {
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		target += sep + r.query.Encode()
+	}
+
+	req := httptest.NewRequest(r.method, target, r.body)
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	for _, ck := range r.client.jar.Cookies(req.URL) {
+		req.AddCookie(ck)
+	}
+
+	rec := httptest.NewRecorder()
+	r.client.handler.ServeHTTP(rec, req)
+
+	if cookies := rec.Result().Cookies(); len(cookies) > 0 {
+		r.client.jar.SetCookies(req.URL, cookies)
+	}
+	return rec, nil
+}
+
+// Expect sends the request and returns a Response for assertions.
+// Failures are reported through t.
+func (r *Request) Expect(t testing.TB) *Response {
+	t.Helper()
+	rec, err := r.Do()
+	if err != nil {
+		t.Fatalf("%s %s: %v", r.method, r.path, err)
+	}
+	return &Response{t: t, name: r.method + " " + r.path, Recorder: rec}
+}
@@ -0,0 +1,175 @@
+// Package soltest
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package soltest
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestClient(t *testing.T) {
+	sl := sol.New()
+	sl.GET("/users/:id", func(c *sol.Context) {
+		c.JSON(http.StatusOK, map[string]any{
+			"id":   c.Param("id"),
+			"name": "Perry",
+			"tags": []string{"a", "b"},
+		})
+	})
+	sl.POST("/login", func(c *sol.Context) {
+		c.SetCookie(&http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		c.Status(http.StatusNoContent)
+	})
+	sl.GET("/me", func(c *sol.Context) {
+		v, err := c.Cookie("session")
+		if err != nil {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.String(http.StatusOK, "%s", v)
+	})
+
+	cl := New(sl)
+
+	cl.GET("/users/1").Expect(t).
+		Status(http.StatusOK).
+		JSONPath("$.id", "1").
+		JSONPath("$.name", "Perry").
+		JSONPath("$.tags[1]", "b")
+
+	cl.GET("/me").Expect(t).Status(http.StatusUnauthorized)
+	cl.POST("/login").Expect(t).Status(http.StatusNoContent)
+	cl.GET("/me").Expect(t).Status(http.StatusOK).Body("abc")
+}
+
+func TestLookupPath(t *testing.T) {
+	doc := map[string]any{
+		"a": []any{map[string]any{"b": 1.0}},
+	}
+
+	tests := []struct {
+		path    string
+		want    any
+		wantErr bool
+	}{
+		{"$.a[0].b", 1.0, false},
+		{"$.a[1]", nil, true},
+		{"$.x", nil, true},
+		{"$.a.b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := lookupPath(doc, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("lookupPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	handler := func(sl *sol.Sol, greeting string) {
+		sl.GET("/greet/:name", func(c *sol.Context) {
+			c.JSON(http.StatusOK, map[string]string{"greeting": greeting, "name": c.Param("name")})
+		})
+		sl.POST("/echo", func(c *sol.Context) {
+			b, _ := c.Body()
+			c.String(http.StatusOK, "%s", b)
+		})
+	}
+
+	recording := sol.New()
+	recording.Use(sol.Record(sol.RecordConfig{Dir: dir, Responses: true}))
+	handler(recording, "hello")
+	cl := New(recording)
+	cl.GET("/greet/ada").Expect(t).Status(http.StatusOK)
+	cl.POST("/echo").WithBody("text/plain", []byte("ping")).Expect(t).Body("ping")
+
+	replayed := sol.New()
+	handler(replayed, "hello")
+	Replay(t, replayed, dir)
+
+	changed := sol.New()
+	handler(changed, "hi")
+	ft := &fakeTB{TB: t}
+	replay(ft, changed, recordingOf(t, dir, "get-greet_ada"), nil)
+	if !ft.failed {
+		t.Error("replay did not detect the changed response")
+	}
+}
+
+func TestReplayWithHeader(t *testing.T) {
+	dir := t.TempDir()
+	handler := func(sl *sol.Sol, token string) {
+		sl.GET("/me", func(c *sol.Context) {
+			if c.Header("Authorization") != "Bearer "+token {
+				c.Status(http.StatusUnauthorized)
+				return
+			}
+			c.String(http.StatusOK, "me")
+		})
+	}
+
+	recording := sol.New()
+	recording.Use(sol.Record(sol.RecordConfig{Dir: dir, Responses: true}))
+	handler(recording, "live")
+	New(recording).GET("/me").WithHeader("Authorization", "Bearer live").Expect(t).Status(http.StatusOK)
+
+	replayed := sol.New()
+	handler(replayed, "test")
+	ReplayWithHeader(t, replayed, dir, http.Header{"authorization": {"Bearer test"}})
+}
+
+func recordingOf(t *testing.T, dir, suffix string) sol.Recording {
+	t.Helper()
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+suffix+".json"))
+	if len(files) != 1 {
+		t.Fatalf("recordings %s: %v", suffix, files)
+	}
+	b, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec sol.Recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper()               {}
+func (f *fakeTB) Errorf(string, ...any) { f.failed = true }
+func (f *fakeTB) Fatalf(string, ...any) { f.failed = true }
+
+func FuzzRouter(f *testing.F) {
+	sl := sol.New()
+	h := func(c *sol.Context) {}
+	sl.GET("/", h)
+	sl.GET("/users", h)
+	sl.GET("/users/:id", h)
+	sl.GET("/users/:id/posts/:post", h)
+	sl.GET("/users/new", h)
+	sl.POST("/users/:id", h)
+	sl.GET("/files/*path", h)
+	sl.GET("/files/readme", h)
+	sl.Group("/api/v1").GET("/orgs/:org/repos/*rest", h)
+	FuzzRoutes(f, sl)
+}
@@ -0,0 +1,272 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticFS serves the files of fsys under prefix.
+func (r *routerImpl) StaticFS(prefix string, fsys fs.FS) {
+	h := serveFS(fsys)
+	prefix = normalizePath(prefix)
+
+	r.GET(prefix+"/*filepath", h)
+	r.HEAD(prefix+"/*filepath", h)
+}
+
+// StaticEmbed serves the root directory of an embedded file system under prefix.
+func (r *routerImpl) StaticEmbed(prefix string, efs embed.FS, root string) {
+	r.StaticFS(prefix, subFS(efs, root))
+}
+
+// SPA serves a single-page application from the root directory of efs.
+// Unmatched GET and HEAD requests are answered with the matching file if it
+// exists and with index.html otherwise, except for paths under one of the
+// excluded prefixes (e.g. "/api"), which keep the regular NotFound handler.
+// SPA wraps the NotFound handler set at the time of the call.
+func (r *routerImpl) SPA(efs embed.FS, root string, excludes ...string) {
+	fsys := subFS(efs, root)
+	fallback := r.notFound
+	etags := &etagCache{}
+
+	prefixes := make([]string, len(excludes))
+	for i, ex := range excludes {
+		prefixes[i] = normalizePath(ex)
+	}
+
+	r.notFound = func(c *Context) {
+		method := c.Method()
+		if method != http.MethodGet && method != http.MethodHead {
+			fallback(c)
+			return
+		}
+
+		reqPath := normalizePath(c.Path())
+		for _, ex := range prefixes {
+			if hasPathPrefix(reqPath, ex) {
+				fallback(c)
+				return
+			}
+		}
+
+		name := strings.TrimPrefix(path.Clean(reqPath), "/")
+		if name == "" || !isFile(fsys, name) {
+			name = "index.html"
+		}
+		setStaticCache(c, fsys, name)
+		serveFile(c, fsys, name, etags)
+	}
+}
+
+func serveFS(fsys fs.FS) HandlerFunc {
+	etags := &etagCache{}
+	return func(c *Context) {
+		name := strings.TrimPrefix(path.Clean("/"+c.Param("filepath")), "/")
+		if name == "" {
+			name = "."
+		}
+		if !fs.ValidPath(name) {
+			http.NotFound(c.Writer, c.Request)
+			return
+		}
+		// Directories serve their index.html and are never listed.
+		if info, err := fs.Stat(fsys, name); err == nil && info.IsDir() {
+			name = path.Join(name, "index.html")
+		}
+		setStaticCache(c, fsys, name)
+		serveFile(c, fsys, name, etags)
+	}
+}
+
+// etagCache holds the ETags of files without a modification time, such
+// as embedded ones. Those never change, so each is hashed once.
+type etagCache struct {
+	m sync.Map
+}
+
+func (ec *etagCache) get(name string, f io.Reader) string {
+	if v, ok := ec.m.Load(name); ok {
+		return v.(string)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+	ec.m.Store(name, etag)
+	return etag
+}
+
+// serveFile serves a regular file of fsys with conditional and range
+// request handling. A precompressed name.br or name.gz sibling is served
+// instead when the client accepts it. Files without a modification time
+// get a content hash ETag so they still revalidate. Directories and
+// missing files are answered with 404.
+func serveFile(c *Context, fsys fs.FS, name string, etags *etagCache) {
+	if !isFile(fsys, name) {
+		// Like http.ServeFileFS, keep errors out of caches.
+		c.Writer.Header().Del("Cache-Control")
+		http.NotFound(c.Writer, c.Request)
+		return
+	}
+
+	h := c.Writer.Header()
+	served := name
+	enc, sibling, offered := precompressed(fsys, name, c.Header("Accept-Encoding"))
+	if offered {
+		// Every representation depends on Accept-Encoding, identity too.
+		h.Add("Vary", "Accept-Encoding")
+	}
+	if sibling != "" {
+		served = sibling
+		h.Set("Content-Encoding", enc)
+		if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+			h.Set("Content-Type", ctype)
+		}
+	}
+
+	f, err := fsys.Open(served)
+	if err != nil {
+		http.ServeFileFS(c.Writer, c.Request, fsys, name)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	rs, ok := f.(io.ReadSeeker)
+	if err != nil || !ok {
+		http.ServeFileFS(c.Writer, c.Request, fsys, name)
+		return
+	}
+
+	if info.ModTime().IsZero() && etags != nil {
+		if etag := etags.get(served, rs); etag != "" {
+			h.Set("ETag", etag)
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	http.ServeContent(c.Writer, c.Request, name, info.ModTime(), rs)
+}
+
+// precompressed returns the encoding and name of the precompressed
+// sibling of name the client prefers, brotli winning ties, and whether
+// name has any sibling at all.
+func precompressed(fsys fs.FS, name, acceptEncoding string) (enc, sibling string, offered bool) {
+	exts := map[string]string{"br": ".br", "gzip": ".gz"}
+	var offers []string
+	for _, coding := range [...]string{"br", "gzip"} {
+		if isFile(fsys, name+exts[coding]) {
+			offers = append(offers, coding)
+		}
+	}
+	if len(offers) == 0 || acceptEncoding == "" {
+		return "", "", len(offers) > 0
+	}
+	if enc := negotiateEncoding(acceptEncoding, offers); enc != "" {
+		return enc, name + exts[enc], true
+	}
+	return "", "", true
+}
+
+// setStaticCache applies DefaultStaticCache unless a middleware already
+// set Cache-Control. index.html gets no-cache instead: it names the
+// current asset bundles, so a cached copy would keep clients on an old
+// release after a deploy.
+func setStaticCache(c *Context, fsys fs.FS, name string) {
+	if c.Writer.Header().Get("Cache-Control") != "" {
+		return
+	}
+	if path.Base(name) == "index.html" {
+		c.CacheControl(Cache{NoCache: true})
+		return
+	}
+	c.CacheControl(DefaultStaticCache)
+}
+
+func subFS(efs embed.FS, root string) fs.FS {
+	root = strings.Trim(path.Clean("/"+root), "/")
+	if root == "" {
+		return efs
+	}
+
+	sub, err := fs.Sub(efs, root)
+	if err != nil {
+		panic(fmt.Sprintf("invalid static root '%s': %v", root, err))
+	}
+	return sub
+}
+
+func isFile(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && !info.IsDir()
+}
+
+// hasPathPrefix reports whether p equals prefix or lies beneath it.
+func hasPathPrefix(p, prefix string) bool {
+	if prefix == "/" {
+		return true
+	}
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// Favicon serves the icon file at name, an OS path, as /favicon.ico.
+func (sl *Sol) Favicon(name string) {
+	sl.FaviconFS(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+}
+
+// FaviconFS serves the icon file name of fsys as /favicon.ico.
+// The file is read once, at registration.
+func (sl *Sol) FaviconFS(fsys fs.FS, name string) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		panic(fmt.Sprintf("invalid favicon '%s': %v", name, err))
+	}
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype == "" {
+		ctype = "image/x-icon"
+	}
+	sl.serveBytes("/favicon.ico", ctype, Cache{Public: true, MaxAge: 7 * 24 * time.Hour}, b)
+}
+
+// RobotsTxt serves content as /robots.txt.
+func (sl *Sol) RobotsTxt(content string) {
+	sl.serveBytes("/robots.txt", "text/plain; charset=utf-8", Cache{Public: true, MaxAge: 24 * time.Hour}, []byte(content))
+}
+
+// serveBytes registers GET and HEAD routes answering with b. Exact paths
+// take precedence over params and catch-alls, so SPA and static
+// catch-all routes do not shadow them.
+func (sl *Sol) serveBytes(p, ctype string, cc Cache, b []byte) {
+	modTime := time.Now()
+	h := func(c *Context) {
+		c.Writer.Header().Set("Content-Type", ctype)
+		c.CacheControl(cc)
+		http.ServeContent(c.Writer, c.Request, p, modTime, bytes.NewReader(b))
+	}
+
+	sl.GET(p, h)
+	sl.HEAD(p, h)
+}
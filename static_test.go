@@ -0,0 +1,198 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"embed"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+//go:embed testdata/static
+var staticFS embed.FS
+
+func TestStaticEmbedAndSPA(t *testing.T) {
+	sl := New()
+	sl.GET("/api/ping", func(c *Context) {
+		c.String(http.StatusOK, "pong")
+	})
+	sl.StaticEmbed("/static", staticFS, "testdata/static")
+	sl.SPA(staticFS, "testdata/static", "/api")
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+		cache  string
+	}{
+		{"/static/assets/app.js", http.StatusOK, `console.log("app")`, DefaultStaticCache.String()},
+		{"/static/missing.js", http.StatusNotFound, "", ""},
+		{"/static/", http.StatusOK, "<title>app</title>", "no-cache"},
+		{"/api/ping", http.StatusOK, "pong", ""},
+		{"/api/missing", http.StatusNotFound, "404 page not found", ""},
+		{"/users/1", http.StatusOK, "<title>app</title>", "no-cache"},
+		{"/assets/app.js", http.StatusOK, `console.log("app")`, DefaultStaticCache.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("body %q does not contain %q", rec.Body.String(), tt.body)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.cache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.cache)
+			}
+		})
+	}
+}
+
+func TestStaticDirectories(t *testing.T) {
+	sl := New()
+	sl.StaticFS("/static", fstest.MapFS{
+		"subdir/app.js":   {Data: []byte("app")},
+		"docs/index.html": {Data: []byte("docs")},
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/static/", http.StatusNotFound, "404 page not found\n"},
+		{"/static/subdir/", http.StatusNotFound, "404 page not found\n"},
+		{"/static/subdir", http.StatusNotFound, "404 page not found\n"},
+		{"/static/docs/", http.StatusOK, "docs"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("%s: got %d %q, want %d %q", tt.path, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+}
+
+func TestFaviconAndRobots(t *testing.T) {
+	sl := New()
+	sl.StaticFS("/", fstest.MapFS{"index.html": {Data: []byte("index")}})
+	sl.FaviconFS(fstest.MapFS{"icon.png": {Data: []byte("png")}}, "icon.png")
+	sl.RobotsTxt("User-agent: *\nDisallow: /admin\n")
+
+	tests := []struct {
+		path, contentType, body string
+	}{
+		{"/favicon.ico", "image/png", "png"},
+		{"/robots.txt", "text/plain; charset=utf-8", "Disallow: /admin"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), tt.body) {
+			t.Errorf("%s: status = %d, body = %q", tt.path, rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.path, ct, tt.contentType)
+		}
+		if !strings.HasPrefix(rec.Header().Get("Cache-Control"), "public, max-age=") {
+			t.Errorf("%s: Cache-Control = %q", tt.path, rec.Header().Get("Cache-Control"))
+		}
+	}
+}
+
+func TestFavicon_disk(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "icons", "favicon.ico")
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte("ico"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sl := New()
+	sl.Favicon(name)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/favicon.ico", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ico" {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestStaticConditionalAndPrecompressed(t *testing.T) {
+	modTime := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	sl := New()
+	sl.StaticFS("/assets", fstest.MapFS{
+		"app.js":    {Data: []byte("console.log(1)"), ModTime: modTime},
+		"app.js.br": {Data: []byte("brotli"), ModTime: modTime},
+		"app.js.gz": {Data: []byte("gzip"), ModTime: modTime},
+		"style.css": {Data: []byte("body{}")},
+	})
+
+	do := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		maps.Copy(req.Header, header)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("/assets/app.js", nil)
+	if rec.Header().Get("Last-Modified") != modTime.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q", rec.Header().Get("Last-Modified"))
+	}
+	if rec := do("/assets/app.js", http.Header{"If-Modified-Since": {modTime.Format(http.TimeFormat)}}); rec.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since: status = %d, want 304", rec.Code)
+	}
+
+	tests := []struct {
+		accept, encoding, body string
+	}{
+		{"gzip, br", "br", "brotli"},
+		{"gzip, br;q=0", "gzip", "gzip"},
+		{"gzip, br;q=0.5", "gzip", "gzip"},
+		{"*", "br", "brotli"},
+		{"identity", "", "console.log(1)"},
+		{"", "", "console.log(1)"},
+	}
+	for _, tt := range tests {
+		rec := do("/assets/app.js", http.Header{"Accept-Encoding": {tt.accept}})
+		if rec.Header().Get("Content-Encoding") != tt.encoding || rec.Body.String() != tt.body {
+			t.Errorf("%q: encoding = %q, body = %q", tt.accept, rec.Header().Get("Content-Encoding"), rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/javascript") {
+			t.Errorf("%q: Content-Type = %q", tt.accept, ct)
+		}
+		if vary := rec.Header().Get("Vary"); vary != "Accept-Encoding" {
+			t.Errorf("%q: Vary = %q, want Accept-Encoding", tt.accept, vary)
+		}
+	}
+	if vary := do("/assets/style.css", nil).Header().Get("Vary"); vary != "" {
+		t.Errorf("file without siblings: Vary = %q, want none", vary)
+	}
+
+	// Without a modification time the file is revalidated by ETag.
+	rec = do("/assets/style.css", nil)
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag for file without modification time")
+	}
+	if rec := do("/assets/style.css", http.Header{"If-None-Match": {etag}}); rec.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match: status = %d, want 304", rec.Code)
+	}
+}
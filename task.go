@@ -0,0 +1,141 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+// taskRunner tracks background goroutines so shutdown can cancel and wait for them.
+type taskRunner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// mu guards queue and closed
+	mu     sync.Mutex
+	queue  chan func(context.Context)
+	closed bool
+}
+
+func newTaskRunner() *taskRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &taskRunner{ctx: ctx, cancel: cancel}
+}
+
+// run calls fn with the runner context, recovering from panics.
+func (tr *taskRunner) run(fn func(context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("[PANIC] background task: %v\n%s", err, debug.Stack())
+		}
+	}()
+	fn(tr.ctx)
+}
+
+// Go runs fn in a background goroutine tied to the server lifecycle.
+// ctx is cancelled when the server shuts down, and shutdown waits for fn
+// to return (bounded by the shutdown timeout).
+// Calls made after shutdown has started are dropped.
+func (sl *Sol) Go(fn func(ctx context.Context)) {
+	tr := sl.tasks
+
+	tr.mu.Lock()
+	if tr.closed {
+		tr.mu.Unlock()
+		log.Println("[WARN] background task dropped: server is shutting down")
+		return
+	}
+	tr.wg.Add(1)
+	tr.mu.Unlock()
+
+	go func() {
+		defer tr.wg.Done()
+		tr.run(fn)
+	}()
+}
+
+// WithWorkers starts a pool of n workers consuming a queue of queueSize
+// tasks submitted with Submit. It must be called before the server starts.
+func (sl *Sol) WithWorkers(n, queueSize int) *Sol {
+	tr := sl.tasks
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.queue != nil || n <= 0 {
+		return sl
+	}
+	tr.queue = make(chan func(context.Context), queueSize)
+
+	tr.wg.Add(n)
+	for range n {
+		go func() {
+			defer tr.wg.Done()
+			for fn := range tr.queue {
+				tr.run(fn)
+			}
+		}()
+	}
+	return sl
+}
+
+// Submit queues fn on the worker pool without blocking. It reports false if
+// there is no pool, the queue is full or the server is shutting down.
+// Tasks still queued at shutdown run with an already cancelled ctx.
+func (sl *Sol) Submit(fn func(ctx context.Context)) bool {
+	tr := sl.tasks
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.closed || tr.queue == nil {
+		return false
+	}
+
+	select {
+	case tr.queue <- fn:
+		return true
+	default:
+		return false
+	}
+}
+
+// pooled reports whether WithWorkers started a worker pool.
+func (tr *taskRunner) pooled() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.queue != nil
+}
+
+// shutdown cancels background tasks and waits for them until ctx is done.
+func (tr *taskRunner) shutdown(ctx context.Context) error {
+	tr.mu.Lock()
+	if !tr.closed {
+		tr.closed = true
+		if tr.queue != nil {
+			close(tr.queue)
+		}
+	}
+	tr.mu.Unlock()
+
+	tr.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		tr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,41 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTasksShutdownWaits(t *testing.T) {
+	sl := New().WithWorkers(2, 4)
+
+	var finished atomic.Int32
+	sl.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished.Add(1)
+	})
+	for range 3 {
+		if !sl.Submit(func(ctx context.Context) { finished.Add(1) }) {
+			t.Fatal("Submit rejected a task")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sl.tasks.shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if n := finished.Load(); n != 4 {
+		t.Errorf("finished = %d, want 4", n)
+	}
+	if sl.Submit(func(ctx context.Context) {}) {
+		t.Error("Submit accepted a task after shutdown")
+	}
+}
@@ -0,0 +1,395 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"text/template/parse"
+	"time"
+)
+
+// TemplateConfig configures how templates are loaded and rendered.
+//
+// Every file under the root is a page, named by its path without the
+// extension ("users/show"). Files under Layouts and Partials are shared:
+// partials are available to every page by name ("partials/nav"), and a page
+// is rendered inside Layout, which includes it with {{template "content" .}}.
+// The "t" function translates a message key like Context.T, and
+// "flashes", "csrf_token" and "current_user" return Context.Flashes,
+// Context.CSRFToken and Context.Principal.
+type TemplateConfig struct {
+	// Dir is a directory on disk holding the templates. Ignored if FS is set.
+	Dir string
+	// FS holds the templates, e.g. an embed.FS.
+	FS fs.FS
+	// Extension of template files, ".html" by default.
+	Extension string
+	// Layouts is the directory of layout templates, "layouts" by default.
+	Layouts string
+	// Partials is the directory of partial templates, "partials" by default.
+	Partials string
+	// Layout is the default layout pages are rendered in, e.g. "layouts/base".
+	// Pages are rendered on their own if empty.
+	Layout string
+	// Delims overrides the action delimiters, e.g. [2]string{"[[", "]]"}.
+	Delims [2]string
+	// Funcs are made available to all templates.
+	Funcs template.FuncMap
+	// ContextFuncs are template functions bound to the request being rendered.
+	// They take no arguments in templates and replace built-ins of the same name.
+	ContextFuncs map[string]func(c *Context) any
+	// Reload re-parses the templates when a file changes. Meant for development.
+	Reload bool
+	// ReloadInterval is how often Reload checks file modification times,
+	// one second by default.
+	ReloadInterval time.Duration
+}
+
+// templateSet holds the parsed pages, one template tree per page.
+type templateSet struct {
+	cfg TemplateConfig
+	fs  fs.FS
+
+	mu      sync.RWMutex
+	pages   map[string]page
+	modTime time.Time
+	checked time.Time
+}
+
+// page is a parsed page. Pages calling context funcs are bound to each
+// request on a clone; the others are executed as they are.
+type page struct {
+	t     *template.Template
+	bound bool
+}
+
+// LoadTemplates parses the templates described by cfg and enables Context.Render.
+// Groups can load their own set with their LoadTemplates.
+func (sl *Sol) LoadTemplates(cfg TemplateConfig) error {
+	ts, err := newTemplateSet(cfg)
+	if err != nil {
+		return err
+	}
+	sl.templates = ts
+	return nil
+}
+
+// newTemplateSet parses the templates described by cfg.
+func newTemplateSet(cfg TemplateConfig) (*templateSet, error) {
+	if cfg.Extension == "" {
+		cfg.Extension = ".html"
+	}
+	if cfg.Layouts == "" {
+		cfg.Layouts = "layouts"
+	}
+	if cfg.Partials == "" {
+		cfg.Partials = "partials"
+	}
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = time.Second
+	}
+
+	ts := &templateSet{cfg: cfg, fs: cfg.FS}
+	if ts.fs == nil {
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("templates: Dir or FS is required")
+		}
+		ts.fs = os.DirFS(cfg.Dir)
+	}
+
+	if err := ts.load(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// load walks the file system and parses every page with the shared templates.
+func (ts *templateSet) load() error {
+	var (
+		shared  = make(map[string]string)
+		pages   = make(map[string]string)
+		modTime time.Time
+	)
+
+	err := fs.WalkDir(ts.fs, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ts.cfg.Extension) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+
+		b, err := fs.ReadFile(ts.fs, p)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(p, ts.cfg.Extension)
+		if isUnder(name, ts.cfg.Layouts) || isUnder(name, ts.cfg.Partials) {
+			shared[name] = string(b)
+		} else {
+			pages[name] = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("templates: %w", err)
+	}
+
+	parsed := make(map[string]page, len(pages))
+	for name, text := range pages {
+		t, err := ts.parse(name, text, shared)
+		if err != nil {
+			return err
+		}
+		parsed[name] = page{t: t, bound: ts.callsContextFuncs(t)}
+	}
+
+	ts.mu.Lock()
+	ts.pages = parsed
+	ts.modTime = modTime
+	ts.checked = time.Now()
+	ts.mu.Unlock()
+	return nil
+}
+
+func (ts *templateSet) parse(name, text string, shared map[string]string) (*template.Template, error) {
+	funcs := template.FuncMap{}
+	for k, v := range ts.cfg.Funcs {
+		funcs[k] = v
+	}
+	// Placeholders so templates using context funcs parse; bound per render.
+	for k := range builtinContextFuncs {
+		funcs[k] = func() any { return nil }
+	}
+	for k := range ts.cfg.ContextFuncs {
+		funcs[k] = func() any { return nil }
+	}
+	funcs["t"] = func(key string, args ...any) string { return key }
+
+	t := template.New(name).Funcs(funcs)
+	if ts.cfg.Delims[0] != "" || ts.cfg.Delims[1] != "" {
+		t.Delims(ts.cfg.Delims[0], ts.cfg.Delims[1])
+	}
+
+	for sharedName, sharedText := range shared {
+		if _, err := t.New(sharedName).Parse(sharedText); err != nil {
+			return nil, fmt.Errorf("templates: parse %s: %w", sharedName, err)
+		}
+	}
+	if _, err := t.New("content").Parse(text); err != nil {
+		return nil, fmt.Errorf("templates: parse %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// builtinContextFuncs are the context funcs every template set provides.
+var builtinContextFuncs = map[string]func(c *Context) any{
+	"flashes":      func(c *Context) any { return c.Flashes() },
+	"csrf_token":   func(c *Context) any { return c.CSRFToken() },
+	"current_user": func(c *Context) any { return c.Principal() },
+}
+
+// isContextFunc reports whether the template func name is bound per render.
+func (ts *templateSet) isContextFunc(name string) bool {
+	_, builtin := builtinContextFuncs[name]
+	_, custom := ts.cfg.ContextFuncs[name]
+	return builtin || custom || name == "t"
+}
+
+// callsContextFuncs reports whether t or a template it shares calls a
+// context func.
+func (ts *templateSet) callsContextFuncs(t *template.Template) bool {
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil && ts.nodeCallsContextFuncs(tmpl.Tree.Root) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ts *templateSet) nodeCallsContextFuncs(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if ts.nodeCallsContextFuncs(child) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return ts.nodeCallsContextFuncs(n.Pipe)
+	case *parse.TemplateNode:
+		return n.Pipe != nil && ts.nodeCallsContextFuncs(n.Pipe)
+	case *parse.IfNode:
+		return ts.branchCallsContextFuncs(&n.BranchNode)
+	case *parse.RangeNode:
+		return ts.branchCallsContextFuncs(&n.BranchNode)
+	case *parse.WithNode:
+		return ts.branchCallsContextFuncs(&n.BranchNode)
+	case *parse.PipeNode:
+		for _, cmd := range n.Cmds {
+			if ts.nodeCallsContextFuncs(cmd) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if ts.nodeCallsContextFuncs(arg) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return ts.nodeCallsContextFuncs(n.Node)
+	case *parse.IdentifierNode:
+		return ts.isContextFunc(n.Ident)
+	}
+	return false
+}
+
+func (ts *templateSet) branchCallsContextFuncs(n *parse.BranchNode) bool {
+	return ts.nodeCallsContextFuncs(n.Pipe) ||
+		ts.nodeCallsContextFuncs(n.List) ||
+		ts.nodeCallsContextFuncs(n.ElseList)
+}
+
+// changed reports whether any template file is newer than the last load.
+// The files are checked at most once per ReloadInterval.
+func (ts *templateSet) changed() bool {
+	now := time.Now()
+	ts.mu.Lock()
+	if now.Sub(ts.checked) < ts.cfg.ReloadInterval {
+		ts.mu.Unlock()
+		return false
+	}
+	ts.checked = now
+	last := ts.modTime
+	ts.mu.Unlock()
+
+	newer := false
+	fs.WalkDir(ts.fs, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(last) {
+			newer = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return newer
+}
+
+// render executes the page into buf.
+func (ts *templateSet) render(buf *bytes.Buffer, c *Context, name, layout string, data any) error {
+	if ts.cfg.Reload && ts.changed() {
+		if err := ts.load(); err != nil {
+			return err
+		}
+	}
+
+	ts.mu.RLock()
+	p, ok := ts.pages[name]
+	ts.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("templates: page %q not found", name)
+	}
+
+	t := p.t
+	if p.bound {
+		clone, err := t.Clone()
+		if err != nil {
+			return fmt.Errorf("templates: clone %s: %w", name, err)
+		}
+		funcs := make(template.FuncMap, len(builtinContextFuncs)+len(ts.cfg.ContextFuncs)+1)
+		for k, fn := range builtinContextFuncs {
+			funcs[k] = func() any { return fn(c) }
+		}
+		for k, fn := range ts.cfg.ContextFuncs {
+			funcs[k] = func() any { return fn(c) }
+		}
+		funcs["t"] = c.T
+		t = clone.Funcs(funcs)
+	}
+
+	entry := "content"
+	if layout != "" {
+		entry = layout
+	}
+
+	if err := t.ExecuteTemplate(buf, entry, data); err != nil {
+		return fmt.Errorf("templates: render %s: %w", name, err)
+	}
+	return nil
+}
+
+// templateSet returns the templates of the matched route's group, or
+// the engine's.
+func (c *Context) templateSet() *templateSet {
+	if c.templates != nil {
+		return c.templates
+	}
+	if c.engine != nil {
+		return c.engine.templates
+	}
+	return nil
+}
+
+// Render renders the named page template inside the default layout.
+func (c *Context) Render(status int, name string, data any) {
+	layout := ""
+	if ts := c.templateSet(); ts != nil {
+		layout = ts.cfg.Layout
+	}
+	c.RenderLayout(status, layout, name, data)
+}
+
+// RenderLayout renders the named page template inside the given layout.
+// An empty layout renders the page on its own.
+func (c *Context) RenderLayout(status int, layout, name string, data any) {
+	ts := c.templateSet()
+	if ts == nil {
+		http.Error(c.Writer, "templates not loaded", http.StatusInternalServerError)
+		return
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := ts.render(buf, c, name, layout, data); err != nil {
+		log.Printf("[ERROR] %v", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	c.Writer.WriteHeader(status)
+	c.Writer.Write(buf.Bytes())
+}
+
+// isUnder reports whether the slash separated name lies in dir.
+func isUnder(name, dir string) bool {
+	dir = strings.Trim(path.Clean(dir), "/")
+	return strings.HasPrefix(name, dir+"/")
+}
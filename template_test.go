@@ -0,0 +1,177 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRenderLayoutAndPartials(t *testing.T) {
+	sl := New()
+	err := sl.LoadTemplates(TemplateConfig{
+		FS: fstest.MapFS{
+			"layouts/base.html":  {Data: []byte(`<body>[[template "partials/nav" .]][[template "content" .]]</body>`)},
+			"partials/nav.html":  {Data: []byte(`<nav>[[csrf]]</nav>`)},
+			"users/show.html":    {Data: []byte(`<h1>[[.Name]]</h1>`)},
+			"users/defines.html": {Data: []byte(`[[define "content"]]<p>[[.Name]]</p>[[end]]`)},
+		},
+		Layout: "layouts/base",
+		Delims: [2]string{"[[", "]]"},
+		ContextFuncs: map[string]func(c *Context) any{
+			"csrf": func(c *Context) any { return c.Header("X-Token") },
+		},
+	})
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+
+	sl.GET("/users/:page", func(c *Context) {
+		c.Render(http.StatusOK, "users/"+c.Param("page"), map[string]string{"Name": "Perry"})
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/users/show", http.StatusOK, "<body><nav>t1</nav><h1>Perry</h1></body>"},
+		{"/users/defines", http.StatusOK, "<body><nav>t1</nav><p>Perry</p></body>"},
+		{"/users/missing", http.StatusInternalServerError, "Internal Server Error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Token", "t1")
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestGroupTemplatesAndStatic(t *testing.T) {
+	sl := New()
+	if err := sl.LoadTemplates(TemplateConfig{FS: fstest.MapFS{
+		"home.html": {Data: []byte(`site {{.}}`)},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	admin := sl.Group("/admin", func(c *Context) { c.SetHeader("X-Admin", "1") })
+	admin.StaticFS("/assets", fstest.MapFS{"app.css": {Data: []byte("body{}")}})
+	render := func(page string) HandlerFunc {
+		return func(c *Context) { c.Render(http.StatusOK, page, "Perry") }
+	}
+	admin.GET("/", render("home"))
+	admin.Group("/users").GET("/", render("home"))
+	sl.GET("/", render("home"))
+
+	// Loaded after the routes were registered, still used by them.
+	if err := admin.LoadTemplates(TemplateConfig{
+		FS: fstest.MapFS{
+			"layouts/admin.html": {Data: []byte(`<admin>{{template "content" .}}</admin>`)},
+			"home.html":          {Data: []byte(`dashboard {{.}}`)},
+		},
+		Layout: "layouts/admin",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/", "site Perry"},
+		{"/admin", "<admin>dashboard Perry</admin>"},
+		{"/admin/users", "<admin>dashboard Perry</admin>"},
+		{"/admin/assets/app.css", "body{}"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Body.String() != tt.body {
+			t.Errorf("%s: body = %q, want %q", tt.path, rec.Body.String(), tt.body)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/assets/app.css", nil))
+	if rec.Header().Get("X-Admin") != "1" {
+		t.Error("group middleware should run for group static files")
+	}
+	if err := admin.LoadTemplates(TemplateConfig{FS: fstest.MapFS{}}); err == nil {
+		t.Error("expected error loading templates after serving started")
+	}
+}
+
+func TestTemplateContextBinding(t *testing.T) {
+	ts, err := newTemplateSet(TemplateConfig{
+		FS: fstest.MapFS{
+			"plain.html":     {Data: []byte(`<p>{{.}}</p>`)},
+			"translate.html": {Data: []byte(`{{if .}}{{t "hello"}}{{end}}`)},
+			"custom.html":    {Data: []byte(`{{range .}}{{. | printf "%s"}}{{end}}{{with stamp}}{{.}}{{end}}`)},
+			"field.html":     {Data: []byte(`{{.t}}`)},
+		},
+		ContextFuncs: map[string]func(c *Context) any{
+			"stamp": func(c *Context) any { return "s" },
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]bool{
+		"plain":     false,
+		"translate": true,
+		"custom":    true,
+		"field":     false,
+	}
+	for name, want := range tests {
+		if got := ts.pages[name].bound; got != want {
+			t.Errorf("%s: bound = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestTemplateReload(t *testing.T) {
+	fsys := fstest.MapFS{
+		"home.html": {Data: []byte(`v1`), ModTime: time.Now().Add(-time.Minute)},
+	}
+	sl := New()
+	if err := sl.LoadTemplates(TemplateConfig{FS: fsys, Reload: true, ReloadInterval: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	sl.GET("/", func(c *Context) { c.Render(http.StatusOK, "home", nil) })
+
+	render := func() string {
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Body.String()
+	}
+
+	fsys["home.html"] = &fstest.MapFile{Data: []byte(`v2`), ModTime: time.Now()}
+	if got := render(); got != "v1" {
+		t.Errorf("within the reload interval: body = %q, want v1", got)
+	}
+
+	// Let the interval pass.
+	sl.templates.mu.Lock()
+	sl.templates.checked = time.Time{}
+	sl.templates.mu.Unlock()
+	if got := render(); got != "v2" {
+		t.Errorf("after the reload interval: body = %q, want v2", got)
+	}
+}
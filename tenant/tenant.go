@@ -0,0 +1,151 @@
+// Package tenant
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+// contextKey is the Context key holding the resolved *Tenant.
+const contextKey = "sol.tenant"
+
+// ErrUnknown is returned by a Lookup for tenants that do not exist.
+var ErrUnknown = errors.New("tenant: unknown tenant")
+
+// Tenant is the tenant a request belongs to.
+type Tenant struct {
+	ID   string
+	Name string
+	// Data holds application specific tenant information.
+	Data any
+}
+
+// Resolver extracts a tenant identifier from the request.
+// It returns "" when the request carries none.
+type Resolver func(c *sol.Context) string
+
+// FromHeader reads the tenant identifier from a request header.
+func FromHeader(name string) Resolver {
+	return func(c *sol.Context) string {
+		return strings.TrimSpace(c.Header(name))
+	}
+}
+
+// FromSubdomain reads the leftmost label of hosts under baseDomain,
+// e.g. "acme" for "acme.example.com" with baseDomain "example.com".
+func FromSubdomain(baseDomain string) Resolver {
+	suffix := "." + strings.ToLower(strings.Trim(baseDomain, "."))
+	return func(c *sol.Context) string {
+		host := strings.ToLower(c.Host())
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		sub, ok := strings.CutSuffix(host, suffix)
+		if !ok || sub == "" {
+			return ""
+		}
+		if i := strings.LastIndexByte(sub, '.'); i >= 0 {
+			sub = sub[i+1:]
+		}
+		return sub
+	}
+}
+
+// FromParam reads the tenant identifier from a route parameter, for
+// path-prefixed routes such as "/:tenant/users".
+func FromParam(name string) Resolver {
+	return func(c *sol.Context) string {
+		return c.Param(name)
+	}
+}
+
+// FromFunc adapts any function, e.g. one reading a JWT claim set by auth middleware.
+func FromFunc(fn func(c *sol.Context) string) Resolver {
+	return fn
+}
+
+// Config configures the tenant middleware.
+type Config struct {
+	// Resolvers are tried in order until one returns an identifier.
+	Resolvers []Resolver
+	// Lookup loads the tenant for an identifier. Without it the Tenant only
+	// carries the ID. Return ErrUnknown for tenants that do not exist.
+	Lookup func(ctx context.Context, id string) (*Tenant, error)
+	// Optional lets requests without a tenant identifier through.
+	Optional bool
+}
+
+// Middleware resolves the tenant and stores it on the Context.
+// Requests without a tenant get 400 unless Optional is set; unknown
+// tenants get 404.
+func Middleware(cfg Config) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		var id string
+		for _, resolve := range cfg.Resolvers {
+			if id = resolve(c); id != "" {
+				break
+			}
+		}
+
+		if id == "" {
+			if cfg.Optional {
+				c.Next()
+				return
+			}
+			c.String(http.StatusBadRequest, "Missing tenant")
+			c.Abort()
+			return
+		}
+
+		t := &Tenant{ID: id}
+		if cfg.Lookup != nil {
+			found, err := cfg.Lookup(c.Context(), id)
+			if errors.Is(err, ErrUnknown) || (err == nil && found == nil) {
+				c.String(http.StatusNotFound, "Unknown tenant")
+				c.Abort()
+				return
+			}
+			if err != nil {
+				log.Printf("[ERROR] tenant lookup %s: %v", id, err)
+				c.String(http.StatusInternalServerError, "Internal Server Error")
+				c.Abort()
+				return
+			}
+			t = found
+		}
+
+		c.Set(contextKey, t)
+		c.Next()
+	}
+}
+
+// From returns the tenant of the request, or nil if none was resolved.
+func From(c *sol.Context) *Tenant {
+	v, _ := c.Get(contextKey)
+	t, _ := v.(*Tenant)
+	return t
+}
+
+// Only scopes a group or route to the given tenants; other tenants,
+// and requests without one, get 404 as if the routes did not exist.
+func Only(ids ...string) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		t := From(c)
+		if t == nil || !slices.Contains(ids, t.ID) {
+			c.String(http.StatusNotFound, "404 page not found\n")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
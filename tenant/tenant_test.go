@@ -0,0 +1,64 @@
+// Package tenant
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestMiddleware(t *testing.T) {
+	mw := Middleware(Config{
+		Resolvers: []Resolver{FromHeader("X-Tenant"), FromSubdomain("example.com")},
+		Lookup: func(ctx context.Context, id string) (*Tenant, error) {
+			if id == "ghost" {
+				return nil, ErrUnknown
+			}
+			return &Tenant{ID: id, Name: "Tenant " + id}, nil
+		},
+	})
+
+	sl := sol.New()
+	sl.GET("/whoami", mw, func(c *sol.Context) {
+		c.String(http.StatusOK, "%s", From(c).Name)
+	})
+	sl.GET("/beta", mw, Only("acme"), func(c *sol.Context) {
+		c.String(http.StatusOK, "beta")
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		host   string
+		header string
+		status int
+		body   string
+	}{
+		{"subdomain", "/whoami", "acme.example.com:8080", "", http.StatusOK, "Tenant acme"},
+		{"header wins", "/whoami", "acme.example.com", "globex", http.StatusOK, "Tenant globex"},
+		{"missing", "/whoami", "example.com", "", http.StatusBadRequest, "Missing tenant"},
+		{"unknown", "/whoami", "ghost.example.com", "", http.StatusNotFound, "Unknown tenant"},
+		{"scoped allowed", "/beta", "acme.example.com", "", http.StatusOK, "beta"},
+		{"scoped denied", "/beta", "globex.example.com", "", http.StatusNotFound, "404 page not found\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Host = tt.host
+			req.Header.Set("X-Tenant", tt.header)
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("got %d %q, want %d %q", rec.Code, rec.Body.String(), tt.status, tt.body)
+			}
+		})
+	}
+}
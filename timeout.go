@@ -0,0 +1,165 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"errors"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WriteTimeout overrides the engine write timeout for the routes it is applied to.
+// A non-positive d removes the write deadline entirely, which is what
+// streaming endpoints such as SSE usually want.
+func WriteTimeout(d time.Duration) HandlerFunc {
+	return func(c *Context) {
+		var deadline time.Time
+		if d > 0 {
+			deadline = time.Now().Add(d)
+		}
+		if err := c.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Printf("[WARN] set write deadline: %v", err)
+		}
+		c.Next()
+	}
+}
+
+// Timeout sets a deadline of d on the request context. Once it passes,
+// context-aware work is cancelled and the remaining handlers are skipped.
+// If nothing was written yet, the client gets a 503 right away, even from
+// a handler that ignores the context; whatever that handler writes later
+// is discarded with http.ErrHandlerTimeout.
+func Timeout(d time.Duration) HandlerFunc {
+	return func(c *Context) {
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		cancel := c.WithTimeout(d)
+		defer cancel()
+
+		w := c.Writer
+		tw := &timeoutWriter{ResponseWriter: w, raw: c.rw.ResponseWriter, header: w.Header().Clone()}
+		c.Writer = tw
+		timer := time.AfterFunc(d, tw.timeout)
+		// Restore the writer even on panic, so Recover sees the 503.
+		defer func() {
+			timer.Stop()
+			c.Writer = w
+			if size, ok := tw.sent(); ok && c.rw.status == 0 {
+				c.rw.status = http.StatusServiceUnavailable
+				c.rw.size = int64(size)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+const timeoutBody = "Service Unavailable\n"
+
+// timeoutWriter keeps the handler's headers apart until the response is
+// committed, so the timer can answer 503 without racing the handler.
+type timeoutWriter struct {
+	http.ResponseWriter
+	// raw is the connection's writer the timer answers on.
+	raw    http.ResponseWriter
+	header http.Header
+
+	mu        sync.Mutex
+	committed bool
+	timedOut  bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// commit copies the handler's headers out; w.mu must be held.
+func (w *timeoutWriter) commit() {
+	if w.committed {
+		return
+	}
+	w.committed = true
+	h := w.ResponseWriter.Header()
+	clear(h)
+	maps.Copy(h, w.header)
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	if code >= http.StatusOK {
+		w.commit()
+	} else {
+		maps.Copy(w.ResponseWriter.Header(), w.header)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.commit()
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError commits the response and pushes it to the client.
+func (w *timeoutWriter) FlushError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return http.ErrHandlerTimeout
+	}
+	w.commit()
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Flush implements http.Flusher for handlers asserting it directly.
+func (w *timeoutWriter) Flush() {
+	w.FlushError()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// timeout answers 503 unless the handler already committed a response.
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.committed {
+		return
+	}
+	w.timedOut = true
+
+	h := w.raw.Header()
+	h.Set("Content-Type", "text/plain; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Content-Length", strconv.Itoa(len(timeoutBody)))
+	w.raw.WriteHeader(http.StatusServiceUnavailable)
+	io.WriteString(w.raw, timeoutBody)
+	http.NewResponseController(w.raw).Flush()
+}
+
+// sent reports whether the timer answered, and the body size it wrote.
+func (w *timeoutWriter) sent() (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(timeoutBody), w.timedOut
+}
@@ -0,0 +1,268 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRouteTimeout(t *testing.T) {
+	sl := New()
+
+	var hasDeadline bool
+	sl.GET("/report", func(c *Context) {
+		_, hasDeadline = c.Context().Deadline()
+	}).Timeout(time.Second)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report", nil))
+
+	if !hasDeadline {
+		t.Error("expected request context to carry a deadline")
+	}
+}
+
+func TestGroupTimeoutStopsChain(t *testing.T) {
+	sl := New()
+	api := sl.Group("/api").Timeout(10 * time.Millisecond)
+
+	called := false
+	api.GET("/slow",
+		func(c *Context) {
+			<-c.Context().Done()
+			c.Next()
+		},
+		func(c *Context) {
+			called = true
+		},
+	)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+
+	if called {
+		t.Error("expected handlers after the deadline to be skipped")
+	}
+}
+
+func TestTimeout_handlerIgnoringContext(t *testing.T) {
+	sl := New()
+
+	release := make(chan struct{})
+	lateErr := make(chan error, 1)
+	sl.GET("/stuck", func(c *Context) {
+		<-release
+		c.SetHeader("X-Late", "1")
+		_, err := c.Writer.Write([]byte("late"))
+		lateErr <- err
+	}).Timeout(20 * time.Millisecond)
+	sl.GET("/fast", func(c *Context) {
+		c.String(http.StatusOK, "fast")
+	}).Timeout(time.Second)
+
+	ts := httptest.NewServer(sl)
+	defer ts.Close()
+
+	// The 503 arrives while the handler is still blocked.
+	res, err := http.Get(ts.URL + "/stuck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	close(release)
+
+	if res.StatusCode != http.StatusServiceUnavailable || string(body) != "Service Unavailable\n" {
+		t.Errorf("got %d %q, want 503", res.StatusCode, body)
+	}
+	if res.Header.Get("X-Late") != "" {
+		t.Error("late header reached the client")
+	}
+	if err := <-lateErr; !errors.Is(err, http.ErrHandlerTimeout) {
+		t.Errorf("late write error = %v, want ErrHandlerTimeout", err)
+	}
+
+	res, err = http.Get(ts.URL + "/fast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || string(body) != "fast" {
+		t.Errorf("fast: got %d %q", res.StatusCode, body)
+	}
+}
+
+func TestTimeout_statusAfterCutoff(t *testing.T) {
+	sl := New()
+	var status int
+	sl.Use(func(c *Context) {
+		c.Next()
+		status = c.ResponseStatus()
+	})
+	sl.GET("/slow", func(c *Context) {
+		time.Sleep(50 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	}).Timeout(10 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.String() != "Service Unavailable\n" {
+		t.Errorf("got %d %q, want 503", rec.Code, rec.Body.String())
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("ResponseStatus = %d, want 503", status)
+	}
+}
+
+func TestWithTimeouts(t *testing.T) {
+	sl := New()
+	if got := timeoutsOf(sl.server); got != DefaultTimeouts {
+		t.Errorf("New() timeouts = %+v, want %+v", got, DefaultTimeouts)
+	}
+
+	tests := []Timeouts{
+		{ReadHeader: time.Second, Read: 2 * time.Second, Write: 3 * time.Second, Idle: 4 * time.Second},
+		{},
+	}
+	for _, want := range tests {
+		sl.WithTimeouts(want)
+		if got := timeoutsOf(sl.server); got != want {
+			t.Errorf("WithTimeouts(%+v) set %+v", want, got)
+		}
+	}
+}
+
+func timeoutsOf(s *http.Server) Timeouts {
+	return Timeouts{ReadHeader: s.ReadHeaderTimeout, Read: s.ReadTimeout, Write: s.WriteTimeout, Idle: s.IdleTimeout}
+}
+
+// deadlineRecorder records the write deadline set through
+// http.ResponseController.
+type deadlineRecorder struct {
+	*httptest.ResponseRecorder
+	deadline time.Time
+	set      bool
+	err      error
+}
+
+func (w *deadlineRecorder) SetWriteDeadline(t time.Time) error {
+	w.deadline, w.set = t, true
+	return w.err
+}
+
+func TestWriteTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		err  error
+		zero bool
+		warn bool
+	}{
+		{name: "positive", d: time.Minute},
+		{name: "zero lifts the deadline", d: 0, zero: true},
+		{name: "negative lifts the deadline", d: -time.Second, zero: true},
+		{name: "error is logged", d: time.Minute, err: errors.New("conn closed"), warn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs strings.Builder
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			sl := New()
+			called := false
+			sl.GET("/stream", WriteTimeout(tt.d), func(c *Context) { called = true })
+
+			w := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder(), err: tt.err}
+			start := time.Now()
+			sl.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+			if !called {
+				t.Error("expected the chain to continue")
+			}
+			if !w.set {
+				t.Fatal("expected a write deadline to be set")
+			}
+			if tt.zero != w.deadline.IsZero() {
+				t.Errorf("deadline = %v, want zero %v", w.deadline, tt.zero)
+			}
+			if !tt.zero && (w.deadline.Before(start.Add(tt.d)) || w.deadline.After(time.Now().Add(tt.d))) {
+				t.Errorf("deadline = %v, want about now+%v", w.deadline, tt.d)
+			}
+			if got := strings.Contains(logs.String(), "[WARN] set write deadline"); got != tt.warn {
+				t.Errorf("warning logged = %v, want %v: %q", got, tt.warn, logs.String())
+			}
+		})
+	}
+}
+
+func TestWriteTimeout_unsupportedWriter(t *testing.T) {
+	var logs strings.Builder
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	sl := New()
+	sl.GET("/", WriteTimeout(0), func(c *Context) { c.String(http.StatusOK, "ok") })
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Body.String() != "ok" {
+		t.Errorf("body = %q, want ok", rec.Body.String())
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning for writers without deadlines, got %q", logs.String())
+	}
+}
+
+// TestWriteTimeout_server checks the per-request deadline against a real
+// connection: the server write timeout cuts off a slow response unless the
+// route lifts it.
+func TestWriteTimeout_server(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	sl := New().WithTimeouts(Timeouts{Write: 50 * time.Millisecond})
+	slow := func(c *Context) {
+		time.Sleep(150 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	}
+	sl.GET("/bounded", slow)
+	sl.GET("/lifted", WriteTimeout(0), slow)
+
+	ts := httptest.NewUnstartedServer(sl)
+	ts.Config = sl.server
+	ts.Start()
+	defer ts.Close()
+
+	if res, err := http.Get(ts.URL + "/bounded"); err == nil {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err == nil {
+			t.Errorf("expected the server write timeout to cut off the response, got %q", body)
+		}
+	}
+
+	res, err := http.Get(ts.URL + "/lifted")
+	if err != nil {
+		t.Fatalf("GET /lifted: %v", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil || string(body) != "done" {
+		t.Errorf("GET /lifted = %q, %v; want done", body, err)
+	}
+}
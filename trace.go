@@ -0,0 +1,132 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// traceKey is the Context key holding the request's trace context.
+const traceKey = "sol.trace"
+
+// traceContext is the W3C Trace Context of a request.
+type traceContext struct {
+	traceID string
+	spanID  string
+	flags   string
+	state   string
+	baggage string
+}
+
+// trace returns the request's trace context. The first call continues the
+// trace of an incoming traceparent header, or starts a new one, with a new
+// span ID for the request.
+func (c *Context) trace() *traceContext {
+	if v, ok := c.Get(traceKey); ok {
+		return v.(*traceContext)
+	}
+
+	tc := &traceContext{flags: "01"}
+	if traceID, _, flags, ok := parseTraceparent(c.Header("traceparent")); ok {
+		tc.traceID, tc.flags = traceID, flags
+		tc.state = c.Header("tracestate")
+	} else {
+		tc.traceID = randomHex(16)
+	}
+	tc.spanID = randomHex(8)
+	tc.baggage = c.Header("baggage")
+
+	c.Set(traceKey, tc)
+	return tc
+}
+
+// TraceID returns the W3C trace ID of the request, taken from the incoming
+// traceparent header or generated.
+func (c *Context) TraceID() string {
+	return c.trace().traceID
+}
+
+// SpanID returns the span ID of the request.
+func (c *Context) SpanID() string {
+	return c.trace().spanID
+}
+
+// SetTrace replaces the trace and span IDs, for tracing middleware that
+// manages its own spans, so that TraceID and InjectTraceHeaders agree with it.
+func (c *Context) SetTrace(traceID, spanID string) {
+	tc := c.trace()
+	tc.traceID, tc.spanID = traceID, spanID
+}
+
+// Baggage returns the value of a W3C baggage entry, or "".
+func (c *Context) Baggage(key string) string {
+	for member := range strings.SplitSeq(c.trace().baggage, ",") {
+		kv, _, _ := strings.Cut(member, ";")
+		k, v, ok := strings.Cut(kv, "=")
+		if ok && strings.TrimSpace(k) == key {
+			v, err := url.PathUnescape(strings.TrimSpace(v))
+			if err != nil {
+				return ""
+			}
+			return v
+		}
+	}
+	return ""
+}
+
+// InjectTraceHeaders sets traceparent, tracestate and baggage on an
+// outgoing request, so the downstream service continues the trace with
+// this request's span as parent.
+func (c *Context) InjectTraceHeaders(req *http.Request) {
+	tc := c.trace()
+	req.Header.Set("traceparent", "00-"+tc.traceID+"-"+tc.spanID+"-"+tc.flags)
+	if tc.state != "" {
+		req.Header.Set("tracestate", tc.state)
+	}
+	if tc.baggage != "" {
+		req.Header.Set("baggage", tc.baggage)
+	}
+}
+
+// parseTraceparent parses a version 00 traceparent header.
+func parseTraceparent(h string) (traceID, parentID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(h), "-")
+	if len(parts) < 4 || parts[0] == "ff" || len(parts[0]) != 2 {
+		return "", "", "", false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return "", "", "", false
+	}
+	traceID, parentID, flags = parts[1], parts[2], parts[3]
+	if !isLowerHex(traceID, 32) || !isLowerHex(parentID, 16) || !isLowerHex(flags, 2) {
+		return "", "", "", false
+	}
+	if strings.Trim(traceID, "0") == "" || strings.Trim(parentID, "0") == "" {
+		return "", "", "", false
+	}
+	return traceID, parentID, flags, true
+}
+
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9' || 'a' <= s[i] && s[i] <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
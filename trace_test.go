@@ -0,0 +1,62 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContext_TracePropagation(t *testing.T) {
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name        string
+		traceparent string
+		continued   bool
+	}{
+		{"incoming", parent, true},
+		{"none", "", false},
+		{"invalid", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"uppercase", strings.ToUpper(parent), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			req.Header.Set("tracestate", "vendor=x")
+			req.Header.Set("baggage", "tenant=acme, user%20id=1;meta, region=eu%2Dwest")
+			c := &Context{Request: req}
+
+			traceID := c.TraceID()
+			if tt.continued != (traceID == "4bf92f3577b34da6a3ce929d0e0e4736") {
+				t.Errorf("TraceID = %s, continued = %v", traceID, tt.continued)
+			}
+			if len(traceID) != 32 || len(c.SpanID()) != 16 || c.SpanID() == "00f067aa0ba902b7" {
+				t.Errorf("TraceID = %q, SpanID = %q", traceID, c.SpanID())
+			}
+			if c.Baggage("tenant") != "acme" || c.Baggage("region") != "eu-west" || c.Baggage("missing") != "" {
+				t.Errorf("Baggage tenant = %q, region = %q", c.Baggage("tenant"), c.Baggage("region"))
+			}
+
+			out := httptest.NewRequest(http.MethodGet, "http://downstream/", nil)
+			c.InjectTraceHeaders(out)
+			want := "00-" + traceID + "-" + c.SpanID() + "-01"
+			if got := out.Header.Get("traceparent"); got != want {
+				t.Errorf("traceparent = %q, want %q", got, want)
+			}
+			if out.Header.Get("baggage") == "" {
+				t.Error("baggage not propagated")
+			}
+			if (out.Header.Get("tracestate") != "") != tt.continued {
+				t.Errorf("tracestate = %q", out.Header.Get("tracestate"))
+			}
+		})
+	}
+}
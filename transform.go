@@ -0,0 +1,110 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BufferedResponse is a response held back by Transform. Status, headers
+// and body can all be rewritten before it is sent.
+type BufferedResponse struct {
+	// Status is the status written by the handlers, 200 if none was.
+	Status int
+	// Header is the response header map.
+	Header http.Header
+	Body   *bytes.Buffer
+}
+
+// Transform buffers the response of the handlers after it and lets fn
+// rewrite it before it hits the wire, e.g. to envelope JSON responses or
+// strip internal headers. Apply it to a group or route; buffering defeats
+// streaming, so keep it off SSE and large downloads, or mark those routes
+// Raw to pass them through.
+func Transform(fn func(c *Context, res *BufferedResponse)) HandlerFunc {
+	return func(c *Context) {
+		if c.IsRaw() {
+			c.Next()
+			return
+		}
+
+		w := c.Writer
+		bw := &bufferWriter{ResponseWriter: w, body: getBuffer()}
+		defer putBuffer(bw.body)
+
+		c.Writer = bw
+		// Restore the writer even on panic, so Recover can still answer.
+		defer func() { c.Writer = w }()
+
+		c.Next()
+
+		res := &BufferedResponse{Status: bw.status, Header: w.Header(), Body: bw.body}
+		if res.Status == 0 {
+			res.Status = http.StatusOK
+		}
+		fn(c, res)
+
+		if res.Header.Get("Content-Length") != "" {
+			res.Header.Set("Content-Length", strconv.Itoa(res.Body.Len()))
+		}
+		w.WriteHeader(res.Status)
+		w.Write(res.Body.Bytes())
+	}
+}
+
+// StripHeaders removes response headers, e.g. internal debugging headers
+// set by upstream code, before the response is sent.
+func StripHeaders(names ...string) HandlerFunc {
+	return Transform(func(c *Context, res *BufferedResponse) {
+		for _, name := range names {
+			res.Header.Del(name)
+		}
+	})
+}
+
+// bufferWriter holds back the status and body of a response.
+type bufferWriter struct {
+	http.ResponseWriter
+	status int
+	body   *bytes.Buffer
+}
+
+func (w *bufferWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferWriter) WriteString(s string) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.WriteString(s)
+}
+
+// FlushError is a no-op: nothing reaches the client before Transform runs.
+func (w *bufferWriter) FlushError() error {
+	return nil
+}
+
+// SetWriteDeadline and SetReadDeadline pass through to the connection,
+// so Timeout and WriteTimeout keep working on transformed routes.
+func (w *bufferWriter) SetWriteDeadline(t time.Time) error {
+	return http.NewResponseController(w.ResponseWriter).SetWriteDeadline(t)
+}
+
+func (w *bufferWriter) SetReadDeadline(t time.Time) error {
+	return http.NewResponseController(w.ResponseWriter).SetReadDeadline(t)
+}
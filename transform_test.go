@@ -0,0 +1,69 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransform_envelope(t *testing.T) {
+	envelope := Transform(func(c *Context, res *BufferedResponse) {
+		if !strings.HasPrefix(res.Header.Get("Content-Type"), "application/json") {
+			return
+		}
+		data := bytes.TrimSpace(res.Body.Bytes())
+		res.Body.Reset()
+		json.NewEncoder(res.Body).Encode(map[string]any{
+			"data": json.RawMessage(data),
+			"meta": map[string]int{"status": res.Status},
+		})
+	})
+
+	sl := New()
+	api := sl.Group("/api", envelope, StripHeaders("X-Internal"))
+	api.GET("/users", func(c *Context) {
+		c.SetHeader("X-Internal", "secret")
+		c.JSON(http.StatusCreated, []string{"perry"})
+	})
+	api.GET("/text", func(c *Context) { c.String(http.StatusOK, "plain") })
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if want := `{"data":["perry"],"meta":{"status":201}}` + "\n"; rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+	if rec.Header().Get("X-Internal") != "" {
+		t.Error("X-Internal was not stripped")
+	}
+
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/text", nil))
+	if rec.Body.String() != "plain" {
+		t.Errorf("text body = %q", rec.Body.String())
+	}
+}
+
+func TestTransform_panic(t *testing.T) {
+	sl := New()
+	sl.GET("/panic", Transform(func(c *Context, res *BufferedResponse) {}), func(c *Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "partial") {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+}
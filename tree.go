@@ -0,0 +1,248 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Router matches requests to routes. The default is a radix tree with
+// ":name" parameters and "*name" catch-alls; NewWithRouter swaps in
+// another matcher, e.g. one keyed by host and path, while Context,
+// middleware and the server lifecycle stay the same.
+//
+// Add is only called while routes are registered. Match is called
+// concurrently while serving and must not modify the router.
+type Router interface {
+	// Add registers rt under rt.Method() and rt.Path(), replacing a route
+	// registered under the same ones. It panics if the path is invalid.
+	Add(rt *Route)
+	// Match returns the route registered for method that matches req, or
+	// nil. Path parameters are stored in *params, allocated on first use.
+	Match(method string, req *http.Request, params *map[string]string) *Route
+}
+
+// treeRouter is the default Router.
+type treeRouter struct {
+	// trees method -> root node
+	trees map[string]*node
+	// static method -> path -> route, for routes without params
+	static map[string]map[string]*Route
+}
+
+func newTreeRouter() *treeRouter {
+	return &treeRouter{
+		trees:  make(map[string]*node),
+		static: make(map[string]map[string]*Route),
+	}
+}
+
+func (t *treeRouter) Add(rt *Route) {
+	root := t.trees[rt.method]
+	if root == nil {
+		root = &node{}
+		t.trees[rt.method] = root
+	}
+	root.insert(rt.path, rt.path).route = rt
+
+	if !strings.ContainsAny(rt.path, ":*") {
+		if t.static[rt.method] == nil {
+			t.static[rt.method] = make(map[string]*Route)
+		}
+		t.static[rt.method][rt.path] = rt
+	}
+}
+
+func (t *treeRouter) Match(method string, req *http.Request, params *map[string]string) *Route {
+	return t.search(method, req.URL.Path, params)
+}
+
+// search finds the route matching path, filling params.
+// Paths of static routes are resolved with a single map lookup; the
+// tree is only walked when that misses.
+func (t *treeRouter) search(method, path string, params *map[string]string) *Route {
+	if rt, ok := t.static[method][path]; ok {
+		return rt
+	}
+
+	root := t.trees[method]
+	if root == nil {
+		return nil
+	}
+	if n := root.lookup(normalizePath(path), params); n != nil {
+		return n.route
+	}
+	return nil
+}
+
+// node represents a radix tree node.
+// https://en.wikipedia.org/wiki/Radix_tree
+//
+// Static nodes hold a compressed byte prefix and are matched byte-wise.
+// Dynamic segments hang off their parent: paramChild matches one path
+// segment (:name) and wildChild matches the remainder of the path (*name).
+// Lookups prefer static, then param, then catch-all, backtracking when a
+// branch dead-ends.
+type node struct {
+	prefix string
+	// indices holds the first byte of each static child's prefix
+	indices    string
+	children   []*node
+	paramChild *node
+	// wildChild matches the remainder of the path (*name)
+	wildChild *node
+	paramName string
+	// route is the route ending at the node, nil for inner nodes
+	route *Route
+}
+
+// insert adds path below n and returns the node it ends at.
+// Existing nodes are never moved, so a node keeps representing the same
+// route when later insertions split prefixes around it.
+func (n *node) insert(path, fullPath string) *node {
+	for path != "" {
+		switch path[0] {
+		case ':':
+			name, rest := path[1:], ""
+			if i := strings.IndexByte(name, '/'); i >= 0 {
+				name, rest = name[:i], name[i:]
+			}
+			if n.paramChild == nil {
+				n.paramChild = &node{paramName: name}
+			} else if n.paramChild.paramName != name {
+				panic(fmt.Sprintf(
+					"cannot register '%s': parameter name ':%s' conflicts with existing ':%s' in previously registered path",
+					fullPath, name, n.paramChild.paramName,
+				))
+			}
+			n, path = n.paramChild, rest
+
+		case '*':
+			name := path[1:]
+			if strings.IndexByte(name, '/') >= 0 {
+				panic(fmt.Sprintf("cannot register '%s': catch-all '%s' must be the last segment", fullPath, path))
+			}
+			if n.wildChild == nil {
+				n.wildChild = &node{paramName: name}
+			} else if n.wildChild.paramName != name {
+				panic(fmt.Sprintf(
+					"cannot register '%s': catch-all '*%s' conflicts with existing '*%s' in previously registered path",
+					fullPath, name, n.wildChild.paramName,
+				))
+			}
+			return n.wildChild
+
+		default:
+			// The static run ends right before the next dynamic segment.
+			end := len(path)
+			if i := indexDynamic(path); i >= 0 {
+				end = i
+			}
+			n, path = n.insertStatic(path[:end]), path[end:]
+		}
+	}
+	return n
+}
+
+// insertStatic descends along static, splitting prefixes as needed,
+// and returns the node whose accumulated prefix ends with static.
+func (n *node) insertStatic(static string) *node {
+	for static != "" {
+		i := strings.IndexByte(n.indices, static[0])
+		if i < 0 {
+			child := &node{prefix: static}
+			n.indices += static[:1]
+			n.children = append(n.children, child)
+			return child
+		}
+
+		child := n.children[i]
+		l := commonPrefix(child.prefix, static)
+		if l < len(child.prefix) {
+			// Put a new parent above child instead of moving child's state.
+			parent := &node{
+				prefix:   child.prefix[:l],
+				indices:  child.prefix[l : l+1],
+				children: []*node{child},
+			}
+			child.prefix = child.prefix[l:]
+			n.children[i] = parent
+			child = parent
+		}
+
+		n, static = child, static[l:]
+	}
+	return n
+}
+
+// lookup matches path below n, whose own prefix is already consumed.
+// Params are recorded only along the branch that matches; the map is
+// allocated on the first param so static routes allocate nothing.
+func (n *node) lookup(path string, params *map[string]string) *node {
+	if path == "" {
+		if n.route != nil {
+			return n
+		}
+		return nil
+	}
+
+	if i := strings.IndexByte(n.indices, path[0]); i >= 0 {
+		child := n.children[i]
+		if strings.HasPrefix(path, child.prefix) {
+			if found := child.lookup(path[len(child.prefix):], params); found != nil {
+				return found
+			}
+		}
+	}
+
+	if n.paramChild != nil {
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		if end > 0 {
+			if found := n.paramChild.lookup(path[end:], params); found != nil {
+				setParam(params, n.paramChild.paramName, path[:end])
+				return found
+			}
+		}
+	}
+
+	if n.wildChild != nil && n.wildChild.route != nil {
+		setParam(params, n.wildChild.paramName, path)
+		return n.wildChild
+	}
+
+	return nil
+}
+
+func setParam(params *map[string]string, key, value string) {
+	if *params == nil {
+		*params = make(map[string]string, 4)
+	}
+	(*params)[key] = value
+}
+
+// indexDynamic returns the index of the first ':' or '*' that starts a segment.
+func indexDynamic(path string) int {
+	for i := 0; i < len(path); i++ {
+		if (path[i] == ':' || path[i] == '*') && (i == 0 || path[i-1] == '/') {
+			return i
+		}
+	}
+	return -1
+}
+
+func commonPrefix(a, b string) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
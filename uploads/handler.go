@@ -0,0 +1,188 @@
+// Package uploads
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package uploads
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wantnotshould/sol"
+)
+
+// Router is the part of a sol engine or group used by Mount.
+type Router interface {
+	POST(path string, h ...sol.HandlerFunc) *sol.Route
+	PUT(path string, h ...sol.HandlerFunc) *sol.Route
+	PATCH(path string, h ...sol.HandlerFunc) *sol.Route
+	HEAD(path string, h ...sol.HandlerFunc) *sol.Route
+	DELETE(path string, h ...sol.HandlerFunc) *sol.Route
+}
+
+// Mount registers the upload endpoints under prefix:
+//
+//	POST   prefix      create, size in the Upload-Length header
+//	HEAD   prefix/:id  current offset in Upload-Offset and Range
+//	PATCH  prefix/:id  append a chunk (PUT is accepted too)
+//	DELETE prefix/:id  abort the upload
+//
+// Chunks declare their position either with Upload-Offset or with a
+// Content-Range header ("bytes 0-1023/4096").
+func (m *Manager) Mount(r Router, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	r.POST(prefix, m.HandleCreate(prefix))
+	r.HEAD(prefix+"/:id", m.HandleStatus)
+	r.PATCH(prefix+"/:id", m.HandleChunk)
+	r.PUT(prefix+"/:id", m.HandleChunk)
+	r.DELETE(prefix+"/:id", m.HandleDelete)
+}
+
+// HandleCreate creates an upload; Location points below prefix.
+func (m *Manager) HandleCreate(prefix string) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		size, err := strconv.ParseInt(c.Header("Upload-Length"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid Upload-Length header"})
+			return
+		}
+
+		u, err := m.Create(size, parseMetadata(c.Header("Upload-Metadata")))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		c.SetHeader("Location", prefix+"/"+u.ID)
+		c.JSON(http.StatusCreated, map[string]any{"id": u.ID, "size": u.Size})
+	}
+}
+
+// HandleStatus reports the offset of the upload so clients can resume.
+func (m *Manager) HandleStatus(c *sol.Context) {
+	u, err := m.Get(c.Param("id"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	setOffsetHeaders(c, u)
+	c.Status(http.StatusOK)
+}
+
+// HandleChunk appends the request body to the upload.
+func (m *Manager) HandleChunk(c *sol.Context) {
+	id := c.Param("id")
+
+	offset, err := chunkOffset(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	// Refuse oversized chunks of known length before storing any of them.
+	if u, err := m.Get(id); err == nil && offset == u.Offset && c.Request.ContentLength > u.Size-u.Offset {
+		setOffsetHeaders(c, u)
+		c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": ErrSizeExceeded.Error()})
+		return
+	}
+
+	u, err := m.Append(id, offset, c.Request.Body)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		c.Status(http.StatusNotFound)
+		return
+	case errors.Is(err, ErrOffsetMismatch), errors.Is(err, ErrAlreadyComplete):
+		setOffsetHeaders(c, u)
+		c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		return
+	case errors.Is(err, ErrSizeExceeded):
+		setOffsetHeaders(c, u)
+		c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
+		return
+	case err != nil:
+		setOffsetHeaders(c, u)
+		c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to store chunk"})
+		return
+	}
+
+	setOffsetHeaders(c, u)
+	if u.Complete() {
+		c.JSON(http.StatusOK, map[string]any{"id": u.ID, "size": u.Size, "complete": true})
+		return
+	}
+	// 308 Resume Incomplete, as used by Content-Range based upload protocols.
+	c.Status(http.StatusPermanentRedirect)
+}
+
+// HandleDelete aborts an upload.
+func (m *Manager) HandleDelete(c *sol.Context) {
+	if err := m.Remove(c.Param("id")); err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func setOffsetHeaders(c *sol.Context, u Upload) {
+	c.SetHeader("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.SetHeader("Upload-Length", strconv.FormatInt(u.Size, 10))
+	if u.Offset > 0 {
+		c.SetHeader("Range", fmt.Sprintf("bytes=0-%d", u.Offset-1))
+	}
+}
+
+// chunkOffset reads the chunk position from Upload-Offset or Content-Range.
+func chunkOffset(c *sol.Context) (int64, error) {
+	if v := c.Header("Upload-Offset"); v != "" {
+		off, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || off < 0 {
+			return 0, fmt.Errorf("invalid Upload-Offset header")
+		}
+		return off, nil
+	}
+
+	if v := c.Header("Content-Range"); v != "" {
+		start, _, err := parseContentRange(v)
+		return start, err
+	}
+
+	return 0, fmt.Errorf("missing Upload-Offset or Content-Range header")
+}
+
+// parseContentRange parses "bytes start-end/total" and returns start and end.
+func parseContentRange(v string) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(v), "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range header")
+	}
+	rng, _, _ := strings.Cut(spec, "/")
+	s, e, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range header")
+	}
+
+	start, err1 := strconv.ParseInt(s, 10, 64)
+	end, err2 := strconv.ParseInt(e, 10, 64)
+	if err1 != nil || err2 != nil || start < 0 || end < start {
+		return 0, 0, fmt.Errorf("invalid Content-Range header")
+	}
+	return start, end, nil
+}
+
+// parseMetadata reads "key value,key2 value2" pairs.
+func parseMetadata(v string) map[string]string {
+	if v == "" {
+		return nil
+	}
+	meta := make(map[string]string)
+	for pair := range strings.SplitSeq(v, ",") {
+		k, val, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if k != "" {
+			meta[k] = val
+		}
+	}
+	return meta
+}
@@ -0,0 +1,75 @@
+// Package uploads
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package uploads
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Storage persists upload data. Chunks are always appended in order, so
+// backends without random writes (e.g. S3 multipart uploads) can implement it.
+type Storage interface {
+	// Create prepares an empty object for the upload.
+	Create(id string) error
+	// Append writes the next chunk and returns the number of bytes written.
+	Append(id string, r io.Reader) (int64, error)
+	// Open returns the assembled object.
+	Open(id string) (io.ReadCloser, error)
+	// Remove deletes the object and any partial data.
+	Remove(id string) error
+}
+
+// DiskStorage stores uploads as files in a directory.
+type DiskStorage struct {
+	Dir string
+}
+
+// NewDiskStorage returns a DiskStorage rooted at dir, creating it if needed.
+func NewDiskStorage(dir string) (*DiskStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("uploads: %w", err)
+	}
+	return &DiskStorage{Dir: dir}, nil
+}
+
+func (s *DiskStorage) path(id string) string {
+	return filepath.Join(s.Dir, filepath.Base(id)+".part")
+}
+
+func (s *DiskStorage) Create(id string) error {
+	f, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (s *DiskStorage) Append(id string, r io.Reader) (int64, error) {
+	f, err := os.OpenFile(s.path(id), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+func (s *DiskStorage) Open(id string) (io.ReadCloser, error) {
+	return os.Open(s.path(id))
+}
+
+func (s *DiskStorage) Remove(id string) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,280 @@
+// Package uploads
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package uploads
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("uploads: upload not found")
+	ErrOffsetMismatch  = errors.New("uploads: chunk does not start at current offset")
+	ErrSizeExceeded    = errors.New("uploads: chunk exceeds declared upload size")
+	ErrAlreadyComplete = errors.New("uploads: upload already complete")
+)
+
+// Upload describes the state of a resumable upload.
+type Upload struct {
+	ID        string
+	Size      int64
+	Offset    int64
+	Metadata  map[string]string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// entry guards an upload while chunks are appended.
+type entry struct {
+	mu sync.Mutex
+	Upload
+}
+
+// Complete reports whether all bytes have been received.
+func (u *Upload) Complete() bool {
+	return u.Offset >= u.Size
+}
+
+// Config configures a Manager.
+type Config struct {
+	// MaxSize limits the declared size of an upload, 0 means unlimited.
+	MaxSize int64
+	// OnProgress is called after every stored chunk.
+	OnProgress func(u Upload)
+	// OnComplete is called once the last chunk is stored.
+	OnComplete func(u Upload)
+}
+
+// Manager tracks uploads in memory and stores their data in a Storage.
+type Manager struct {
+	storage Storage
+	cfg     Config
+
+	mu      sync.RWMutex
+	uploads map[string]*entry
+}
+
+// New returns a Manager backed by storage.
+func New(storage Storage, config ...Config) *Manager {
+	m := &Manager{
+		storage: storage,
+		uploads: make(map[string]*entry),
+	}
+	if len(config) > 0 {
+		m.cfg = config[0]
+	}
+	return m
+}
+
+// Create starts a new upload of size bytes.
+func (m *Manager) Create(size int64, metadata map[string]string) (Upload, error) {
+	if size < 0 {
+		return Upload{}, fmt.Errorf("uploads: invalid size %d", size)
+	}
+	if m.cfg.MaxSize > 0 && size > m.cfg.MaxSize {
+		return Upload{}, fmt.Errorf("uploads: size %d exceeds limit %d", size, m.cfg.MaxSize)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Upload{}, err
+	}
+	if err := m.storage.Create(id); err != nil {
+		return Upload{}, fmt.Errorf("uploads: create %s: %w", id, err)
+	}
+
+	now := time.Now()
+	u := Upload{
+		ID:        id,
+		Size:      size,
+		Metadata:  metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	m.mu.Lock()
+	m.uploads[id] = &entry{Upload: u}
+	m.mu.Unlock()
+
+	return u, nil
+}
+
+// Get returns the current state of an upload.
+func (m *Manager) Get(id string) (Upload, error) {
+	u, ok := m.lookup(id)
+	if !ok {
+		return Upload{}, ErrNotFound
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.Upload, nil
+}
+
+// Append stores a chunk starting at offset. Chunks must arrive in order;
+// a chunk not starting at the current offset returns ErrOffsetMismatch
+// so the client can query the offset and resume. A chunk running past
+// the declared size returns ErrSizeExceeded before its end is stored, so
+// the upload never completes with an error and can be resumed from the
+// returned offset.
+func (m *Manager) Append(id string, offset int64, r io.Reader) (Upload, error) {
+	u, ok := m.lookup(id)
+	if !ok {
+		return Upload{}, ErrNotFound
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.Complete() {
+		return u.Upload, ErrAlreadyComplete
+	}
+	if offset != u.Offset {
+		return u.Upload, ErrOffsetMismatch
+	}
+
+	n, err := m.storage.Append(id, &sizeGuard{r: r, left: u.Size - u.Offset})
+	u.Offset += n
+	u.UpdatedAt = time.Now()
+	if errors.Is(err, ErrSizeExceeded) {
+		return u.Upload, ErrSizeExceeded
+	}
+	if err != nil {
+		return u.Upload, fmt.Errorf("uploads: append %s: %w", id, err)
+	}
+
+	snap := u.Upload
+	if m.cfg.OnProgress != nil {
+		m.cfg.OnProgress(snap)
+	}
+	if snap.Complete() && m.cfg.OnComplete != nil {
+		m.cfg.OnComplete(snap)
+	}
+	return snap, nil
+}
+
+// Open returns the data of a completed upload.
+func (m *Manager) Open(id string) (io.ReadCloser, error) {
+	u, ok := m.lookup(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	u.mu.Lock()
+	complete := u.Complete()
+	u.mu.Unlock()
+	if !complete {
+		return nil, fmt.Errorf("uploads: upload %s is incomplete", id)
+	}
+	return m.storage.Open(id)
+}
+
+// Remove deletes an upload and its data.
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	_, ok := m.uploads[id]
+	delete(m.uploads, id)
+	m.mu.Unlock()
+
+	if !ok {
+		return ErrNotFound
+	}
+	return m.storage.Remove(id)
+}
+
+// Cleanup removes incomplete uploads that have not received data within
+// maxAge and returns how many were removed.
+func (m *Manager) Cleanup(maxAge time.Duration) int {
+	cutoff := time.Now().Add(-maxAge)
+
+	var stale []string
+	m.mu.RLock()
+	for id, u := range m.uploads {
+		u.mu.Lock()
+		if !u.Complete() && u.UpdatedAt.Before(cutoff) {
+			stale = append(stale, id)
+		}
+		u.mu.Unlock()
+	}
+	m.mu.RUnlock()
+
+	removed := 0
+	for _, id := range stale {
+		if err := m.Remove(id); err != nil {
+			log.Printf("[WARN] uploads: cleanup %s: %v", id, err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// RunCleanup calls Cleanup every interval until ctx is done.
+func (m *Manager) RunCleanup(ctx context.Context, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Cleanup(maxAge)
+		}
+	}
+}
+
+func (m *Manager) lookup(id string) (*entry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	u, ok := m.uploads[id]
+	return u, ok
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("uploads: generate id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sizeGuard passes at most left bytes of r. If r holds more, the read
+// that would reach the limit fails with ErrSizeExceeded instead, so the
+// chunk is not stored up to the declared size.
+type sizeGuard struct {
+	r    io.Reader
+	left int64
+}
+
+func (g *sizeGuard) Read(p []byte) (int, error) {
+	if g.left <= 0 {
+		if g.more() {
+			return 0, ErrSizeExceeded
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > g.left {
+		p = p[:g.left]
+	}
+	n, err := g.r.Read(p)
+	if int64(n) == g.left && g.more() {
+		return 0, ErrSizeExceeded
+	}
+	g.left -= int64(n)
+	return n, err
+}
+
+// more reports whether r has data left.
+func (g *sizeGuard) more() bool {
+	var extra [1]byte
+	k, _ := io.ReadFull(g.r, extra[:])
+	return k > 0
+}
@@ -0,0 +1,136 @@
+// Package uploads
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package uploads
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestResumableUpload(t *testing.T) {
+	storage, err := NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var completed Upload
+	m := New(storage, Config{OnComplete: func(u Upload) { completed = u }})
+
+	sl := sol.New()
+	m.Mount(sl, "/uploads")
+
+	do := func(method, target, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/uploads", "", map[string]string{"Upload-Length": "11"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d", rec.Code)
+	}
+	loc := rec.Header().Get("Location")
+
+	rec = do(http.MethodPatch, loc, "hello ", map[string]string{"Content-Range": "bytes 0-5/11"})
+	if rec.Code != http.StatusPermanentRedirect || rec.Header().Get("Upload-Offset") != "6" {
+		t.Fatalf("first chunk: status = %d, offset = %s", rec.Code, rec.Header().Get("Upload-Offset"))
+	}
+
+	rec = do(http.MethodPatch, loc, "world", map[string]string{"Upload-Offset": "0"})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("out of order chunk: status = %d", rec.Code)
+	}
+
+	rec = do(http.MethodHead, loc, "", nil)
+	if rec.Header().Get("Upload-Offset") != "6" {
+		t.Fatalf("status offset = %s", rec.Header().Get("Upload-Offset"))
+	}
+
+	rec = do(http.MethodPatch, loc, "world", map[string]string{"Upload-Offset": "6"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("last chunk: status = %d", rec.Code)
+	}
+	if !completed.Complete() {
+		t.Fatal("expected OnComplete to be called")
+	}
+
+	r, err := m.Open(completed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	if string(data) != "hello world" {
+		t.Errorf("data = %q, want %q", data, "hello world")
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	storage, err := NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(storage)
+
+	u, err := m.Create(10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := m.Cleanup(time.Hour); n != 0 {
+		t.Errorf("Cleanup removed %d fresh uploads", n)
+	}
+	if n := m.Cleanup(0); n != 1 {
+		t.Errorf("Cleanup removed %d uploads, want 1", n)
+	}
+	if _, err := m.Get(u.ID); err != ErrNotFound {
+		t.Errorf("Get after cleanup: %v", err)
+	}
+}
+
+func TestAppendSizeExceeded(t *testing.T) {
+	storage, err := NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var completed bool
+	m := New(storage, Config{OnComplete: func(Upload) { completed = true }})
+
+	u, err := m.Create(11, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A reader of unknown length running past the declared size.
+	got, err := m.Append(u.ID, 0, io.MultiReader(strings.NewReader("hello "), strings.NewReader("world!!")))
+	if err != ErrSizeExceeded || got.Complete() || completed {
+		t.Fatalf("oversized chunk: %v, offset %d, completed %v", err, got.Offset, completed)
+	}
+
+	got, err = m.Append(u.ID, got.Offset, strings.NewReader("hello world"[got.Offset:]))
+	if err != nil || !got.Complete() || !completed {
+		t.Fatalf("resumed chunk: %v, offset %d, completed %v", err, got.Offset, completed)
+	}
+
+	sl := sol.New()
+	m.Mount(sl, "/uploads")
+	u, _ = m.Create(4, nil)
+	req := httptest.NewRequest(http.MethodPatch, "/uploads/"+u.ID, strings.NewReader("too long"))
+	req.Header.Set("Upload-Offset", "0")
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge || rec.Header().Get("Upload-Offset") != "0" {
+		t.Errorf("oversized request: %d, offset %s", rec.Code, rec.Header().Get("Upload-Offset"))
+	}
+}
@@ -0,0 +1,25 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import "github.com/wantnotshould/sol/validator"
+
+// Validate checks obj against its validate tags and returns
+// validator.FieldErrors if any check fails. Messages are in the
+// request language picked from AcceptLanguages among those the validator
+// has messages for, so concurrent requests are each answered in their
+// own language; validator.SetLanguage only sets the fallback.
+func (c *Context) Validate(obj any) error {
+	if errs := c.Validator().Validate(obj); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validator returns the validator Validate uses for the request, for
+// binders that validate or report errors themselves.
+func (c *Context) Validator() *validator.Validator {
+	return validator.New().WithLanguage(validator.MatchLanguage(c.AcceptLanguages()...))
+}
@@ -0,0 +1,51 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wantnotshould/sol/validator"
+)
+
+func TestContext_Validate(t *testing.T) {
+	type signup struct {
+		Email string `json:"email" validate:"required"`
+	}
+
+	tests := []struct {
+		acceptLanguage string
+		locale         string
+		want           string
+	}{
+		{"", "", "This field is required"},
+		{"zh-CN,zh;q=0.9,en;q=0.8", "", "此字段是必填的"},
+		{"fr-FR, en;q=0.5", "", "This field is required"},
+		{"en", "zh", "此字段是必填的"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage+"/"+tt.locale, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/signup", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			c := &Context{Request: req, locale: tt.locale}
+
+			var verrs validator.ValidationErrors
+			if err := c.Validate(&signup{}); !errors.As(err, &verrs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			if got := verrs["email"][0]; got != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	c := &Context{Request: httptest.NewRequest(http.MethodPost, "/signup", nil)}
+	if err := c.Validate(&signup{Email: "perry@example.com"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package validator
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package validator
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// enums maps registered enum types to their allowed values.
+var enums sync.Map
+
+// RegisterEnum declares the allowed values of a string-backed type.
+// Struct fields of that type are then checked as if tagged with oneof.
+func RegisterEnum(t reflect.Type, values ...string) {
+	enums.Store(t, slices.Clone(values))
+}
+
+// EnumValues returns the allowed values of a registered enum type.
+func EnumValues(t reflect.Type) ([]string, bool) {
+	v, ok := enums.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return v.([]string), true
+}
+
+// checkOneOf checks value against the allowed values.
+func checkOneOf(lang Language, value any, allowed []string) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.String || v.Len() == 0 {
+		return ""
+	}
+	if !slices.Contains(allowed, v.String()) {
+		return GetMessageIn(lang, "oneof", strings.Join(allowed, ", "))
+	}
+	return ""
+}
@@ -4,7 +4,13 @@
 // license that can be found in the LICENSE file.
 package validator
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+)
 
 type Language string
 
@@ -23,8 +29,17 @@ var messages = map[Language]map[string]string{
 		"gte":      "This field must be greater than or equal to %v",
 		"lt":       "This field must be less than %v",
 		"lte":      "This field must be less than or equal to %v",
+		"gt_len":   "This field must be longer than %v characters",
+		"gte_len":  "This field must be at least %v characters long",
+		"lt_len":   "This field must be shorter than %v characters",
+		"lte_len":  "This field must be at most %v characters long",
+		"gt_time":  "This field must be after %v",
+		"gte_time": "This field must not be before %v",
+		"lt_time":  "This field must be before %v",
+		"lte_time": "This field must not be after %v",
 		"email":    "This field must be a valid email address",
 		"regex":    "This field format is invalid",
+		"oneof":    "This field must be one of %v",
 	},
 	ZH: {
 		"required": "此字段是必填的",
@@ -35,8 +50,17 @@ var messages = map[Language]map[string]string{
 		"gte":      "此字段必须大于或等于 %v",
 		"lt":       "此字段必须小于 %v",
 		"lte":      "此字段必须小于或等于 %v",
+		"gt_len":   "此字段长度必须大于 %v 个字符",
+		"gte_len":  "此字段长度必须至少为 %v 个字符",
+		"lt_len":   "此字段长度必须小于 %v 个字符",
+		"lte_len":  "此字段长度不能超过 %v 个字符",
+		"gt_time":  "此字段必须晚于 %v",
+		"gte_time": "此字段不能早于 %v",
+		"lt_time":  "此字段必须早于 %v",
+		"lte_time": "此字段不能晚于 %v",
 		"email":    "此字段必须是有效的电子邮件地址",
 		"regex":    "此字段格式无效",
+		"oneof":    "此字段必须是 %v 之一",
 	},
 }
 
@@ -47,7 +71,56 @@ func SetLanguage(lang Language) {
 	currentLanguage = lang
 }
 
-// ValidationErrors represents validation errors
+// RegisterLanguage adds the messages of a language, or replaces messages
+// of a known one. Rules missing from msgs fall back to EN. Like
+// SetLanguage it must be called before validation starts, e.g. in init.
+func RegisterLanguage(lang Language, msgs map[string]string) {
+	catalog := messages[lang]
+	if catalog == nil {
+		catalog = make(map[string]string, len(msgs))
+		messages[lang] = catalog
+	}
+	maps.Copy(catalog, msgs)
+}
+
+// MatchLanguage returns the first of the language tags, e.g. taken from
+// Accept-Language, that has messages. "zh-CN" matches ZH. Without a
+// match the language set with SetLanguage is returned.
+func MatchLanguage(tags ...string) Language {
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+		for {
+			if _, ok := messages[Language(tag)]; ok {
+				return Language(tag)
+			}
+			i := strings.LastIndexByte(tag, '-')
+			if i < 0 {
+				break
+			}
+			tag = tag[:i]
+		}
+	}
+	return currentLanguage
+}
+
+// FieldError is a single failed check of a field. Code is the rule name,
+// e.g. "required" or "min", or "type" for a value that could not be
+// converted to the field type. Param is the rule parameter, if any.
+type FieldError struct {
+	// Field is the name of the field, set in FieldErrors
+	Field   string `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+}
+
+// Error implements the error interface
+func (fe FieldError) Error() string {
+	return fe.Message
+}
+
+// ValidationErrors maps field names to their error messages. FieldErrors
+// carries the same errors with their codes and parameters.
 type ValidationErrors map[string][]string
 
 // Error implements the error interface
@@ -66,16 +139,110 @@ func (ve ValidationErrors) Add(field, message string) {
 	ve[field] = append(ve[field], message)
 }
 
+// FieldErrors holds validation errors in the order the fields appear in
+// the struct. Binding and validation both report through it, so clients
+// see one shape, with the fields in that order:
+//
+//	{"error":"validation failed","fields":{"age":[{"code":"min","message":"...","param":"18"}]}}
+//
+// errors.As also accepts a *ValidationErrors target for the message map.
+type FieldErrors []FieldError
+
+// Error implements the error interface
+func (fe FieldErrors) Error() string {
+	if len(fe) == 0 {
+		return ""
+	}
+	return "validation failed"
+}
+
+// Add appends an error for field.
+func (fe *FieldErrors) Add(field string, err FieldError) {
+	err.Field = field
+	*fe = append(*fe, err)
+}
+
+// Map returns the error messages keyed by field name.
+func (fe FieldErrors) Map() ValidationErrors {
+	ve := make(ValidationErrors)
+	for _, err := range fe {
+		ve.Add(err.Field, err.Message)
+	}
+	return ve
+}
+
+// ByField returns the errors keyed by field name.
+func (fe FieldErrors) ByField() map[string][]FieldError {
+	m := make(map[string][]FieldError)
+	for _, err := range fe {
+		m[err.Field] = append(m[err.Field], err)
+	}
+	return m
+}
+
+// As lets errors.As fill a *ValidationErrors with the map view.
+func (fe FieldErrors) As(target any) bool {
+	if ve, ok := target.(*ValidationErrors); ok {
+		*ve = fe.Map()
+		return true
+	}
+	return false
+}
+
+// MarshalJSON encodes the errors in the envelope shown on FieldErrors.
+func (fe FieldErrors) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"error":"validation failed","fields":{`)
+
+	seen := make(map[string]bool)
+	for _, err := range fe {
+		if seen[err.Field] {
+			continue
+		}
+		seen[err.Field] = true
+
+		var entries []FieldError
+		for _, e := range fe {
+			if e.Field == err.Field {
+				entries = append(entries, e)
+			}
+		}
+		key, _ := json.Marshal(err.Field)
+		value, jerr := json.Marshal(entries)
+		if jerr != nil {
+			return nil, jerr
+		}
+		if len(seen) > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteString("}}")
+	return buf.Bytes(), nil
+}
+
 // GetMessage returns the localized validation message for a given rule
 func GetMessage(rule string, param any) string {
-	// First try the current language
-	if msg, ok := messages[currentLanguage][rule]; ok {
+	return GetMessageIn(currentLanguage, rule, param)
+}
+
+// GetMessageIn returns the validation message for a given rule in lang.
+// An empty lang means the language set with SetLanguage.
+func GetMessageIn(lang Language, rule string, param any) string {
+	if lang == "" {
+		lang = currentLanguage
+	}
+	// First try the requested language
+	if msg, ok := messages[lang][rule]; ok {
 		if param != nil {
 			return fmt.Sprintf(msg, param)
 		}
 		return msg
 	}
-	// If the rule is not found in the current language, fallback to the default language (EN)
+	// If the rule is not found in the requested language, fallback to the default language (EN)
 	if msg, ok := messages[EN][rule]; ok {
 		if param != nil {
 			return fmt.Sprintf(msg, param)
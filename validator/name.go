@@ -0,0 +1,36 @@
+// Package validator
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NameFunc resolves the name a struct field has in error paths.
+// Nested fields are joined with dots and slice elements get their index,
+// e.g. "items[2].price".
+type NameFunc func(field reflect.StructField) string
+
+// TagName returns a NameFunc using the name in the given struct tag, e.g.
+// "json" or "form", and the lowercased Go field name for fields without
+// one.
+func TagName(tag string) NameFunc {
+	return func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			return strings.ToLower(field.Name)
+		}
+		return name
+	}
+}
+
+var defaultNameFunc = TagName("json")
+
+// SetNameFunc sets the NameFunc of new validators, TagName("json") by
+// default. Like SetLanguage it must be called before validation starts.
+func SetNameFunc(fn NameFunc) {
+	defaultNameFunc = fn
+}
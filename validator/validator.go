@@ -5,26 +5,63 @@
 package validator
 
 import (
+	"cmp"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-type Validator struct{}
+type Validator struct {
+	// lang is the language of the messages, "" for the one set with SetLanguage
+	lang Language
+	// name resolves field names in error paths
+	name NameFunc
+}
 
 func New() *Validator {
-	return &Validator{}
+	return &Validator{name: defaultNameFunc}
+}
+
+// WithNameFunc sets how field names in error paths are resolved.
+func (v *Validator) WithNameFunc(fn NameFunc) *Validator {
+	v.name = fn
+	return v
+}
+
+// WithLanguage sets the language of the messages, overriding SetLanguage
+// for this validator, e.g. with the language negotiated for a request.
+func (v *Validator) WithLanguage(lang Language) *Validator {
+	v.lang = lang
+	return v
 }
 
+// FieldName returns the name field has in error paths, so binders can
+// key their own errors the same way.
+func (v *Validator) FieldName(field reflect.StructField) string {
+	if v.name == nil {
+		return defaultNameFunc(field)
+	}
+	return v.name(field)
+}
+
+// ValidateStruct validates obj, keyed by field name. See Validate for
+// the errors in field order.
 func (v *Validator) ValidateStruct(obj any) ValidationErrors {
-	errs := make(ValidationErrors)
+	return v.Validate(obj).Map()
+}
+
+// Validate validates obj and returns its errors in struct field order.
+func (v *Validator) Validate(obj any) FieldErrors {
+	var errs FieldErrors
 
 	val := reflect.ValueOf(obj)
 	if val.Kind() == reflect.Pointer {
 		val = val.Elem()
 	}
 	if val.Kind() != reflect.Struct {
-		errs.Add("", "must be a struct or struct pointer")
+		errs.Add("", FieldError{Code: "type", Message: "must be a struct or struct pointer"})
 		return errs
 	}
 
@@ -38,40 +75,55 @@ func (v *Validator) ValidateStruct(obj any) ValidationErrors {
 		}
 
 		tag := field.Tag.Get("validate")
-		if tag == "" {
+		enumValues, isEnum := EnumValues(field.Type)
+		if tag == "" && !isEnum {
 			continue
 		}
 
-		fieldName := field.Tag.Get("json")
-		if fieldName == "" || fieldName == "-" {
-			fieldName = strings.ToLower(field.Name)
-		}
+		fieldName := v.FieldName(field)
 
 		rules := ParseTag(tag)
 
-		if fieldVal.Kind() == reflect.Struct {
-			nestedErrs := v.ValidateStruct(fieldVal.Interface())
+		if sv := reflect.Indirect(fieldVal); sv.Kind() == reflect.Struct && sv.Type() != reflect.TypeFor[time.Time]() {
+			nestedErrs := v.Validate(sv.Interface())
 
 			if isEmpty(fieldVal.Interface()) {
-				errs.Add(fieldName, "This field is required")
+				errs.Add(fieldName, FieldError{Code: "required", Message: GetMessageIn(v.lang, "required", nil)})
 			}
 
-			for nestedField, nestedMessages := range nestedErrs {
-				for _, msg := range nestedMessages {
-					errs.Add(fieldName+"."+nestedField, msg)
-				}
+			for _, nested := range nestedErrs {
+				errs.Add(fieldName+"."+nested.Field, nested)
 			}
 			continue
 		}
 
+		if elems := structElems(fieldVal); elems.IsValid() {
+			for j := 0; j < elems.Len(); j++ {
+				elem := reflect.Indirect(elems.Index(j))
+				if !elem.IsValid() {
+					continue
+				}
+				prefix := fieldName + "[" + strconv.Itoa(j) + "]."
+				for _, nested := range v.Validate(elem.Interface()) {
+					errs.Add(prefix+nested.Field, nested)
+				}
+			}
+		}
+
 		for _, rule := range rules {
 			if rule.Name == "required" && isEmpty(fieldVal.Interface()) {
-				errs.Add(fieldName, GetMessage("required", nil))
+				errs.Add(fieldName, FieldError{Code: "required", Message: GetMessageIn(v.lang, "required", nil)})
 				break
 			}
 
-			if errMsg := v.checkRule(fieldVal.Interface(), rule); errMsg != "" {
-				errs.Add(fieldName, errMsg)
+			if errMsg := v.checkRule(fieldVal.Interface(), rule, val); errMsg != "" {
+				errs.Add(fieldName, FieldError{Code: rule.Name, Message: errMsg, Param: rule.Param})
+			}
+		}
+
+		if isEnum {
+			if errMsg := checkOneOf(v.lang, fieldVal.Interface(), enumValues); errMsg != "" {
+				errs.Add(fieldName, FieldError{Code: "oneof", Message: errMsg, Param: strings.Join(enumValues, " ")})
 			}
 		}
 	}
@@ -79,30 +131,28 @@ func (v *Validator) ValidateStruct(obj any) ValidationErrors {
 	return errs
 }
 
-func (v *Validator) checkRule(value any, rule Rule) string {
+// checkRule checks value against rule. parent is the struct holding the
+// field, for rules referring to other fields.
+func (v *Validator) checkRule(value any, rule Rule, parent reflect.Value) string {
 	switch rule.Name {
 	case "required":
 		if isEmpty(value) {
-			return GetMessage("required", nil)
+			return GetMessageIn(v.lang, "required", nil)
 		}
 	case "min":
-		return checkMin(value, rule.Param)
+		return checkMin(v.lang, value, rule.Param)
 	case "max":
-		return checkMax(value, rule.Param)
+		return checkMax(v.lang, value, rule.Param)
 	case "len":
-		return checkLen(value, rule.Param)
-	case "gt":
-		return checkGt(value, rule.Param)
-	case "gte":
-		return checkGte(value, rule.Param)
-	case "lt":
-		return checkLt(value, rule.Param)
-	case "lte":
-		return checkLte(value, rule.Param)
+		return checkLen(v.lang, value, rule.Param)
+	case "gt", "gte", "lt", "lte":
+		return checkCompare(v.lang, rule.Name, value, rule.Param, parent)
+	case "oneof":
+		return checkOneOf(v.lang, value, strings.Fields(rule.Param))
 	case "email":
 		if str, ok := value.(string); ok && str != "" {
 			if !isValidEmail(str) {
-				return GetMessage("email", nil)
+				return GetMessageIn(v.lang, "email", nil)
 			}
 		}
 	case "regex":
@@ -117,17 +167,36 @@ func (v *Validator) checkRule(value any, rule Rule) string {
 			}
 
 			if !re.MatchString(str) {
-				return GetMessage("regex", nil)
+				return GetMessageIn(v.lang, "regex", nil)
 			}
 		}
 	}
 	return ""
 }
 
+// structElems returns v if it is a slice or array of structs or struct
+// pointers, whose elements are validated as nested structs.
+func structElems(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}
+	}
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeFor[time.Time]() {
+		return reflect.Value{}
+	}
+	return v
+}
+
 func isEmpty(value any) bool {
 	if value == nil {
 		return true
 	}
+	if t, ok := value.(time.Time); ok {
+		return t.IsZero()
+	}
 	switch v := reflect.ValueOf(value); v.Kind() {
 	case reflect.String, reflect.Array, reflect.Map, reflect.Slice:
 		return v.Len() == 0
@@ -165,41 +234,41 @@ func toInt(value any) (int, bool) {
 	return 0, false
 }
 
-func checkMin(value any, param string) string {
+func checkMin(lang Language, value any, param string) string {
 	p, err := strconv.ParseFloat(param, 64)
 	if err != nil {
 		return ""
 	}
 	if i, ok := toInt(value); ok && float64(i) < p {
-		return GetMessage("min", int(p))
+		return GetMessageIn(lang, "min", int(p))
 	}
 	if f, ok := toFloat(value); ok && f < p {
-		return GetMessage("min", int(p))
+		return GetMessageIn(lang, "min", int(p))
 	}
 	if s, ok := value.(string); ok && len(s) < int(p) {
-		return GetMessage("min", int(p))
+		return GetMessageIn(lang, "min", int(p))
 	}
 	return ""
 }
 
-func checkMax(value any, param string) string {
+func checkMax(lang Language, value any, param string) string {
 	p, err := strconv.ParseFloat(param, 64)
 	if err != nil {
 		return ""
 	}
 	if i, ok := toInt(value); ok && float64(i) > p {
-		return GetMessage("max", int(p))
+		return GetMessageIn(lang, "max", int(p))
 	}
 	if f, ok := toFloat(value); ok && f > p {
-		return GetMessage("max", int(p))
+		return GetMessageIn(lang, "max", int(p))
 	}
 	if s, ok := value.(string); ok && len(s) > int(p) {
-		return GetMessage("max", int(p))
+		return GetMessageIn(lang, "max", int(p))
 	}
 	return ""
 }
 
-func checkLen(value any, param string) string {
+func checkLen(lang Language, value any, param string) string {
 	p, err := strconv.Atoi(param)
 	if err != nil {
 		return "Invalid length parameter"
@@ -208,7 +277,7 @@ func checkLen(value any, param string) string {
 	switch v := value.(type) {
 	case string:
 		if len(v) != p {
-			return GetMessage("len", p)
+			return GetMessageIn(lang, "len", p)
 		}
 	default:
 		return "Unsupported type for len check"
@@ -217,46 +286,93 @@ func checkLen(value any, param string) string {
 	return ""
 }
 
-func checkGt(value any, param string) string {
-	p, err := strconv.ParseFloat(param, 64)
-	if err != nil {
+// checkCompare implements gt, gte, lt and lte. Numbers compare by value
+// and strings by their length in characters. A time.Time compares against
+// the parameter, which is an RFC 3339 time, "now", "now" plus or minus a
+// duration such as "now+24h", or the name of another time.Time field of
+// the struct; a zero time is left to required.
+func checkCompare(lang Language, rule string, value any, param string, parent reflect.Value) string {
+	if tp, ok := value.(*time.Time); ok {
+		if tp == nil {
+			return ""
+		}
+		value = *tp
+	}
+	if t, ok := value.(time.Time); ok {
+		p, ok := timeParam(param, parent)
+		if !ok || t.IsZero() {
+			return ""
+		}
+		if !compareOK(rule, t.Compare(p)) {
+			return GetMessageIn(lang, rule+"_time", param)
+		}
 		return ""
 	}
-	if f, ok := toFloat(value); ok && f <= p {
-		return GetMessage("gt", p)
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.String {
+		p, err := strconv.Atoi(param)
+		if err != nil {
+			return ""
+		}
+		if !compareOK(rule, cmp.Compare(utf8.RuneCountInString(rv.String()), p)) {
+			return GetMessageIn(lang, rule+"_len", p)
+		}
+		return ""
 	}
-	return ""
-}
 
-func checkGte(value any, param string) string {
 	p, err := strconv.ParseFloat(param, 64)
 	if err != nil {
 		return ""
 	}
-	if f, ok := toFloat(value); ok && f < p {
-		return GetMessage("gte", p)
+	if f, ok := toFloat(value); ok && !compareOK(rule, cmp.Compare(f, p)) {
+		return GetMessageIn(lang, rule, p)
 	}
 	return ""
 }
 
-func checkLt(value any, param string) string {
-	p, err := strconv.ParseFloat(param, 64)
-	if err != nil {
-		return ""
-	}
-	if f, ok := toFloat(value); ok && f >= p {
-		return GetMessage("lt", p)
+// compareOK reports whether the comparison result c satisfies rule.
+func compareOK(rule string, c int) bool {
+	switch rule {
+	case "gt":
+		return c > 0
+	case "gte":
+		return c >= 0
+	case "lt":
+		return c < 0
+	case "lte":
+		return c <= 0
 	}
-	return ""
+	return true
 }
 
-func checkLte(value any, param string) string {
-	p, err := strconv.ParseFloat(param, 64)
-	if err != nil {
-		return ""
+// timeParam resolves the time a comparison rule refers to.
+func timeParam(param string, parent reflect.Value) (time.Time, bool) {
+	if rest, ok := strings.CutPrefix(param, "now"); ok {
+		if rest == "" {
+			return time.Now(), true
+		}
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Now().Add(d), true
 	}
-	if f, ok := toFloat(value); ok && f > p {
-		return GetMessage("lte", p)
+
+	if t, err := time.Parse(time.RFC3339, param); err == nil {
+		return t, true
 	}
-	return ""
+
+	if parent.Kind() == reflect.Struct {
+		if f := parent.FieldByName(param); f.IsValid() && f.CanInterface() {
+			switch t := f.Interface().(type) {
+			case time.Time:
+				return t, !t.IsZero()
+			case *time.Time:
+				if t != nil && !t.IsZero() {
+					return *t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
 }
@@ -5,8 +5,12 @@
 package validator
 
 import (
+	"encoding/json"
+	"errors"
 	"maps"
+	"reflect"
 	"testing"
+	"time"
 )
 
 type User struct {
@@ -206,3 +210,213 @@ func TestValidateStructWithNested(t *testing.T) {
 		})
 	}
 }
+
+type status string
+
+type post struct {
+	Status   status `json:"status"`
+	Priority string `json:"priority" validate:"oneof=low high"`
+}
+
+func TestValidateEnum(t *testing.T) {
+	RegisterEnum(reflect.TypeFor[status](), "draft", "published")
+
+	v := New()
+	if errs := v.ValidateStruct(post{Status: "draft", Priority: "low"}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	errs := v.ValidateStruct(post{Status: "deleted", Priority: "urgent"})
+	if got := errs["status"]; len(got) != 1 || got[0] != "This field must be one of draft, published" {
+		t.Errorf("status errors = %v", got)
+	}
+	if got := errs["priority"]; len(got) != 1 || got[0] != "This field must be one of low, high" {
+		t.Errorf("priority errors = %v", got)
+	}
+}
+
+func TestValidationErrorsJSON(t *testing.T) {
+	errs := New().Validate(struct {
+		Age int `json:"age" validate:"min=18"`
+	}{Age: 12})
+
+	got, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"error":"validation failed","fields":{"age":[{"code":"min","message":"This field must be at least 18","param":"18"}]}}`
+	if string(got) != want {
+		t.Errorf("json = %s, want %s", got, want)
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	RegisterLanguage("de", map[string]string{"required": "Dieses Feld ist erforderlich"})
+
+	tests := []struct {
+		tags []string
+		want Language
+	}{
+		{nil, EN},
+		{[]string{"zh-Hans-CN"}, ZH},
+		{[]string{"fr", "de-AT"}, "de"},
+		{[]string{"zh_TW"}, ZH},
+	}
+	for _, tt := range tests {
+		if got := MatchLanguage(tt.tags...); got != tt.want {
+			t.Errorf("MatchLanguage(%v) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+
+	if got := GetMessageIn("de", "min", 3); got != "This field must be at least 3" {
+		t.Errorf("missing rule should fall back to EN, got %q", got)
+	}
+}
+
+func TestFieldErrorsOrder(t *testing.T) {
+	type signup struct {
+		Zip   string `json:"zip" validate:"required"`
+		Name  string `json:"name" validate:"required,len=5"`
+		Email string `json:"email" validate:"email"`
+		Age   int    `json:"age" validate:"min=18"`
+	}
+
+	errs := New().Validate(signup{Name: "Al", Email: "x", Age: 3})
+
+	var fields []string
+	for _, fe := range errs {
+		fields = append(fields, fe.Field)
+	}
+	if want := []string{"zip", "name", "email", "age"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+
+	got, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"error":"validation failed","fields":{` +
+		`"zip":[{"code":"required","message":"This field is required"}],` +
+		`"name":[{"code":"len","message":"This field must be exactly 5 characters","param":"5"}],` +
+		`"email":[{"code":"email","message":"This field must be a valid email address"}],` +
+		`"age":[{"code":"min","message":"This field must be at least 18","param":"18"}]}}`
+	if string(got) != want {
+		t.Errorf("json = %s\nwant %s", got, want)
+	}
+
+	var ve ValidationErrors
+	if !errors.As(error(errs), &ve) || len(ve["name"]) != 1 {
+		t.Errorf("map view = %v", ve)
+	}
+}
+
+func TestCompareTimesAndLengths(t *testing.T) {
+	type booking struct {
+		Code  string    `json:"code" validate:"gte=3,lt=6"`
+		Start time.Time `json:"start" validate:"required,gt=now"`
+		End   time.Time `json:"end" validate:"gt=Start,lte=2100-01-01T00:00:00Z"`
+		Seats int       `json:"seats" validate:"gt=0"`
+	}
+
+	start := time.Now().Add(time.Hour)
+	tests := []struct {
+		name   string
+		input  booking
+		fields []string
+	}{
+		{"valid", booking{Code: "日本語", Start: start, End: start.Add(time.Hour), Seats: 2}, nil},
+		{"short code", booking{Code: "ab", Start: start, End: start.Add(time.Hour), Seats: 2}, []string{"code"}},
+		{"long code", booking{Code: "abcdef", Start: start, End: start.Add(time.Hour), Seats: 2}, []string{"code"}},
+		{"past start", booking{Code: "abc", Start: start.Add(-2 * time.Hour), End: start, Seats: 2}, []string{"start"}},
+		{"missing start", booking{Code: "abc", Seats: 2}, []string{"start"}},
+		{"end before start", booking{Code: "abc", Start: start, End: start.Add(-time.Minute), Seats: 2}, []string{"end"}},
+		{"end too late", booking{Code: "abc", Start: start, End: time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC), Seats: 2}, []string{"end"}},
+		{"no seats", booking{Code: "abc", Start: start, End: start.Add(time.Hour)}, []string{"seats"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, fe := range New().Validate(tt.input) {
+				fields = append(fields, fe.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+
+	errs := New().Validate(booking{Code: "ab", Start: start, End: start.Add(-time.Minute), Seats: 1})
+	if got := errs[0].Message; got != "This field must be at least 3 characters long" {
+		t.Errorf("length message = %q", got)
+	}
+	if got := errs[1].Message; got != "This field must be after Start" {
+		t.Errorf("time message = %q", got)
+	}
+}
+
+func TestCompareTimePointers(t *testing.T) {
+	type window struct {
+		Start *time.Time `json:"start" validate:"gt=now"`
+		End   *time.Time `json:"end" validate:"gt=Start"`
+	}
+
+	start := time.Now().Add(time.Hour)
+	before, after, past := start.Add(-time.Minute), start.Add(time.Minute), time.Now().Add(-time.Hour)
+	tests := []struct {
+		name   string
+		input  window
+		fields []string
+	}{
+		{"valid", window{Start: &start, End: &after}, nil},
+		{"nil", window{}, nil},
+		{"past start", window{Start: &past}, []string{"start"}},
+		{"end before start", window{Start: &start, End: &before}, []string{"end"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, fe := range New().Validate(tt.input) {
+				fields = append(fields, fe.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestNestedPaths(t *testing.T) {
+	type item struct {
+		SKU   string  `json:"sku,omitempty" validate:"required"`
+		Price float64 `validate:"gt=0"`
+	}
+	type order struct {
+		Items    []item   `json:"items" validate:"required"`
+		Gift     *item    `json:"gift" validate:"required"`
+		Shipping *Address `form:"ship_to" validate:"required"`
+	}
+
+	o := order{
+		Items:    []item{{SKU: "a", Price: 1}, {SKU: "b", Price: 2}, {Price: 0}},
+		Gift:     &item{SKU: "g"},
+		Shipping: &Address{Street: "Maple"},
+	}
+
+	var paths []string
+	for _, fe := range New().Validate(o) {
+		paths = append(paths, fe.Field)
+	}
+	want := []string{"items[2].sku", "items[2].price", "gift.price", "shipping.city"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	paths = nil
+	for _, fe := range New().WithNameFunc(TagName("form")).Validate(o) {
+		paths = append(paths, fe.Field)
+	}
+	want = []string{"items[2].sku", "items[2].price", "gift.price", "ship_to.city"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("form paths = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,265 @@
+// Package versioning
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package versioning
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+// contextKey is the Context key holding the resolved version name.
+const contextKey = "sol.api_version"
+
+// Router is the part of a sol engine or group used by Mount.
+type Router interface {
+	GET(path string, h ...sol.HandlerFunc) *sol.Route
+	POST(path string, h ...sol.HandlerFunc) *sol.Route
+	PUT(path string, h ...sol.HandlerFunc) *sol.Route
+	DELETE(path string, h ...sol.HandlerFunc) *sol.Route
+	PATCH(path string, h ...sol.HandlerFunc) *sol.Route
+	OPTIONS(path string, h ...sol.HandlerFunc) *sol.Route
+	HEAD(path string, h ...sol.HandlerFunc) *sol.Route
+}
+
+// Config controls how the requested version is resolved.
+type Config struct {
+	// Default is used when the request names no version.
+	Default string
+	// Path also registers every route under "/v{version}".
+	Path bool
+	// Header names the request header carrying the version, e.g. "API-Version".
+	Header string
+	// MediaTypeParam names the Accept parameter carrying the version,
+	// e.g. "version" for "application/json; version=2".
+	MediaTypeParam string
+}
+
+// API collects the routes of every version until Mount registers them.
+type API struct {
+	cfg      Config
+	versions []*Version
+}
+
+// New returns an API with the given resolution rules.
+func New(cfg Config) *API {
+	return &API{cfg: cfg}
+}
+
+// Option configures a Version.
+type Option func(v *Version)
+
+// Deprecated marks the version deprecated since t; responses carry a
+// Deprecation header, plus a Link to docs when link is not empty.
+func Deprecated(t time.Time, link string) Option {
+	return func(v *Version) {
+		v.deprecated = t
+		v.link = link
+	}
+}
+
+// Sunset announces when the version will be removed with a Sunset header.
+func Sunset(t time.Time) Option {
+	return func(v *Version) {
+		v.sunset = t
+	}
+}
+
+type route struct {
+	method   string
+	path     string
+	handlers []sol.HandlerFunc
+}
+
+// Version is one version of the API. Its registration methods mirror a
+// sol group; handlers of a route join the request's chain, so middleware
+// among them can call c.Next.
+type Version struct {
+	name       string
+	deprecated time.Time
+	sunset     time.Time
+	link       string
+	routes     []route
+}
+
+// Version declares a version and returns it for route registration.
+func (a *API) Version(name string, opts ...Option) *Version {
+	v := &Version{name: name}
+	for _, opt := range opts {
+		opt(v)
+	}
+	a.versions = append(a.versions, v)
+	return v
+}
+
+func (v *Version) add(method, path string, h []sol.HandlerFunc) {
+	v.routes = append(v.routes, route{method: method, path: path, handlers: h})
+}
+
+func (v *Version) GET(path string, h ...sol.HandlerFunc)     { v.add(http.MethodGet, path, h) }
+func (v *Version) POST(path string, h ...sol.HandlerFunc)    { v.add(http.MethodPost, path, h) }
+func (v *Version) PUT(path string, h ...sol.HandlerFunc)     { v.add(http.MethodPut, path, h) }
+func (v *Version) DELETE(path string, h ...sol.HandlerFunc)  { v.add(http.MethodDelete, path, h) }
+func (v *Version) PATCH(path string, h ...sol.HandlerFunc)   { v.add(http.MethodPatch, path, h) }
+func (v *Version) OPTIONS(path string, h ...sol.HandlerFunc) { v.add(http.MethodOptions, path, h) }
+func (v *Version) HEAD(path string, h ...sol.HandlerFunc)    { v.add(http.MethodHead, path, h) }
+
+// Mount registers the collected routes on r. Each distinct method and
+// path gets one route that dispatches on the resolved version; with
+// Config.Path the routes are also registered under "/v{version}".
+//
+// The handlers of every version are registered on the shared route, each
+// running only for its own version, so a c.Next() in them continues the
+// route's chain like on any other route.
+func (a *API) Mount(r Router) {
+	type key struct{ method, path string }
+	dispatch := make(map[key]map[*Version][]sol.HandlerFunc)
+	var order []key
+
+	for _, v := range a.versions {
+		for _, rt := range v.routes {
+			k := key{rt.method, rt.path}
+			if dispatch[k] == nil {
+				dispatch[k] = make(map[*Version][]sol.HandlerFunc)
+				order = append(order, k)
+			}
+			dispatch[k][v] = rt.handlers
+
+			if a.cfg.Path {
+				handlers := append([]sol.HandlerFunc{fixed(v)}, rt.handlers...)
+				register(r, rt.method, "/v"+v.name+"/"+strings.TrimPrefix(rt.path, "/"), handlers)
+			}
+		}
+	}
+
+	for _, k := range order {
+		byVersion := dispatch[k]
+		handlers := []sol.HandlerFunc{a.dispatcher(byVersion)}
+		for _, v := range a.versions {
+			for _, h := range byVersion[v] {
+				handlers = append(handlers, only(v, h))
+			}
+		}
+		register(r, k.method, k.path, handlers)
+	}
+}
+
+func fixed(v *Version) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		serve(c, v)
+	}
+}
+
+// dispatcher resolves the version of the request, answering 404 if it
+// has no handlers for the route. The response varies on the headers the
+// version is read from, so caches keep one copy per version.
+func (a *API) dispatcher(byVersion map[*Version][]sol.HandlerFunc) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		if a.cfg.Header != "" {
+			c.Writer.Header().Add("Vary", a.cfg.Header)
+		}
+		if a.cfg.MediaTypeParam != "" {
+			c.Writer.Header().Add("Vary", "Accept")
+		}
+
+		name := a.resolve(c)
+		v := a.lookup(name)
+		if _, ok := byVersion[v]; !ok {
+			c.String(http.StatusNotFound, "Unsupported API version %q", name)
+			c.Abort()
+			return
+		}
+		serve(c, v)
+	}
+}
+
+// only runs h when v serves the request.
+func only(v *Version, h sol.HandlerFunc) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		if Current(c) == v.name {
+			h(c)
+		}
+	}
+}
+
+// resolve picks the requested version from the header, then the Accept
+// media type, falling back to the default.
+func (a *API) resolve(c *sol.Context) string {
+	if a.cfg.Header != "" {
+		if v := strings.TrimSpace(c.Header(a.cfg.Header)); v != "" {
+			return strings.TrimPrefix(v, "v")
+		}
+	}
+
+	if a.cfg.MediaTypeParam != "" {
+		for part := range strings.SplitSeq(c.Header("Accept"), ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			if v := params[a.cfg.MediaTypeParam]; v != "" {
+				return strings.TrimPrefix(v, "v")
+			}
+		}
+	}
+
+	return a.cfg.Default
+}
+
+func (a *API) lookup(name string) *Version {
+	for _, v := range a.versions {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func serve(c *sol.Context, v *Version) {
+	c.Set(contextKey, v.name)
+	v.setHeaders(c)
+}
+
+func (v *Version) setHeaders(c *sol.Context) {
+	if !v.deprecated.IsZero() {
+		// RFC 9745 structured date: "@" followed by a unix timestamp.
+		c.SetHeader("Deprecation", "@"+strconv.FormatInt(v.deprecated.Unix(), 10))
+		if v.link != "" {
+			c.Writer.Header().Add("Link", "<"+v.link+`>; rel="deprecation"`)
+		}
+	}
+	if !v.sunset.IsZero() {
+		c.SetHeader("Sunset", v.sunset.UTC().Format(http.TimeFormat))
+	}
+}
+
+// Current returns the API version serving the request, or "".
+func Current(c *sol.Context) string {
+	s, _ := c.GetString(contextKey)
+	return s
+}
+
+func register(r Router, method, path string, h []sol.HandlerFunc) {
+	switch method {
+	case http.MethodGet:
+		r.GET(path, h...)
+	case http.MethodPost:
+		r.POST(path, h...)
+	case http.MethodPut:
+		r.PUT(path, h...)
+	case http.MethodDelete:
+		r.DELETE(path, h...)
+	case http.MethodPatch:
+		r.PATCH(path, h...)
+	case http.MethodOptions:
+		r.OPTIONS(path, h...)
+	case http.MethodHead:
+		r.HEAD(path, h...)
+	}
+}
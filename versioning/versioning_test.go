@@ -0,0 +1,103 @@
+// Package versioning
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestVersioning(t *testing.T) {
+	sunset := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	api := New(Config{
+		Default:        "2",
+		Path:           true,
+		Header:         "API-Version",
+		MediaTypeParam: "version",
+	})
+	v1 := api.Version("1", Deprecated(time.Unix(1700000000, 0), "https://example.com/migrate"), Sunset(sunset))
+	v1.GET("/users", func(c *sol.Context) { c.String(http.StatusOK, "v1:%s", Current(c)) })
+	v2 := api.Version("2")
+	v2.GET("/users", func(c *sol.Context) { c.String(http.StatusOK, "v2:%s", Current(c)) })
+
+	sl := sol.New()
+	api.Mount(sl.Group("/api"))
+
+	tests := []struct {
+		name        string
+		path        string
+		header      string
+		accept      string
+		body        string
+		deprecation string
+	}{
+		{"default", "/api/users", "", "", "v2:2", ""},
+		{"path", "/api/v1/users", "", "", "v1:1", "@1700000000"},
+		{"header", "/api/users", "1", "", "v1:1", "@1700000000"},
+		{"accept", "/api/users", "", "application/json; version=1", "v1:1", "@1700000000"},
+		{"unsupported", "/api/users", "9", "", `Unsupported API version "9"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("API-Version", tt.header)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			sl.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+			}
+			if got := rec.Header().Get("Deprecation"); got != tt.deprecation {
+				t.Errorf("Deprecation = %q, want %q", got, tt.deprecation)
+			}
+			wantVary := "API-Version,Accept"
+			if tt.path == "/api/v1/users" {
+				wantVary = ""
+			}
+			if got := strings.Join(rec.Header().Values("Vary"), ","); got != wantVary {
+				t.Errorf("Vary = %q, want %q", got, wantVary)
+			}
+			if tt.deprecation != "" && rec.Header().Get("Sunset") != "Fri, 01 Jan 2027 00:00:00 GMT" {
+				t.Errorf("Sunset = %q", rec.Header().Get("Sunset"))
+			}
+		})
+	}
+}
+
+func TestVersioning_Middleware(t *testing.T) {
+	api := New(Config{Default: "1"})
+	api.Version("1").GET("/users",
+		func(c *sol.Context) {
+			c.SetHeader("X-Before", "yes")
+			c.Next()
+		},
+		func(c *sol.Context) { c.String(http.StatusOK, "users") },
+	)
+
+	var after bool
+	sl := sol.New()
+	sl.Use(func(c *sol.Context) {
+		c.Next()
+		after = true
+	})
+	api.Mount(sl)
+
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	if rec.Body.String() != "users" || rec.Header().Get("X-Before") != "yes" {
+		t.Errorf("body = %q, headers = %v", rec.Body.String(), rec.Header())
+	}
+	if !after {
+		t.Error("outer middleware did not resume after the version chain")
+	}
+}
@@ -0,0 +1,250 @@
+// Package webhook
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrInvalidTimestamp = errors.New("webhook: invalid timestamp")
+	ErrExpired          = errors.New("webhook: timestamp outside tolerance")
+	ErrNoSecret         = errors.New("webhook: empty secret")
+	ErrBodyTooLarge     = errors.New("webhook: body too large")
+)
+
+// DefaultTolerance is the accepted clock skew for timestamped signatures.
+const DefaultTolerance = 5 * time.Minute
+
+// DefaultMaxBody bounds the body read to check a signature.
+const DefaultMaxBody = 10 << 20
+
+// Scheme describes how a provider signs its webhooks with HMAC-SHA256.
+type Scheme struct {
+	// Secret is the shared signing secret.
+	Secret string
+	// SignatureHeader holds the hex encoded signature.
+	SignatureHeader string
+	// SignaturePrefix is stripped from the header value, e.g. "sha256=".
+	SignaturePrefix string
+	// TimestampHeader holds the unix timestamp, if the provider sends one.
+	TimestampHeader string
+	// Tolerance bounds the age of the timestamp, DefaultTolerance if zero.
+	Tolerance time.Duration
+	// MaxBody bounds the body read before the signature is checked,
+	// DefaultMaxBody if zero, so unsigned callers cannot exhaust memory.
+	MaxBody int64
+	// Signed builds the signed content from the timestamp and body.
+	// The body alone is signed if nil.
+	Signed func(timestamp string, body []byte) []byte
+	// Parse extracts the timestamp and candidate signatures from the headers.
+	// The default reads TimestampHeader and SignatureHeader.
+	Parse func(h http.Header) (timestamp string, signatures []string, err error)
+}
+
+// GitHub verifies X-Hub-Signature-256 signatures.
+func GitHub(secret string) *Scheme {
+	return &Scheme{
+		Secret:          secret,
+		SignatureHeader: "X-Hub-Signature-256",
+		SignaturePrefix: "sha256=",
+	}
+}
+
+// Slack verifies X-Slack-Signature signatures with X-Slack-Request-Timestamp.
+func Slack(secret string) *Scheme {
+	return &Scheme{
+		Secret:          secret,
+		SignatureHeader: "X-Slack-Signature",
+		SignaturePrefix: "v0=",
+		TimestampHeader: "X-Slack-Request-Timestamp",
+		Signed: func(ts string, body []byte) []byte {
+			return append([]byte("v0:"+ts+":"), body...)
+		},
+	}
+}
+
+// Stripe verifies Stripe-Signature headers ("t=...,v1=...,v1=...").
+func Stripe(secret string) *Scheme {
+	return &Scheme{
+		Secret:          secret,
+		SignatureHeader: "Stripe-Signature",
+		Signed: func(ts string, body []byte) []byte {
+			return append([]byte(ts+"."), body...)
+		},
+		Parse: func(h http.Header) (string, []string, error) {
+			var (
+				ts   string
+				sigs []string
+			)
+			for part := range strings.SplitSeq(h.Get("Stripe-Signature"), ",") {
+				k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+				switch k {
+				case "t":
+					ts = v
+				case "v1":
+					sigs = append(sigs, v)
+				}
+			}
+			if ts == "" {
+				return "", nil, ErrInvalidTimestamp
+			}
+			return ts, sigs, nil
+		},
+	}
+}
+
+// HMAC verifies a generic "X-Signature" over "timestamp.body" with an
+// "X-Timestamp" header, a common convention for internal services.
+func HMAC(secret string) *Scheme {
+	return &Scheme{
+		Secret:          secret,
+		SignatureHeader: "X-Signature",
+		TimestampHeader: "X-Timestamp",
+		Signed: func(ts string, body []byte) []byte {
+			return append([]byte(ts+"."), body...)
+		},
+	}
+}
+
+// Sign returns the hex encoded signature of body at timestamp ts,
+// which is useful for tests and for sending webhooks. It fails with
+// ErrNoSecret if the scheme has no secret.
+func (s *Scheme) Sign(ts string, body []byte) (string, error) {
+	if s.Secret == "" {
+		return "", ErrNoSecret
+	}
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write(s.signed(ts, body))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (s *Scheme) signed(ts string, body []byte) []byte {
+	if s.Signed == nil {
+		return body
+	}
+	return s.Signed(ts, body)
+}
+
+// Verify checks the signature of body against the request headers.
+// A scheme without a secret rejects every request with ErrNoSecret.
+func (s *Scheme) Verify(h http.Header, body []byte, now time.Time) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+
+	parse := s.Parse
+	if parse == nil {
+		parse = s.parseHeaders
+	}
+
+	ts, sigs, err := parse(h)
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return ErrMissingSignature
+	}
+
+	if ts != "" {
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return ErrInvalidTimestamp
+		}
+		tolerance := s.Tolerance
+		if tolerance == 0 {
+			tolerance = DefaultTolerance
+		}
+		if d := now.Sub(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+			return ErrExpired
+		}
+	}
+
+	sig, err := s.Sign(ts, body)
+	if err != nil {
+		return err
+	}
+	expected, _ := hex.DecodeString(sig)
+	for _, sig := range sigs {
+		got, err := hex.DecodeString(strings.TrimPrefix(sig, s.SignaturePrefix))
+		if err == nil && hmac.Equal(got, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func (s *Scheme) parseHeaders(h http.Header) (string, []string, error) {
+	sig := h.Get(s.SignatureHeader)
+	if sig == "" {
+		return "", nil, ErrMissingSignature
+	}
+
+	var ts string
+	if s.TimestampHeader != "" {
+		if ts = h.Get(s.TimestampHeader); ts == "" {
+			return "", nil, ErrInvalidTimestamp
+		}
+	}
+	return ts, []string{sig}, nil
+}
+
+// Verify checks the request signature using the cached raw body, leaving
+// the body readable for binding afterwards. Bodies over MaxBody fail with
+// ErrBodyTooLarge.
+func Verify(c *sol.Context, s *Scheme) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+
+	maxBody := s.MaxBody
+	if maxBody <= 0 {
+		maxBody = DefaultMaxBody
+	}
+	if c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBody)
+	}
+	body, err := c.Body()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ErrBodyTooLarge
+		}
+		return fmt.Errorf("webhook: read body: %w", err)
+	}
+	return s.Verify(c.Request.Header, body, time.Now())
+}
+
+// Middleware rejects requests whose signature does not verify with 401,
+// and bodies over MaxBody with 413.
+func Middleware(s *Scheme) sol.HandlerFunc {
+	return func(c *sol.Context) {
+		err := Verify(c, s)
+		if errors.Is(err, ErrBodyTooLarge) {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			log.Printf("[WARN] %v | %s %s", err, c.Method(), c.Path())
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
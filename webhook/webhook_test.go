@@ -0,0 +1,137 @@
+// Package webhook
+// Copyright 2026 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wantnotshould/sol"
+)
+
+func TestSchemes(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	old := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+
+	gh := GitHub("s3cret")
+	slack := Slack("s3cret")
+	stripe := Stripe("s3cret")
+	sign := func(s *Scheme, ts string) string {
+		sig, err := s.Sign(ts, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	tests := []struct {
+		name   string
+		scheme *Scheme
+		header http.Header
+		want   error
+	}{
+		{"github ok", gh, http.Header{"X-Hub-Signature-256": {"sha256=" + sign(gh, "")}}, nil},
+		{"github bad", gh, http.Header{"X-Hub-Signature-256": {"sha256=00"}}, ErrInvalidSignature},
+		{"github missing", gh, http.Header{}, ErrMissingSignature},
+		{"empty secret", GitHub(""), http.Header{"X-Hub-Signature-256": {"sha256=" + emptyKeySig(body)}}, ErrNoSecret},
+		{"slack ok", slack, http.Header{
+			"X-Slack-Signature":         {"v0=" + sign(slack, ts)},
+			"X-Slack-Request-Timestamp": {ts},
+		}, nil},
+		{"slack expired", slack, http.Header{
+			"X-Slack-Signature":         {"v0=" + sign(slack, old)},
+			"X-Slack-Request-Timestamp": {old},
+		}, ErrExpired},
+		{"stripe ok", stripe, http.Header{
+			"Stripe-Signature": {"t=" + ts + ",v1=deadbeef,v1=" + sign(stripe, ts)},
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.scheme.Verify(tt.header, body, now); !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// emptyKeySig is what an attacker computes against an unset secret.
+func emptyKeySig(body []byte) string {
+	mac := hmac.New(sha256.New, nil)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignEmptySecret(t *testing.T) {
+	if _, err := GitHub("").Sign("", []byte("x")); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("Sign = %v, want ErrNoSecret", err)
+	}
+}
+
+func TestMiddlewareMaxBody(t *testing.T) {
+	gh := GitHub("s3cret")
+	gh.MaxBody = 16
+
+	sl := sol.New()
+	sl.POST("/hook", Middleware(gh), func(c *sol.Context) { c.String(http.StatusOK, "ok") })
+
+	for _, tt := range []struct {
+		body   string
+		status int
+	}{
+		{"small", http.StatusOK},
+		{strings.Repeat("x", 17), http.StatusRequestEntityTooLarge},
+	} {
+		sig, _ := gh.Sign("", []byte(tt.body))
+		req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(tt.body))
+		req.Header.Set("X-Hub-Signature-256", "sha256="+sig)
+		rec := httptest.NewRecorder()
+		sl.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%d byte body: status = %d, want %d", len(tt.body), rec.Code, tt.status)
+		}
+	}
+}
+
+func TestMiddlewareKeepsBody(t *testing.T) {
+	gh := GitHub("s3cret")
+	body := `{"id":1}`
+
+	sl := sol.New()
+	sl.POST("/hook", Middleware(gh), func(c *sol.Context) {
+		b, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "%s", b)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	sig, _ := gh.Sign("", []byte(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sig)
+	rec := httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	sl.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned request: status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,81 @@
+// Package sol
+// Copyright 2025 wantnotshould. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+package sol
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// responseWriter is the Context.Writer of every request. It records
+// whether the response was committed, so error handling can tell whether
+// a status can still be sent.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *responseWriter) reset(rw http.ResponseWriter) {
+	w.ResponseWriter = rw
+	w.status = 0
+	w.size = 0
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	// 1xx responses are informational and leave the response open.
+	if w.status == 0 && code >= http.StatusOK {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher, committing the response.
+func (w *responseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements http.Hijacker for WebSocket and similar upgrades.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Written reports whether the response status and headers were sent, after
+// which the status can no longer change.
+func (c *Context) Written() bool {
+	return c.rw.status != 0
+}
+
+// ResponseStatus returns the status sent so far, 0 if not yet committed.
+func (c *Context) ResponseStatus() int {
+	return c.rw.status
+}
+
+// ResponseSize returns the number of body bytes written so far.
+func (c *Context) ResponseSize() int64 {
+	return c.rw.size
+}